@@ -0,0 +1,143 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/Postcord/objects"
+)
+
+// AttachmentOptions is used to define the options for opening an attachment.
+type AttachmentOptions struct {
+	// MaxSize is the maximum size of the attachment in bytes. If this is 0, the size is not limited.
+	MaxSize int64
+
+	// ContentTypes is the content types which are allowed. A type ending in "/" (such as "image/") allows any sub-type.
+	// If this is empty, all content types are allowed.
+	ContentTypes []string
+
+	// HTTPClient is the client used to download the attachment. If this is nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// AttachmentNotFound is thrown when the option is not set to an attachment which Discord resolved.
+var AttachmentNotFound = errors.New("attachment option is not set or was not resolved")
+
+// AttachmentTooLarge is thrown when the attachment is larger than the maximum size.
+var AttachmentTooLarge = errors.New("attachment is too large")
+
+// AttachmentContentTypeNotAllowed is thrown when the content type of the attachment is not allowed.
+var AttachmentContentTypeNotAllowed = errors.New("attachment content type is not allowed")
+
+// Checks if the content type is allowed.
+func (o *AttachmentOptions) contentTypeAllowed(contentType string) bool {
+	if len(o.ContentTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, v := range o.ContentTypes {
+		if mediaType == v || (strings.HasSuffix(v, "/") && strings.HasPrefix(mediaType, v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Used to stop reading once the maximum size is exceeded. Discord reports the size of the attachment, but the body is
+// also limited in case the size is wrong.
+type attachmentReader struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (r *attachmentReader) Read(p []byte) (int, error) {
+	if r.remaining < 0 {
+		// The limit was already exceeded by a previous read.
+		return 0, AttachmentTooLarge
+	}
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.ReadCloser.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		// Only return the bytes which were within the limit.
+		n += int(r.remaining)
+		if n < 0 {
+			n = 0
+		}
+		return n, AttachmentTooLarge
+	}
+	return n, err
+}
+
+// OpenAttachment is used to stream the contents of the attachment option specified. The size and content type of the
+// attachment are checked against the options before it is downloaded, and the body is limited to the maximum size. If
+// opts is nil, the default options are used. The caller must close the reader.
+func (c *CommandRouterCtx) OpenAttachment(option string, opts *AttachmentOptions) (io.ReadCloser, *objects.Attachment, error) {
+	if opts == nil {
+		opts = &AttachmentOptions{}
+	}
+
+	// Get the attachment.
+	resolvable, _ := c.Options[option].(ResolvableAttachment)
+	if resolvable == nil {
+		return nil, nil, AttachmentNotFound
+	}
+	attachment := resolvable.Resolve()
+	if attachment == nil {
+		return nil, nil, AttachmentNotFound
+	}
+
+	// Check the attachment before downloading it.
+	if opts.MaxSize != 0 && int64(attachment.Size) > opts.MaxSize {
+		return nil, attachment, AttachmentTooLarge
+	}
+	if !opts.contentTypeAllowed(attachment.ContentType) {
+		return nil, attachment, AttachmentContentTypeNotAllowed
+	}
+
+	// Download the attachment.
+	ctx := c.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, attachment.URL, nil)
+	if err != nil {
+		return nil, attachment, fmt.Errorf("failed to create attachment request: %w", err)
+	}
+	client := opts.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, attachment, fmt.Errorf("failed to download attachment: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_ = resp.Body.Close()
+		return nil, attachment, fmt.Errorf("failed to download attachment: %s", resp.Status)
+	}
+
+	// Check what was actually sent.
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" && !opts.contentTypeAllowed(contentType) {
+		_ = resp.Body.Close()
+		return nil, attachment, AttachmentContentTypeNotAllowed
+	}
+	if opts.MaxSize != 0 {
+		if resp.ContentLength > opts.MaxSize {
+			_ = resp.Body.Close()
+			return nil, attachment, AttachmentTooLarge
+		}
+		return &attachmentReader{ReadCloser: resp.Body, remaining: opts.MaxSize}, attachment, nil
+	}
+	return resp.Body, attachment, nil
+}
@@ -0,0 +1,132 @@
+package router
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandRouterCtx_OpenAttachment(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cat.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte("meow"))
+		case "/liar.png":
+			w.Header().Set("Content-Type", "application/x-msdownload")
+			_, _ = w.Write([]byte("MZ"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+
+		attachment *objects.Attachment
+		option     string
+		opts       *AttachmentOptions
+
+		expects    string
+		expectsErr string
+	}{
+		{
+			name:       "success",
+			attachment: &objects.Attachment{URL: srv.URL + "/cat.png", ContentType: "image/png", Size: 4},
+			opts:       &AttachmentOptions{MaxSize: 4, ContentTypes: []string{"image/"}},
+			expects:    "meow",
+		},
+		{
+			name:       "default options",
+			attachment: &objects.Attachment{URL: srv.URL + "/cat.png", ContentType: "image/png", Size: 4},
+			expects:    "meow",
+		},
+		{
+			name:       "option not set",
+			option:     "other",
+			attachment: &objects.Attachment{URL: srv.URL + "/cat.png"},
+			expectsErr: "attachment option is not set or was not resolved",
+		},
+		{
+			name:       "too large",
+			attachment: &objects.Attachment{URL: srv.URL + "/cat.png", ContentType: "image/png", Size: 4},
+			opts:       &AttachmentOptions{MaxSize: 3},
+			expectsErr: "attachment is too large",
+		},
+		{
+			name:       "size is wrong",
+			attachment: &objects.Attachment{URL: srv.URL + "/cat.png", ContentType: "image/png", Size: 1},
+			opts:       &AttachmentOptions{MaxSize: 3},
+			expectsErr: "attachment is too large",
+		},
+		{
+			name:       "content type not allowed",
+			attachment: &objects.Attachment{URL: srv.URL + "/cat.png", ContentType: "image/png"},
+			opts:       &AttachmentOptions{ContentTypes: []string{"text/plain"}},
+			expectsErr: "attachment content type is not allowed",
+		},
+		{
+			name:       "served content type not allowed",
+			attachment: &objects.Attachment{URL: srv.URL + "/liar.png", ContentType: "image/png"},
+			opts:       &AttachmentOptions{ContentTypes: []string{"image/png"}},
+			expectsErr: "attachment content type is not allowed",
+		},
+		{
+			name:       "not found",
+			attachment: &objects.Attachment{URL: srv.URL + "/dog.png"},
+			expectsErr: "failed to download attachment: 404 Not Found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &objects.ApplicationCommandInteractionData{
+				Resolved: objects.ApplicationCommandInteractionDataResolved{
+					Attachments: map[objects.Snowflake]objects.Attachment{1: *tt.attachment},
+				},
+			}
+			option := tt.option
+			if option == "" {
+				option = "file"
+			}
+			ctx := &CommandRouterCtx{Options: map[string]any{
+				option: (ResolvableAttachment)(resolvable[objects.Attachment]{id: "1", data: data}),
+			}}
+			r, attachment, err := ctx.OpenAttachment("file", tt.opts)
+			if err == nil {
+				defer r.Close()
+				var b []byte
+				b, err = io.ReadAll(r)
+				if err == nil {
+					assert.Equal(t, tt.expects, string(b))
+					assert.Equal(t, tt.attachment, attachment)
+				}
+			}
+			if tt.expectsErr == "" {
+				require.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectsErr)
+			}
+		})
+	}
+}
+
+func Test_attachmentReader_Read(t *testing.T) {
+	r := &attachmentReader{ReadCloser: io.NopCloser(strings.NewReader("meow")), remaining: 3}
+	p := make([]byte, 10)
+	n, err := r.Read(p)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, AttachmentTooLarge, err)
+	assert.Equal(t, "meo", string(p[:n]))
+
+	// Reading again after the limit is exceeded should not return a negative count.
+	n, err = r.Read(p)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, AttachmentTooLarge, err)
+}
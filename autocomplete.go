@@ -0,0 +1,168 @@
+package router
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/Postcord/objects"
+)
+
+// AutocompleteCtx is used to define the context for an auto-complete handler. The locale of the user is available from
+// the embedded interaction as Locale.
+type AutocompleteCtx struct {
+	*CommandRouterCtx
+
+	// Focused is the name of the option which the user is typing in.
+	Focused string
+
+	// Input is the raw partial input for the focused option. This is what the user has typed so far, so it is always a
+	// string even for int and double options.
+	Input string
+
+	// Defines the option type of the focused option.
+	focusedType objects.ApplicationCommandOptionType
+
+	// Defines the choices which will be sent to Discord.
+	choices []*objects.ApplicationCommandOptionChoice
+}
+
+// AutocompleteFunc is used to define an auto-complete handler which uses the AutocompleteCtx. The choices are added
+// with AddChoice.
+type AutocompleteFunc = func(*AutocompleteCtx) error
+
+// AddChoice is used to add a choice to the auto-complete result. Names longer than 100 characters are truncated. The
+// value must match the type of the focused option. If there are already 25 choices, TooManyChoices is returned, and
+// InvalidChoice is returned if the name is empty or the value is not valid.
+func (c *AutocompleteCtx) AddChoice(name string, value any) error {
+	if len(c.choices) == 25 {
+		return TooManyChoices
+	}
+	if name == "" {
+		return InvalidChoice
+	}
+	if utf8.RuneCountInString(name) > 100 {
+		name = string([]rune(name)[:100])
+	}
+	switch c.focusedType {
+	case objects.TypeString:
+		s, ok := value.(string)
+		if !ok || s == "" || utf8.RuneCountInString(s) > 100 {
+			return InvalidChoice
+		}
+	case objects.TypeInteger:
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			return InvalidChoice
+		}
+	case objects.TypeNumber:
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			return InvalidChoice
+		}
+	}
+	c.choices = append(c.choices, &objects.ApplicationCommandOptionChoice{Name: name, Value: value})
+	return nil
+}
+
+// Choices is used to get the choices which have been added.
+func (c *AutocompleteCtx) Choices() []*objects.ApplicationCommandOptionChoice {
+	return c.choices
+}
+
+// Parses the partial string values Discord sends for int and double options during auto-complete. Values which cannot
+// be parsed are removed since they are not usable.
+func (c *Command) parseAutocompleteOptions(options map[string]any) {
+	for _, option := range c.Options {
+		s, ok := options[option.Name].(string)
+		if !ok {
+			continue
+		}
+		switch option.OptionType {
+		case objects.TypeInteger:
+			if i, err := strconv.Atoi(s); err == nil {
+				options[option.Name] = i
+			} else {
+				delete(options, option.Name)
+			}
+		case objects.TypeNumber:
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				options[option.Name] = f
+			} else {
+				delete(options, option.Name)
+			}
+		}
+	}
+}
+
+// Runs the auto-complete function for the focused option.
+func (c *Command) runAutocomplete(f any, ctx *CommandRouterCtx, focused *objects.ApplicationCommandInteractionDataOption) ([]*objects.ApplicationCommandOptionChoice, error) {
+	var resultOptions []*objects.ApplicationCommandOptionChoice
+	switch x := f.(type) {
+	case StringAutoCompleteFunc:
+		stringifiedOptions, err := x(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resultOptions = make([]*objects.ApplicationCommandOptionChoice, len(stringifiedOptions))
+		for i, v := range stringifiedOptions {
+			resultOptions[i] = &objects.ApplicationCommandOptionChoice{
+				Name:  v.Name,
+				Value: v.Value,
+			}
+		}
+	case IntAutoCompleteFunc:
+		intOptions, err := x(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resultOptions = make([]*objects.ApplicationCommandOptionChoice, len(intOptions))
+		for i, v := range intOptions {
+			resultOptions[i] = &objects.ApplicationCommandOptionChoice{
+				Name:  v.Name,
+				Value: v.Value,
+			}
+		}
+	case DoubleAutoCompleteFunc:
+		doubleOptions, err := x(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resultOptions = make([]*objects.ApplicationCommandOptionChoice, len(doubleOptions))
+		for i, v := range doubleOptions {
+			resultOptions[i] = &objects.ApplicationCommandOptionChoice{
+				Name:  v.Name,
+				Value: v.Value,
+			}
+		}
+	case AutocompleteFunc:
+		// Check choices against the declared type since Discord may send partial values with a different type.
+		focusedType := focused.Type
+		if option := findOption(focused.Name, c.Options); option != nil {
+			focusedType = option.OptionType
+		}
+		autocompleteCtx := &AutocompleteCtx{
+			CommandRouterCtx: ctx,
+			Focused:          focused.Name,
+			Input:            fmt.Sprint(focused.Value),
+			focusedType:      focusedType,
+		}
+		c.parseAutocompleteOptions(ctx.Options)
+		if err := x(autocompleteCtx); err != nil {
+			return nil, err
+		}
+		resultOptions = autocompleteCtx.choices
+		if resultOptions == nil {
+			resultOptions = []*objects.ApplicationCommandOptionChoice{}
+		}
+	default:
+		panic("postcord internal error - unknown autocomplete type")
+	}
+	return resultOptions, nil
+}
@@ -0,0 +1,237 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutocompleteCtx_AddChoice(t *testing.T) {
+	tests := []struct {
+		name string
+
+		focusedType objects.ApplicationCommandOptionType
+		existing    int
+		choiceName  string
+		value       any
+
+		wantsErr  error
+		wantsName string
+	}{
+		{
+			name:        "string",
+			focusedType: objects.TypeString,
+			choiceName:  "a",
+			value:       "b",
+			wantsName:   "a",
+		},
+		{
+			name:        "int",
+			focusedType: objects.TypeInteger,
+			choiceName:  "a",
+			value:       1,
+			wantsName:   "a",
+		},
+		{
+			name:        "double with int",
+			focusedType: objects.TypeNumber,
+			choiceName:  "a",
+			value:       1,
+			wantsName:   "a",
+		},
+		{
+			name:        "double",
+			focusedType: objects.TypeNumber,
+			choiceName:  "a",
+			value:       1.5,
+			wantsName:   "a",
+		},
+		{
+			name:        "long name truncated",
+			focusedType: objects.TypeString,
+			choiceName:  strings.Repeat("é", 101),
+			value:       "b",
+			wantsName:   strings.Repeat("é", 100),
+		},
+		{
+			name:        "empty name",
+			focusedType: objects.TypeString,
+			value:       "b",
+			wantsErr:    InvalidChoice,
+		},
+		{
+			name:        "long string value",
+			focusedType: objects.TypeString,
+			choiceName:  "a",
+			value:       strings.Repeat("a", 101),
+			wantsErr:    InvalidChoice,
+		},
+		{
+			name:        "int with string",
+			focusedType: objects.TypeInteger,
+			choiceName:  "a",
+			value:       "1",
+			wantsErr:    InvalidChoice,
+		},
+		{
+			name:        "int with double",
+			focusedType: objects.TypeInteger,
+			choiceName:  "a",
+			value:       1.5,
+			wantsErr:    InvalidChoice,
+		},
+		{
+			name:        "too many choices",
+			focusedType: objects.TypeString,
+			existing:    25,
+			choiceName:  "a",
+			value:       "b",
+			wantsErr:    TooManyChoices,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &AutocompleteCtx{focusedType: tt.focusedType}
+			for i := 0; i < tt.existing; i++ {
+				require.NoError(t, ctx.AddChoice("x", "x"))
+			}
+			err := ctx.AddChoice(tt.choiceName, tt.value)
+			if tt.wantsErr != nil {
+				assert.ErrorIs(t, err, tt.wantsErr)
+				assert.Len(t, ctx.Choices(), tt.existing)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, ctx.Choices(), 1)
+			assert.Equal(t, tt.wantsName, ctx.Choices()[0].Name)
+			assert.Equal(t, tt.value, ctx.Choices()[0].Value)
+		})
+	}
+}
+
+func TestCommandRouter_Autocomplete(t *testing.T) {
+	r := &CommandRouter{}
+	middlewareCalls := 0
+	r.Use(func(ctx MiddlewareCtx) error {
+		middlewareCalls++
+		if ctx.Options["query"] == "blocked" {
+			return errors.New("blocked")
+		}
+		return ctx.Next()
+	})
+	var got *AutocompleteCtx
+	r.NewCommandBuilder("search").
+		StringOption("query", "The query.", true, nil).
+		IntOption("limit", "The limit.", false, nil).
+		DoubleOption("score", "The score.", false, nil).
+		Autocomplete("query", func(ctx *AutocompleteCtx) error {
+			got = ctx
+			if ctx.Input == "fail" {
+				return errors.New("failed")
+			}
+			return ctx.AddChoice("Result", ctx.Input+"!")
+		}).
+		Handler(func(ctx *CommandRouterCtx) error { return nil }).
+		MustBuild()
+
+	var errResult error
+	_, handler := r.build(loaderPassthrough{
+		rest: dummyRestClient,
+		errHandler: func(err error) *objects.InteractionResponse {
+			errResult = err
+			return nil
+		},
+	})
+	interaction := func(query string) *objects.Interaction {
+		interaction := mockInteraction(&objects.ApplicationCommandInteractionData{
+			Name: "search",
+			Type: objects.CommandTypeChatInput,
+			Options: []*objects.ApplicationCommandInteractionDataOption{
+				{Name: "query", Type: objects.TypeString, Value: query, Focused: true},
+				{Name: "limit", Type: objects.TypeString, Value: "10"},
+				{Name: "score", Type: objects.TypeString, Value: "1."},
+			},
+		})
+		interaction.Locale = "en-GB"
+		return interaction
+	}
+
+	resp := handler(context.Background(), interaction("abc"))
+	require.NoError(t, errResult)
+	assert.Equal(t, &objects.InteractionResponse{
+		Type: objects.ResponseCommandAutocompleteResult,
+		Data: &objects.InteractionApplicationCommandCallbackData{
+			Choices: []*objects.ApplicationCommandOptionChoice{{Name: "Result", Value: "abc!"}},
+		},
+	}, resp)
+	require.NotNil(t, got)
+	assert.Equal(t, "query", got.Focused)
+	assert.Equal(t, "abc", got.Input)
+	assert.Equal(t, "en-GB", got.Interaction.Locale)
+	assert.Equal(t, map[string]any{"query": "abc", "limit": 10, "score": 1.0}, got.Options)
+
+	resp = handler(context.Background(), interaction("fail"))
+	assert.Nil(t, resp)
+	assert.EqualError(t, errResult, "failed")
+
+	got = nil
+	resp = handler(context.Background(), interaction("blocked"))
+	assert.Nil(t, resp)
+	assert.EqualError(t, errResult, "blocked")
+	assert.Nil(t, got)
+	assert.Equal(t, 3, middlewareCalls)
+}
+
+func TestCommandRouter_Autocomplete_declaredType(t *testing.T) {
+	r := &CommandRouter{}
+	var errs []error
+	r.NewCommandBuilder("count").
+		IntOption("n", "The number.", true, nil).
+		Autocomplete("n", func(ctx *AutocompleteCtx) error {
+			errs = append(errs, ctx.AddChoice("String", "1"), ctx.AddChoice("Int", 1))
+			return nil
+		}).
+		Handler(func(ctx *CommandRouterCtx) error { return nil }).
+		MustBuild()
+	_, handler := r.build(loaderPassthrough{rest: dummyRestClient})
+
+	// Discord sends the partial value of the focused option as a string.
+	resp := handler(context.Background(), mockInteraction(&objects.ApplicationCommandInteractionData{
+		Name: "count",
+		Type: objects.CommandTypeChatInput,
+		Options: []*objects.ApplicationCommandInteractionDataOption{
+			{Name: "n", Type: objects.TypeString, Value: "1", Focused: true},
+		},
+	}))
+	require.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0], InvalidChoice)
+	assert.NoError(t, errs[1])
+	require.NotNil(t, resp)
+	assert.Equal(t, []*objects.ApplicationCommandOptionChoice{{Name: "Int", Value: 1}}, resp.Data.Choices)
+}
+
+func TestCommand_parseAutocompleteOptions(t *testing.T) {
+	cmd := &Command{Options: []*objects.ApplicationCommandOption{
+		{Name: "str", OptionType: objects.TypeString},
+		{Name: "int", OptionType: objects.TypeInteger},
+		{Name: "bad_int", OptionType: objects.TypeInteger},
+		{Name: "double", OptionType: objects.TypeNumber},
+		{Name: "bad_double", OptionType: objects.TypeNumber},
+		{Name: "mapped", OptionType: objects.TypeInteger},
+	}}
+	options := map[string]any{
+		"str":        "1",
+		"int":        "1",
+		"bad_int":    "1.",
+		"double":     "1.5",
+		"bad_double": "",
+		"mapped":     2,
+	}
+	cmd.parseAutocompleteOptions(options)
+	assert.Equal(t, map[string]any{"str": "1", "int": 1, "double": 1.5, "mapped": 2}, options)
+}
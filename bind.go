@@ -0,0 +1,326 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Postcord/objects"
+)
+
+// InvalidBindTarget is thrown when the data passed to Bind is not a pointer to a struct.
+var InvalidBindTarget = errors.New("data must be a pointer to a struct")
+
+// IncompatibleBindField is thrown when an option cannot be bound to the type of the field.
+var IncompatibleBindField = errors.New("option cannot be bound to the field type")
+
+// BindValueOverflow is thrown when a numeric option does not fit in the type of the field.
+var BindValueOverflow = errors.New("option value overflows the field type")
+
+// UnresolvedBindValue is thrown when an option is bound to a resolved type but Discord did not send the resolved data.
+var UnresolvedBindValue = errors.New("option value was not resolved")
+
+// InvalidBindDefault is thrown when the default tag on a field cannot be parsed into the type of the field.
+var InvalidBindDefault = errors.New("default value cannot be parsed into the field type")
+
+// UnsupportedBindField is thrown when a struct field is not embedded and is not tagged with the name of a sub-command.
+var UnsupportedBindField = errors.New("struct fields must be embedded or tagged with the name of a sub-command")
+
+// UnexportedBindPointer is thrown when a struct embeds a nil pointer to an unexported struct, since it cannot be
+// allocated.
+var UnexportedBindPointer = errors.New("cannot allocate an embedded pointer to an unexported struct")
+
+// BindError is thrown when an option cannot be bound to a field. The underlying error is one of the errors above, so
+// errors.Is can be used to check what went wrong.
+type BindError struct {
+	// Option is the name of the option.
+	Option string
+
+	// Field is the name of the struct field.
+	Field string
+
+	// Type is the type of the struct field.
+	Type reflect.Type
+
+	// Err is the bind error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *BindError) Error() string {
+	return fmt.Sprintf("failed to bind option %q to field %s (%s): %v", e.Option, e.Field, e.Type, e.Err)
+}
+
+// Unwrap is used to get the bind error.
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// Defines the types used when binding.
+var (
+	snowflakeType   = reflect.TypeOf(objects.Snowflake(0))
+	guildMemberType = reflect.TypeOf(objects.GuildMember{})
+)
+
+// Gets the number from the option value. Integer options are an int, and number options are a float64.
+func bindNumber(value any) (i int64, f float64, isInt, ok bool) {
+	switch x := value.(type) {
+	case int:
+		return int64(x), float64(x), true, true
+	case float64:
+		return int64(x), x, x == float64(int64(x)), true
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return i, float64(i), true, true
+		}
+		f, err := x.Float64()
+		return int64(f), f, false, err == nil
+	}
+	return 0, 0, false, false
+}
+
+// Binds a numeric option value to the field.
+func bindNumeric(field reflect.Value, value any) error {
+	i, f, isInt, ok := bindNumber(value)
+	if !ok {
+		return IncompatibleBindField
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !isInt {
+			return IncompatibleBindField
+		}
+		if field.OverflowInt(i) {
+			return BindValueOverflow
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !isInt {
+			return IncompatibleBindField
+		}
+		if i < 0 || field.OverflowUint(uint64(i)) {
+			return BindValueOverflow
+		}
+		field.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		if field.OverflowFloat(f) {
+			return BindValueOverflow
+		}
+		field.SetFloat(f)
+	default:
+		return IncompatibleBindField
+	}
+	return nil
+}
+
+// Binds a resolvable option value to the field. The field can be the resolvable, a snowflake, or the resolved type.
+func bindResolvable(field reflect.Value, value any) error {
+	// Handle snowflakes.
+	if field.Type() == snowflakeType {
+		if x, ok := value.(interface{ Snowflake() objects.Snowflake }); ok {
+			field.SetUint(uint64(x.Snowflake()))
+			return nil
+		}
+		return IncompatibleBindField
+	}
+
+	// Handle members, which are resolved separately to users.
+	if x, ok := value.(ResolvableUser); ok && field.Type() == guildMemberType {
+		member := x.ResolveMember()
+		if member == nil {
+			return UnresolvedBindValue
+		}
+		field.Set(reflect.ValueOf(member).Elem())
+		return nil
+	}
+
+	// Resolve the value.
+	method := reflect.ValueOf(value).MethodByName("Resolve")
+	if !method.IsValid() {
+		return IncompatibleBindField
+	}
+	if out := method.Type().Out(0); out.Kind() == reflect.Ptr && !out.Elem().AssignableTo(field.Type()) {
+		return IncompatibleBindField
+	}
+	resolved := method.Call(nil)[0]
+	if resolved.Kind() == reflect.Interface {
+		// Mentionables resolve to any.
+		resolved = resolved.Elem()
+	}
+	if !resolved.IsValid() || resolved.IsNil() {
+		return UnresolvedBindValue
+	}
+	if !resolved.Elem().Type().AssignableTo(field.Type()) {
+		return IncompatibleBindField
+	}
+	field.Set(resolved.Elem())
+	return nil
+}
+
+// Binds an option value to the field.
+func bindValue(field reflect.Value, value any) error {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return IncompatibleBindField
+	}
+
+	// Handle pointers by binding to a new value.
+	if field.Kind() == reflect.Ptr && !v.Type().AssignableTo(field.Type()) {
+		ptr := reflect.New(field.Type().Elem())
+		if err := bindValue(ptr.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+
+	// Handle anything which can be assigned directly. This includes the resolvable interfaces.
+	if v.Type().AssignableTo(field.Type()) {
+		field.Set(v)
+		return nil
+	}
+
+	// Handle the conversions.
+	switch x := value.(type) {
+	case string:
+		if field.Kind() != reflect.String {
+			return IncompatibleBindField
+		}
+		field.SetString(x)
+	case bool:
+		if field.Kind() != reflect.Bool {
+			return IncompatibleBindField
+		}
+		field.SetBool(x)
+	case int, float64, json.Number:
+		return bindNumeric(field, value)
+	default:
+		return bindResolvable(field, value)
+	}
+	return nil
+}
+
+// Parses the default value from the tag into the field.
+func bindDefault(field reflect.Value, s string) error {
+	if field.Kind() == reflect.Ptr {
+		ptr := reflect.New(field.Type().Elem())
+		if err := bindDefault(ptr.Elem(), s); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return InvalidBindDefault
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, field.Type().Bits())
+		if err != nil {
+			return InvalidBindDefault
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 10, field.Type().Bits())
+		if err != nil {
+			return InvalidBindDefault
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, field.Type().Bits())
+		if err != nil {
+			return InvalidBindDefault
+		}
+		field.SetFloat(f)
+	default:
+		return InvalidBindDefault
+	}
+	return nil
+}
+
+// Checks if the field is a struct or a pointer to one which could be nested. Postcord objects are resolved rather than
+// nested.
+func isNestedBindStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t.PkgPath() != guildMemberType.PkgPath()
+}
+
+// Checks if the name is the name of the command being run or one of the sub-commands next to it.
+func (c *CommandRouterCtx) isSubcommandName(name string) bool {
+	if c.Command == nil || c.Command.parent == nil {
+		return false
+	}
+	_, ok := c.Command.parent.Subcommands[name].(*Command)
+	return ok
+}
+
+// Binds the options to the struct. Nested structs are bound if they are embedded or tagged with the command name.
+func (c *CommandRouterCtx) bindStruct(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get(selectorTagName)
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && isNestedBindStruct(field.Type) {
+			// Flatten embedded structs.
+			fieldValue := v.Field(i)
+			if fieldValue.Kind() == reflect.Ptr && fieldValue.IsNil() && !fieldValue.CanSet() {
+				return &BindError{Field: field.Name, Type: field.Type, Err: UnexportedBindPointer}
+			}
+			if err := c.bindNested(fieldValue); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" || !field.IsExported() {
+			continue
+		}
+		fieldValue := v.Field(i)
+
+		// Handle structs for sub-commands. Other structs (such as time.Time) cannot be bound to.
+		if isNestedBindStruct(field.Type) {
+			if !c.isSubcommandName(name) {
+				return &BindError{Option: name, Field: field.Name, Type: field.Type, Err: UnsupportedBindField}
+			}
+			if c.Command.Name == name {
+				if err := c.bindNested(fieldValue); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		// Bind the option or the default.
+		var err error
+		if option, ok := c.Options[name]; ok {
+			err = bindValue(fieldValue, option)
+		} else if s, ok := field.Tag.Lookup("default"); ok {
+			err = bindDefault(fieldValue, s)
+		}
+		if err != nil {
+			return &BindError{Option: name, Field: field.Name, Type: field.Type, Err: err}
+		}
+	}
+	return nil
+}
+
+// Binds to a nested struct, allocating it if it is a pointer.
+func (c *CommandRouterCtx) bindNested(v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	return c.bindStruct(v)
+}
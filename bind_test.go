@@ -0,0 +1,259 @@
+package router
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var bindTestData = &objects.ApplicationCommandInteractionData{
+	Resolved: objects.ApplicationCommandInteractionDataResolved{
+		Users: map[objects.Snowflake]objects.User{
+			1: {DiscordBaseObject: objects.DiscordBaseObject{ID: 1}, Username: "jeff"},
+		},
+		Members: map[objects.Snowflake]objects.GuildMember{
+			1: {Nick: "jeffrey"},
+		},
+		Channels: map[objects.Snowflake]objects.Channel{
+			2: {DiscordBaseObject: objects.DiscordBaseObject{ID: 2}, Name: "general"},
+		},
+		Attachments: map[objects.Snowflake]objects.Attachment{
+			3: {DiscordBaseObject: objects.DiscordBaseObject{ID: 3}, Filename: "cat.png"},
+		},
+	},
+}
+
+func bindTestCtx(options map[string]any) *CommandRouterCtx {
+	group := &CommandGroup{Subcommands: map[string]any{}}
+	ban := &Command{Name: "ban", parent: group}
+	group.Subcommands["ban"] = ban
+	group.Subcommands["kick"] = &Command{Name: "kick", parent: group}
+	return &CommandRouterCtx{Command: ban, Options: options}
+}
+
+func TestCommandRouterCtx_Bind_types(t *testing.T) {
+	type nested struct {
+		Reason string `discord:"reason"`
+	}
+	type embedded struct {
+		Days uint8 `discord:"days"`
+	}
+	type args struct {
+		embedded
+		Int8      int8                 `discord:"int"`
+		Uint      uint                 `discord:"int"`
+		Float32   float32              `discord:"double"`
+		IntPtr    *int64               `discord:"int"`
+		Unset     *string              `discord:"unset"`
+		Default   int                  `discord:"unset" default:"5"`
+		DefPtr    *bool                `discord:"unset" default:"true"`
+		UserID    objects.Snowflake    `discord:"user"`
+		User      *objects.User        `discord:"user"`
+		Member    objects.GuildMember  `discord:"user"`
+		Resolv    ResolvableUser       `discord:"user"`
+		Channel   objects.Channel      `discord:"channel"`
+		Mention   *objects.Channel     `discord:"mentionable"`
+		File      *objects.Attachment  `discord:"attachment"`
+		Ban       *nested              `discord:"ban"`
+		Kick      *nested              `discord:"kick"`
+		Attach    ResolvableAttachment `discord:"attachment"`
+		unexposed string               `discord:"reason"`
+	}
+	user := (ResolvableUser)(resolvableUser{resolvable[objects.User]{id: "1", data: bindTestData}})
+	attachment := (ResolvableAttachment)(resolvable[objects.Attachment]{id: "3", data: bindTestData})
+	ctx := bindTestCtx(map[string]any{
+		"int":    100,
+		"double": 1.5,
+		"days":   7,
+		"reason": "spam",
+		"user":   user,
+		"channel": (ResolvableChannel)(resolvable[objects.Channel]{
+			id: "2", data: bindTestData,
+		}),
+		"mentionable": (ResolvableMentionable)(resolvableMentionable{resolvable[any]{id: "2", data: bindTestData}}),
+		"attachment":  attachment,
+	})
+
+	var a args
+	require.NoError(t, ctx.Bind(&a))
+	i := int64(100)
+	tr := true
+	assert.Equal(t, args{
+		embedded: embedded{Days: 7},
+		Int8:     100,
+		Uint:     100,
+		Float32:  1.5,
+		IntPtr:   &i,
+		Default:  5,
+		DefPtr:   &tr,
+		UserID:   1,
+		User:     &objects.User{DiscordBaseObject: objects.DiscordBaseObject{ID: 1}, Username: "jeff"},
+		Member: objects.GuildMember{
+			Nick: "jeffrey",
+			User: &objects.User{DiscordBaseObject: objects.DiscordBaseObject{ID: 1}, Username: "jeff"},
+		},
+		Resolv:  user,
+		Channel: objects.Channel{DiscordBaseObject: objects.DiscordBaseObject{ID: 2}, Name: "general"},
+		Mention: &objects.Channel{DiscordBaseObject: objects.DiscordBaseObject{ID: 2}, Name: "general"},
+		File:    &objects.Attachment{DiscordBaseObject: objects.DiscordBaseObject{ID: 3}, Filename: "cat.png"},
+		Ban:     &nested{Reason: "spam"},
+		Attach:  attachment,
+	}, a)
+}
+
+func TestCommandRouterCtx_Bind_unexportedPointer(t *testing.T) {
+	type inner struct {
+		Reason string `discord:"reason"`
+	}
+	a := struct{ *inner }{inner: &inner{}}
+	require.NoError(t, bindTestCtx(map[string]any{"reason": "spam"}).Bind(&a))
+	assert.Equal(t, "spam", a.Reason)
+}
+
+func TestCommandRouterCtx_Bind_errors(t *testing.T) {
+	type inner struct {
+		B string `discord:"b"`
+	}
+	tests := []struct {
+		name string
+
+		options map[string]any
+		data    func() any
+
+		expectsErr   error
+		expectsField string
+	}{
+		{
+			name:       "nil pointer",
+			data:       func() any { return (*struct{})(nil) },
+			expectsErr: InvalidBindTarget,
+		},
+		{
+			name:    "string to int",
+			options: map[string]any{"a": "hello"},
+			data: func() any {
+				return &struct {
+					A int `discord:"a"`
+				}{}
+			},
+			expectsErr:   IncompatibleBindField,
+			expectsField: "A",
+		},
+		{
+			name:    "double to int",
+			options: map[string]any{"a": 1.5},
+			data: func() any {
+				return &struct {
+					A int `discord:"a"`
+				}{}
+			},
+			expectsErr:   IncompatibleBindField,
+			expectsField: "A",
+		},
+		{
+			name:    "overflow",
+			options: map[string]any{"a": 300},
+			data: func() any {
+				return &struct {
+					A int8 `discord:"a"`
+				}{}
+			},
+			expectsErr:   BindValueOverflow,
+			expectsField: "A",
+		},
+		{
+			name:    "negative to uint",
+			options: map[string]any{"a": -1},
+			data: func() any {
+				return &struct {
+					A uint `discord:"a"`
+				}{}
+			},
+			expectsErr:   BindValueOverflow,
+			expectsField: "A",
+		},
+		{
+			name: "unresolved",
+			options: map[string]any{
+				"a": (ResolvableChannel)(resolvable[objects.Channel]{id: "5", data: bindTestData}),
+			},
+			data: func() any {
+				return &struct {
+					A *objects.Channel `discord:"a"`
+				}{}
+			},
+			expectsErr:   UnresolvedBindValue,
+			expectsField: "A",
+		},
+		{
+			name: "wrong resolved type",
+			options: map[string]any{
+				"a": (ResolvableChannel)(resolvable[objects.Channel]{id: "2", data: bindTestData}),
+			},
+			data: func() any {
+				return &struct {
+					A objects.Role `discord:"a"`
+				}{}
+			},
+			expectsErr:   IncompatibleBindField,
+			expectsField: "A",
+		},
+		{
+			name: "invalid default",
+			data: func() any {
+				return &struct {
+					A int `discord:"a" default:"five"`
+				}{}
+			},
+			expectsErr:   InvalidBindDefault,
+			expectsField: "A",
+		},
+		{
+			name:    "unsupported struct",
+			options: map[string]any{"a": "2022-01-01T00:00:00Z"},
+			data: func() any {
+				return &struct {
+					A time.Time `discord:"a"`
+				}{}
+			},
+			expectsErr:   UnsupportedBindField,
+			expectsField: "A",
+		},
+		{
+			name: "struct not tagged with a sub-command",
+			data: func() any {
+				return &struct {
+					A *struct {
+						B string `discord:"b"`
+					} `discord:"mute"`
+				}{}
+			},
+			expectsErr:   UnsupportedBindField,
+			expectsField: "A",
+		},
+		{
+			name:    "nil embedded pointer to an unexported struct",
+			options: map[string]any{"b": "hello"},
+			data: func() any {
+				return &struct{ *inner }{}
+			},
+			expectsErr:   UnexportedBindPointer,
+			expectsField: "inner",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bindTestCtx(tt.options).Bind(tt.data())
+			assert.ErrorIs(t, err, tt.expectsErr)
+			if tt.expectsField != "" {
+				var bindErr *BindError
+				require.True(t, errors.As(err, &bindErr))
+				assert.Equal(t, tt.expectsField, bindErr.Field)
+			}
+		})
+	}
+}
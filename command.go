@@ -28,18 +28,44 @@ type Command struct {
 	// Description is the description for the command.
 	Description string `json:"description"`
 
+	// NameLocalizations defines the localizations for the command name.
+	NameLocalizations Localizations `json:"name_localizations,omitempty"`
+
+	// DescriptionLocalizations defines the localizations for the command description.
+	DescriptionLocalizations Localizations `json:"description_localizations,omitempty"`
+
 	// AllowedMentions is used to set a command level rule on allowed mentions. If this is not nil, it overrides the last configuration.
 	AllowedMentions *objects.AllowedMentions `json:"allowed_mentions"`
 
+	// ErrorHandler is used to set a command level error handler. If this is not nil, it overrides the last configuration.
+	ErrorHandler ContextErrorHandler `json:"-"`
+
 	// DefaultPermissions indicates which users should be allowed to use this command based on their permissions.  Set to 0 to disable by default. (default: all allowed)
 	DefaultPermissions *permissions.PermissionBit `json:"default_member_permissions,omitempty"`
 
 	// UseInDMs determines if the command should be usable in DMs (default: true)
 	UseInDMs *bool `json:"dm_permission,omitempty"`
 
+	// Guilds is used to scope the command to the specified guilds. If this is empty, the command is global. Only top level
+	// commands can be scoped.
+	Guilds []objects.Snowflake `json:"guilds,omitempty"`
+
 	// Options defines the options which are required for a command.
 	Options []*objects.ApplicationCommandOption `json:"options"`
 
+	// OptionLocalizations defines the localizations for the options. The key is the name of the option.
+	OptionLocalizations map[string]*OptionLocalizations `json:"option_localizations,omitempty"`
+
+	// OptionConstraints defines the constraints on the values of the options. The key is the name of the option.
+	OptionConstraints map[string]*OptionConstraints `json:"option_constraints,omitempty"`
+
+	// Middleware defines the middleware which is only used for this command. This is called after the router and group
+	// middleware.
+	Middleware []MiddlewareFunc `json:"-"`
+
+	// SkipInheritedMiddleware defines if the router and group middleware should not be used for this command.
+	SkipInheritedMiddleware bool `json:"skip_inherited_middleware,omitempty"`
+
 	// Function is used to define the command being called.
 	Function func(*CommandRouterCtx) error `json:"-"`
 }
@@ -57,6 +83,39 @@ func findOption(name string, options []*objects.ApplicationCommandOption) *objec
 	return nil
 }
 
+// Gets the options in the form they are uploaded to Discord.
+func (c *Command) discordOptions() []DiscordCommandOption {
+	options := make([]DiscordCommandOption, len(c.Options))
+	for i, v := range c.Options {
+		option := DiscordCommandOption{ApplicationCommandOption: *v}
+		option.ApplicationCommandOption.Choices = nil
+		if option.Description == "" {
+			// Discord requires option descriptions, so fill in the same message as commands.
+			option.Description = "No description provided."
+		}
+		localizations := c.OptionLocalizations[v.Name]
+		if localizations != nil {
+			option.NameLocalizations = localizations.Name
+			option.DescriptionLocalizations = localizations.Description
+		}
+		if constraints := c.OptionConstraints[v.Name]; constraints != nil {
+			option.MinLength = constraints.MinLength
+			option.MaxLength = constraints.MaxLength
+		}
+		if v.Choices != nil {
+			option.Choices = make([]DiscordCommandOptionChoice, len(v.Choices))
+			for j, choice := range v.Choices {
+				option.Choices[j] = DiscordCommandOptionChoice{ApplicationCommandOptionChoice: choice}
+				if localizations != nil {
+					option.Choices[j].NameLocalizations = localizations.Choices[choice.Name]
+				}
+			}
+		}
+		options[i] = option
+	}
+	return options
+}
+
 // NonExistentOption is thrown when an option is provided in an interaction that doesn't exist in the command.
 var NonExistentOption = errors.New("interaction option doesn't exist on command")
 
@@ -72,6 +131,10 @@ type commandExecutionOptions struct {
 	interaction      *objects.Interaction
 	data             *objects.ApplicationCommandInteractionData
 	options          []*objects.ApplicationCommandInteractionDataOption
+	aroundMiddleware []AroundMiddlewareFunc
+	route            string
+	errorScope       *errorScope
+	logger           Logger
 }
 
 // Maps out the options.
@@ -129,13 +192,50 @@ func (c *Command) mapOptions(autocomplete bool, data *objects.ApplicationCommand
 			})
 		case objects.TypeNumber:
 			mappedOptions[option.Name] = v.Value
+		case objects.TypeAttachment:
+			mappedOptions[option.Name] = (ResolvableAttachment)(resolvable[objects.Attachment]{
+				id:   v.Value.(string),
+				data: data,
+			})
+		}
+
+		// Check the value against the constraints. Auto-complete values are partial, so they are not checked.
+		if constraints := c.OptionConstraints[option.Name]; constraints != nil && !autocomplete {
+			if err := constraints.check(option.Name, mappedOptions[option.Name]); err != nil {
+				return exceptionHandler(err), nil
+			}
 		}
 	}
 	return nil, mappedOptions
 }
 
+// Gets the middleware list for the command from the inherited middleware.
+func (c *Command) middlewareList(inherited *list.List) *list.List {
+	if c.SkipInheritedMiddleware {
+		inherited.Init()
+	}
+	for _, v := range c.Middleware {
+		inherited.PushBack(v)
+	}
+	return inherited
+}
+
 // Execute the command.
 func (c *Command) execute(reqCtx context.Context, opts commandExecutionOptions, middlewareList *list.List) (resp *objects.InteractionResponse) {
+	// Attempt to catch errors from here.
+	defer func() {
+		if errGeneric := recover(); errGeneric != nil {
+			resp = opts.exceptionHandler(newPanicError(errGeneric, opts.route))
+		}
+	}()
+
+	// Errors from the router are wrapped with where they happened.
+	routerErrHandler := wrapRouterErrors(opts.exceptionHandler, &ErrorInfo{
+		Interaction: opts.interaction,
+		Route:       opts.route,
+		Kind:        InteractionKindCommand,
+	})
+
 	// Process the options.
 	var mappedOptions map[string]any
 	if opts.data.TargetID != 0 {
@@ -157,7 +257,7 @@ func (c *Command) execute(reqCtx context.Context, opts commandExecutionOptions,
 	} else {
 		// Call the function to map options.
 		var response *objects.InteractionResponse
-		response, mappedOptions = c.mapOptions(false, opts.data, opts.options, opts.exceptionHandler)
+		response, mappedOptions = c.mapOptions(false, opts.data, opts.options, routerErrHandler)
 		if mappedOptions == nil {
 			return response
 		}
@@ -168,13 +268,6 @@ func (c *Command) execute(reqCtx context.Context, opts commandExecutionOptions,
 		opts.allowedMentions = c.AllowedMentions
 	}
 
-	// Attempt to catch errors from here.
-	defer func() {
-		if errGeneric := recover(); errGeneric != nil {
-			resp = opts.exceptionHandler(ungenericError(errGeneric))
-		}
-	}()
-
 	// Create the context.
 	rctx := &CommandRouterCtx{
 		globalAllowedMentions: opts.allowedMentions,
@@ -185,6 +278,11 @@ func (c *Command) execute(reqCtx context.Context, opts commandExecutionOptions,
 		Command:               c,
 		Options:               mappedOptions,
 		RESTClient:            opts.restClient,
+		route:                 opts.route,
+		logger:                opts.logger,
+	}
+	if opts.errorScope != nil {
+		opts.errorScope.builder = &rctx.responseBuilder
 	}
 
 	// Run the command.
@@ -193,27 +291,20 @@ func (c *Command) execute(reqCtx context.Context, opts commandExecutionOptions,
 		// don't nil crash
 		handler = func(ctx *CommandRouterCtx) error { return nil }
 	}
-	if middlewareList.Len() == 0 {
-		// Just call the command function.
-		if err := handler(rctx); err != nil {
-			return opts.exceptionHandler(err)
-		}
-	} else {
-		// Wrap the end command function in a middleware function and push it.
-		var middlewareWrapper MiddlewareFunc = func(ctx MiddlewareCtx) error {
-			return handler(ctx.CommandRouterCtx)
-		}
-		middlewareList.PushBack(middlewareWrapper)
-
-		// Create the middleware context.
-		mctx := MiddlewareCtx{CommandRouterCtx: rctx, middlewareList: middlewareList}
-
-		// Call the middleware chain.
-		if err := mctx.Next(); err != nil {
-			return opts.exceptionHandler(err)
+	aroundMiddleware := opts.aroundMiddleware
+	if c.SkipInheritedMiddleware {
+		aroundMiddleware = nil
+	}
+	resp, err := runAroundMiddleware(rctx, aroundMiddleware, func() (*objects.InteractionResponse, error) {
+		if err := runCommandMiddleware(rctx, c.middlewareList(middlewareList), handler); err != nil {
+			return nil, err
 		}
+		return rctx.buildResponse(false, routerErrHandler, opts.allowedMentions), nil
+	})
+	if err != nil {
+		return opts.exceptionHandler(err)
 	}
-	return rctx.buildResponse(false, opts.exceptionHandler, opts.allowedMentions)
+	return resp
 }
 
 // Groups is used to get the command groups that this belongs to.
@@ -33,7 +33,7 @@ func StringAutoCompleteFuncBuilder(f StringAutoCompleteFunc) StringChoiceBuilder
 	}
 }
 
-func (c *commandBuilder[T]) StringOption(name, description string, required bool, choiceBuilder StringChoiceBuilder) T {
+func (c *commandBuilder[T]) StringOption(name, description string, required bool, choiceBuilder StringChoiceBuilder, configs ...OptionConfig) T {
 	var discordifiedChoices []objects.ApplicationCommandOptionChoice
 	var f StringAutoCompleteFunc
 	if choiceBuilder != nil {
@@ -44,6 +44,7 @@ func (c *commandBuilder[T]) StringOption(name, description string, required bool
 			discordifiedChoices = make([]objects.ApplicationCommandOptionChoice, len(choices))
 			for i, v := range choices {
 				discordifiedChoices[i] = objects.ApplicationCommandOptionChoice{Name: v.Name, Value: v.Value}
+				c.cmd.localizeChoice(name, v.Name, v.NameLocalizations)
 			}
 		}, func(autoCompleteFunc StringAutoCompleteFunc) {
 			if discordifiedChoices != nil {
@@ -53,14 +54,16 @@ func (c *commandBuilder[T]) StringOption(name, description string, required bool
 		})
 	}
 
-	c.cmd.Options = append(c.cmd.Options, &objects.ApplicationCommandOption{
+	option := &objects.ApplicationCommandOption{
 		OptionType:   objects.TypeString,
 		Name:         name,
 		Description:  description,
 		Required:     required,
 		Choices:      discordifiedChoices,
 		Autocomplete: f != nil,
-	})
+	}
+	c.cmd.configureOption(option, configs)
+	c.cmd.Options = append(c.cmd.Options, option)
 	if f != nil {
 		if c.cmd.autocomplete == nil {
 			c.cmd.autocomplete = map[string]any{}
@@ -93,7 +96,7 @@ func IntAutoCompleteFuncBuilder(f IntAutoCompleteFunc) IntChoiceBuilder {
 	}
 }
 
-func (c *commandBuilder[T]) IntOption(name, description string, required bool, choiceBuilder IntChoiceBuilder) T {
+func (c *commandBuilder[T]) IntOption(name, description string, required bool, choiceBuilder IntChoiceBuilder, configs ...OptionConfig) T {
 	var discordifiedChoices []objects.ApplicationCommandOptionChoice
 	var f IntAutoCompleteFunc
 	if choiceBuilder != nil {
@@ -104,6 +107,7 @@ func (c *commandBuilder[T]) IntOption(name, description string, required bool, c
 			discordifiedChoices = make([]objects.ApplicationCommandOptionChoice, len(choices))
 			for i, v := range choices {
 				discordifiedChoices[i] = objects.ApplicationCommandOptionChoice{Name: v.Name, Value: v.Value}
+				c.cmd.localizeChoice(name, v.Name, v.NameLocalizations)
 			}
 		}, func(autoCompleteFunc IntAutoCompleteFunc) {
 			if discordifiedChoices != nil {
@@ -113,14 +117,16 @@ func (c *commandBuilder[T]) IntOption(name, description string, required bool, c
 		})
 	}
 
-	c.cmd.Options = append(c.cmd.Options, &objects.ApplicationCommandOption{
+	option := &objects.ApplicationCommandOption{
 		OptionType:   objects.TypeInteger,
 		Name:         name,
 		Description:  description,
 		Required:     required,
 		Choices:      discordifiedChoices,
 		Autocomplete: f != nil,
-	})
+	}
+	c.cmd.configureOption(option, configs)
+	c.cmd.Options = append(c.cmd.Options, option)
 	if f != nil {
 		if c.cmd.autocomplete == nil {
 			c.cmd.autocomplete = map[string]any{}
@@ -153,7 +159,7 @@ func DoubleAutoCompleteFuncBuilder(f DoubleAutoCompleteFunc) DoubleChoiceBuilder
 	}
 }
 
-func (c *commandBuilder[T]) DoubleOption(name, description string, required bool, choiceBuilder DoubleChoiceBuilder) T {
+func (c *commandBuilder[T]) DoubleOption(name, description string, required bool, choiceBuilder DoubleChoiceBuilder, configs ...OptionConfig) T {
 	var discordifiedChoices []objects.ApplicationCommandOptionChoice
 	var f DoubleAutoCompleteFunc
 	if choiceBuilder != nil {
@@ -164,6 +170,7 @@ func (c *commandBuilder[T]) DoubleOption(name, description string, required bool
 			discordifiedChoices = make([]objects.ApplicationCommandOptionChoice, len(choices))
 			for i, v := range choices {
 				discordifiedChoices[i] = objects.ApplicationCommandOptionChoice{Name: v.Name, Value: v.Value}
+				c.cmd.localizeChoice(name, v.Name, v.NameLocalizations)
 			}
 		}, func(autoCompleteFunc DoubleAutoCompleteFunc) {
 			if discordifiedChoices != nil {
@@ -173,14 +180,16 @@ func (c *commandBuilder[T]) DoubleOption(name, description string, required bool
 		})
 	}
 
-	c.cmd.Options = append(c.cmd.Options, &objects.ApplicationCommandOption{
+	option := &objects.ApplicationCommandOption{
 		OptionType:   objects.TypeNumber,
 		Name:         name,
 		Description:  description,
 		Required:     required,
 		Choices:      discordifiedChoices,
 		Autocomplete: f != nil,
-	})
+	}
+	c.cmd.configureOption(option, configs)
+	c.cmd.Options = append(c.cmd.Options, option)
 	if f != nil {
 		if c.cmd.autocomplete == nil {
 			c.cmd.autocomplete = map[string]any{}
@@ -7,6 +7,9 @@ type StringChoice struct {
 	// Name is the name of the choice.
 	Name string `json:"name"`
 
+	// NameLocalizations defines the localizations for the name of the choice. This is only used for static choices.
+	NameLocalizations Localizations `json:"name_localizations,omitempty"`
+
 	// Value is the string that is the resulting value.
 	Value string `json:"value"`
 }
@@ -16,6 +19,9 @@ type IntChoice struct {
 	// Name is the name of the choice.
 	Name string `json:"name"`
 
+	// NameLocalizations defines the localizations for the name of the choice. This is only used for static choices.
+	NameLocalizations Localizations `json:"name_localizations,omitempty"`
+
 	// Value is the int that is the resulting value.
 	Value int `json:"value"`
 }
@@ -25,6 +31,9 @@ type DoubleChoice struct {
 	// Name is the name of the choice.
 	Name string `json:"name"`
 
+	// NameLocalizations defines the localizations for the name of the choice. This is only used for static choices.
+	NameLocalizations Localizations `json:"name_localizations,omitempty"`
+
 	// Value is the double that is the resulting value.
 	Value float64 `json:"value"`
 }
@@ -39,6 +48,24 @@ func (c *commandBuilder[T]) appendOption(type_ objects.ApplicationCommandOptionT
 	return builderWrapify(c)
 }
 
+func (c *commandBuilder[T]) OptionLocalizations(option string, names, descriptions Localizations) T {
+	l := c.cmd.getOptionLocalizations(option)
+	l.Name = names
+	l.Description = descriptions
+	return builderWrapify(c)
+}
+
+func (c *commandBuilder[T]) Autocomplete(option string, f AutocompleteFunc) T {
+	if c.cmd.autocomplete == nil {
+		c.cmd.autocomplete = map[string]any{}
+	}
+	c.cmd.autocomplete[option] = f
+	if o := findOption(option, c.cmd.Options); o != nil {
+		o.Autocomplete = true
+	}
+	return builderWrapify(c)
+}
+
 func (c *commandBuilder[T]) BoolOption(name, description string, required bool) T {
 	return c.appendOption(objects.TypeBoolean, name, description, required)
 }
@@ -47,8 +74,10 @@ func (c *commandBuilder[T]) UserOption(name, description string, required bool)
 	return c.appendOption(objects.TypeUser, name, description, required)
 }
 
-func (c *commandBuilder[T]) ChannelOption(name, description string, required bool) T {
-	return c.appendOption(objects.TypeChannel, name, description, required)
+func (c *commandBuilder[T]) ChannelOption(name, description string, required bool, configs ...OptionConfig) T {
+	c.appendOption(objects.TypeChannel, name, description, required)
+	c.cmd.configureOption(c.cmd.Options[len(c.cmd.Options)-1], configs)
+	return builderWrapify(c)
 }
 
 func (c *commandBuilder[T]) RoleOption(name, description string, required bool) T {
@@ -15,6 +15,16 @@ func (c *commandBuilder[T]) Description(description string) T {
 	return builderWrapify(c)
 }
 
+func (c *commandBuilder[T]) NameLocalizations(localizations Localizations) T {
+	c.cmd.NameLocalizations = localizations
+	return builderWrapify(c)
+}
+
+func (c *commandBuilder[T]) DescriptionLocalizations(localizations Localizations) T {
+	c.cmd.DescriptionLocalizations = localizations
+	return builderWrapify(c)
+}
+
 func (c *commandBuilder[T]) DefaultPermissions(perms permissions.PermissionBit) T {
 	c.cmd.DefaultPermissions = &perms
 	return builderWrapify(c)
@@ -26,17 +36,43 @@ func (c *commandBuilder[T]) GuildCommand() T {
 	return builderWrapify(c)
 }
 
+func (c *commandBuilder[T]) Guilds(guildIDs ...objects.Snowflake) T {
+	c.cmd.Guilds = guildIDs
+	return builderWrapify(c)
+}
+
 func (c *commandBuilder[T]) AllowedMentions(config *objects.AllowedMentions) T {
 	c.cmd.AllowedMentions = config
 	return builderWrapify(c)
 }
 
+func (c *commandBuilder[T]) ErrorHandler(f ContextErrorHandler) T {
+	c.cmd.ErrorHandler = f
+	return builderWrapify(c)
+}
+
 func (c *commandBuilder[T]) Handler(handler func(*CommandRouterCtx) error) T {
 	c.cmd.Function = handler
 	return builderWrapify(c)
 }
 
+func (c *commandBuilder[T]) Use(f MiddlewareFunc) T {
+	c.cmd.Middleware = append(c.cmd.Middleware, f)
+	return builderWrapify(c)
+}
+
+func (c *commandBuilder[T]) SkipInheritedMiddleware() T {
+	c.cmd.SkipInheritedMiddleware = true
+	return builderWrapify(c)
+}
+
 func (c *commandBuilder[T]) Build() (*Command, error) {
+	if err := c.cmd.validate(); err != nil {
+		return nil, err
+	}
+	if err := validateAddToGroup(c.cmd.Name, c.map_, c.cmd.parent == nil); err != nil {
+		return nil, err
+	}
 	c.map_[c.cmd.Name] = &c.cmd
 	return &c.cmd, nil
 }
@@ -8,12 +8,14 @@ import (
 // commandOptions is a struct that contains the options for a command.
 type commandOptions[T any] interface {
 	// StringOption is used to define an option of the type string. Note that choices is ignored if it's nil or length 0.
+	// The length of the string can be constrained with OptionMinLength and OptionMaxLength.
 	// Maps to option type 3 (STRING): https://discord.com/developers/docs/interactions/slash-commands#application-command-object-application-command-option-type
-	StringOption(name, description string, required bool, choiceBuilder StringChoiceBuilder) T
+	StringOption(name, description string, required bool, choiceBuilder StringChoiceBuilder, configs ...OptionConfig) T
 
 	// IntOption is used to define an option of the type int. Note that choices is ignored if it's nil or length 0.
+	// The value can be constrained with OptionMinValue and OptionMaxValue.
 	// Maps to option type 4 (INTEGER): https://discord.com/developers/docs/interactions/slash-commands#application-command-object-application-command-option-type
-	IntOption(name, description string, required bool, choiceBuilder IntChoiceBuilder) T
+	IntOption(name, description string, required bool, choiceBuilder IntChoiceBuilder, configs ...OptionConfig) T
 
 	// BoolOption is used to define an option of the type bool.
 	// Maps to option type 5 (BOOLEAN): https://discord.com/developers/docs/interactions/slash-commands#application-command-object-application-command-option-type
@@ -23,9 +25,9 @@ type commandOptions[T any] interface {
 	// Maps to option type 6 (USER): https://discord.com/developers/docs/interactions/slash-commands#application-command-object-application-command-option-type
 	UserOption(name, description string, required bool) T
 
-	// ChannelOption is used to define an option of the type channel.
+	// ChannelOption is used to define an option of the type channel. The channel types allowed can be constrained with OptionChannelTypes.
 	// Maps to option type 7 (CHANNEL): https://discord.com/developers/docs/interactions/slash-commands#application-command-object-application-command-option-type
-	ChannelOption(name, description string, required bool) T
+	ChannelOption(name, description string, required bool, configs ...OptionConfig) T
 
 	// RoleOption is used to define an option of the type role.
 	// Maps to option type 8 (ROLE): https://discord.com/developers/docs/interactions/slash-commands#application-command-object-application-command-option-type
@@ -36,12 +38,21 @@ type commandOptions[T any] interface {
 	MentionableOption(name, description string, required bool) T
 
 	// DoubleOption is used to define an option of the type double. Note that choices is ignored if it's nil or length 0.
+	// The value can be constrained with OptionMinValue and OptionMaxValue.
 	// Maps to option type 10 (INTEGER): https://discord.com/developers/docs/interactions/slash-commands#application-command-object-application-command-option-type
-	DoubleOption(name, description string, required bool, choiceBuilder DoubleChoiceBuilder) T
+	DoubleOption(name, description string, required bool, choiceBuilder DoubleChoiceBuilder, configs ...OptionConfig) T
 
 	// AttachmentOption is used to define an option of the type attachment.
 	// Maps to option type 11 (ATTACHMENT): https://discord.com/developers/docs/interactions/slash-commands#application-command-object-application-command-option-type
 	AttachmentOption(name, description string, required bool) T
+
+	// OptionLocalizations is used to set the localizations for the name and description of the option specified.
+	// Localizations for static choices are set with the NameLocalizations field on the choice.
+	OptionLocalizations(option string, names, descriptions Localizations) T
+
+	// Autocomplete is used to set an auto-complete handler which uses the AutocompleteCtx on the option specified. The
+	// option must be a string, int, or double option without static choices, and must be defined before this is called.
+	Autocomplete(option string, f AutocompleteFunc) T
 }
 
 // TextCommandBuilder is used to define a builder for a Command object where the type is a text command.
@@ -54,16 +65,34 @@ type TextCommandBuilder interface {
 	// GuildCommand is used to forbid this from running in DMs.
 	GuildCommand() TextCommandBuilder
 
+	// Guilds is used to scope this command to the specified guilds. The command will not be registered globally.
+	Guilds(...objects.Snowflake) TextCommandBuilder
+
 	// Description is used to define the commands description.
 	Description(string) TextCommandBuilder
 
+	// NameLocalizations is used to define the localizations for the commands name.
+	NameLocalizations(Localizations) TextCommandBuilder
+
+	// DescriptionLocalizations is used to define the localizations for the commands description.
+	DescriptionLocalizations(Localizations) TextCommandBuilder
+
 	// AllowedMentions is used to set a command level rule on allowed mentions. If this is not nil, it overrides the last configuration.
 	AllowedMentions(*objects.AllowedMentions) TextCommandBuilder
 
+	// ErrorHandler is used to set a command level error handler. If this is not nil, it overrides the last configuration.
+	ErrorHandler(ContextErrorHandler) TextCommandBuilder
+
 	// Handler is used to add a command handler.
 	Handler(func(*CommandRouterCtx) error) TextCommandBuilder
 
-	// Build is used to build the command and insert it into the command router.
+	// Use is used to add middleware which is only used for this command. This is called after the router and group middleware.
+	Use(f MiddlewareFunc) TextCommandBuilder
+
+	// SkipInheritedMiddleware is used to stop the router and group middleware from being used for this command.
+	SkipInheritedMiddleware() TextCommandBuilder
+
+	// Build is used to validate the command and insert it into the command router. If the command is not valid, a *ValidationError is returned.
 	Build() (*Command, error)
 
 	// MustBuild is used to define when a command must build or panic.
@@ -77,13 +106,28 @@ type SubCommandBuilder interface {
 	// Description is used to define the commands description.
 	Description(string) SubCommandBuilder
 
+	// NameLocalizations is used to define the localizations for the commands name.
+	NameLocalizations(Localizations) SubCommandBuilder
+
+	// DescriptionLocalizations is used to define the localizations for the commands description.
+	DescriptionLocalizations(Localizations) SubCommandBuilder
+
 	// AllowedMentions is used to set a command level rule on allowed mentions. If this is not nil, it overrides the last configuration.
 	AllowedMentions(*objects.AllowedMentions) SubCommandBuilder
 
+	// ErrorHandler is used to set a command level error handler. If this is not nil, it overrides the last configuration.
+	ErrorHandler(ContextErrorHandler) SubCommandBuilder
+
 	// Handler is used to add a command handler.
 	Handler(func(*CommandRouterCtx) error) SubCommandBuilder
 
-	// Build is used to build the command and insert it into the command router.
+	// Use is used to add middleware which is only used for this command. This is called after the router and group middleware.
+	Use(f MiddlewareFunc) SubCommandBuilder
+
+	// SkipInheritedMiddleware is used to stop the router and group middleware from being used for this command.
+	SkipInheritedMiddleware() SubCommandBuilder
+
+	// Build is used to validate the command and insert it into the command router. If the command is not valid, a *ValidationError is returned.
 	Build() (*Command, error)
 
 	// MustBuild is used to define when a command must build or panic.
@@ -98,13 +142,28 @@ type MessageCommandBuilder interface {
 	// GuildCommand is used to forbid this from running in DMs.
 	GuildCommand() MessageCommandBuilder
 
+	// NameLocalizations is used to define the localizations for the commands name.
+	NameLocalizations(Localizations) MessageCommandBuilder
+
+	// Guilds is used to scope this command to the specified guilds. The command will not be registered globally.
+	Guilds(...objects.Snowflake) MessageCommandBuilder
+
 	// AllowedMentions is used to set a command level rule on allowed mentions. If this is not nil, it overrides the last configuration.
 	AllowedMentions(*objects.AllowedMentions) MessageCommandBuilder
 
+	// ErrorHandler is used to set a command level error handler. If this is not nil, it overrides the last configuration.
+	ErrorHandler(ContextErrorHandler) MessageCommandBuilder
+
 	// Handler is used to add a command handler.
 	Handler(func(*CommandRouterCtx, *objects.Message) error) MessageCommandBuilder
 
-	// Build is used to build the command and insert it into the command router.
+	// Use is used to add middleware which is only used for this command. This is called after the router and group middleware.
+	Use(f MiddlewareFunc) MessageCommandBuilder
+
+	// SkipInheritedMiddleware is used to stop the router and group middleware from being used for this command.
+	SkipInheritedMiddleware() MessageCommandBuilder
+
+	// Build is used to validate the command and insert it into the command router. If the command is not valid, a *ValidationError is returned.
 	Build() (*Command, error)
 
 	// MustBuild is used to define when a command must build or panic.
@@ -119,13 +178,28 @@ type UserCommandBuilder interface {
 	// GuildCommand is used to forbid this from running in DMs.
 	GuildCommand() UserCommandBuilder
 
+	// NameLocalizations is used to define the localizations for the commands name.
+	NameLocalizations(Localizations) UserCommandBuilder
+
+	// Guilds is used to scope this command to the specified guilds. The command will not be registered globally.
+	Guilds(...objects.Snowflake) UserCommandBuilder
+
 	// AllowedMentions is used to set a command level rule on allowed mentions. If this is not nil, it overrides the last configuration.
 	AllowedMentions(*objects.AllowedMentions) UserCommandBuilder
 
+	// ErrorHandler is used to set a command level error handler. If this is not nil, it overrides the last configuration.
+	ErrorHandler(ContextErrorHandler) UserCommandBuilder
+
 	// Handler is used to add a command handler.
 	Handler(func(*CommandRouterCtx, *objects.GuildMember) error) UserCommandBuilder
 
-	// Build is used to build the command and insert it into the command router.
+	// Use is used to add middleware which is only used for this command. This is called after the router and group middleware.
+	Use(f MiddlewareFunc) UserCommandBuilder
+
+	// SkipInheritedMiddleware is used to stop the router and group middleware from being used for this command.
+	SkipInheritedMiddleware() UserCommandBuilder
+
+	// Build is used to validate the command and insert it into the command router. If the command is not valid, a *ValidationError is returned.
 	Build() (*Command, error)
 
 	// MustBuild is used to define when a command must build or panic.
@@ -142,9 +216,18 @@ type CommandBuilder interface {
 	// GuildCommand is used to forbid this from running in DMs.
 	GuildCommand() CommandBuilder
 
+	// Guilds is used to scope this command to the specified guilds. The command will not be registered globally.
+	Guilds(...objects.Snowflake) CommandBuilder
+
 	// Description is used to define the commands description.
 	Description(string) CommandBuilder
 
+	// NameLocalizations is used to define the localizations for the commands name.
+	NameLocalizations(Localizations) CommandBuilder
+
+	// DescriptionLocalizations is used to define the localizations for the commands description.
+	DescriptionLocalizations(Localizations) CommandBuilder
+
 	// TextCommand is used to define that this should be a text command builder.
 	TextCommand() TextCommandBuilder
 
@@ -157,10 +240,19 @@ type CommandBuilder interface {
 	// AllowedMentions is used to set a command level rule on allowed mentions. If this is not nil, it overrides the last configuration.
 	AllowedMentions(*objects.AllowedMentions) CommandBuilder
 
+	// ErrorHandler is used to set a command level error handler. If this is not nil, it overrides the last configuration.
+	ErrorHandler(ContextErrorHandler) CommandBuilder
+
 	// Handler is used to add a command handler.
 	Handler(func(*CommandRouterCtx) error) CommandBuilder
 
-	// Build is used to build the command and insert it into the command router.
+	// Use is used to add middleware which is only used for this command. This is called after the router and group middleware.
+	Use(f MiddlewareFunc) CommandBuilder
+
+	// SkipInheritedMiddleware is used to stop the router and group middleware from being used for this command.
+	SkipInheritedMiddleware() CommandBuilder
+
+	// Build is used to validate the command and insert it into the command router. If the command is not valid, a *ValidationError is returned.
 	Build() (*Command, error)
 
 	// MustBuild is used to define when a command must build or panic.
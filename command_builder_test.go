@@ -741,6 +741,27 @@ func Test_commandBuilder_Handler(t *testing.T) {
 	assert.NotNil(t, base.(*commandBuilder[CommandBuilder]).cmd.Function)
 }
 
+func Test_commandBuilder_Use(t *testing.T) {
+	var base CommandBuilder = &commandBuilder[CommandBuilder]{}
+	assert.NoError(t, callBuilderFunction(t, base, true, "Use", MiddlewareFunc(middleware1)))
+	assert.NoError(t, callBuilderFunction(t, base, true, "Use", MiddlewareFunc(middleware2)))
+	assert.Len(t, base.(*commandBuilder[CommandBuilder]).cmd.Middleware, 2)
+}
+
+func Test_commandBuilder_SkipInheritedMiddleware(t *testing.T) {
+	var base CommandBuilder = &commandBuilder[CommandBuilder]{}
+	assert.NoError(t, callBuilderFunction(t, base, true, "SkipInheritedMiddleware"))
+	assert.True(t, base.(*commandBuilder[CommandBuilder]).cmd.SkipInheritedMiddleware)
+}
+
+func Test_commandBuilder_ErrorHandler(t *testing.T) {
+	var base CommandBuilder = &commandBuilder[CommandBuilder]{}
+	assert.NoError(t, callBuilderFunction(t, base, true, "ErrorHandler", ContextErrorHandler(func(error, *ErrorInfo) *objects.InteractionResponse {
+		return nil
+	})))
+	assert.NotNil(t, base.(*commandBuilder[CommandBuilder]).cmd.ErrorHandler)
+}
+
 func Test_textCommandBuilder_Description(t *testing.T) {
 	var b TextCommandBuilder = textCommandBuilder{&commandBuilder[TextCommandBuilder]{}}
 	assert.NoError(t, callBuilderFunction(t, b, true, "Description", "testing"))
@@ -5,8 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/Postcord/interactions"
 	"github.com/Postcord/objects"
@@ -32,6 +32,12 @@ type CommandRouterCtx struct {
 	// Defines the void ID generator.
 	voidGenerator
 
+	// Defines the route which is being handled. This is used when recovering from a panic.
+	route string
+
+	// Defines the logger used for errors when updating the response later.
+	logger Logger
+
 	// Defines the interaction which started this.
 	*objects.Interaction
 
@@ -42,7 +48,7 @@ type CommandRouterCtx struct {
 	Command *Command `json:"command"`
 
 	// Options is used to define any options that were set in the context. Note that if an option is unset from Discord, it will not be in the map.
-	// Note that for User, Channel, Role, and Mentionable from Discord; a "*Resolvable<option type>" type is used. This will allow you to get the ID as a Snowflake, string, or attempt to get from resolved.
+	// Note that for User, Channel, Role, Mentionable, and Attachment from Discord; a "*Resolvable<option type>" type is used. This will allow you to get the ID as a Snowflake, string, or attempt to get from resolved.
 	Options map[string]any `json:"options"`
 
 	// RESTClient is used to define the REST client.
@@ -102,9 +108,18 @@ type CommandGroup struct {
 	// Middleware defines all of the groups middleware.
 	Middleware []MiddlewareFunc `json:"middleware"`
 
+	// AroundMiddleware defines all of the groups middleware which wraps the response.
+	AroundMiddleware []AroundMiddlewareFunc `json:"-"`
+
 	// Description is the description for the command group.
 	Description string `json:"description"`
 
+	// NameLocalizations defines the localizations for the group name.
+	NameLocalizations Localizations `json:"name_localizations,omitempty"`
+
+	// DescriptionLocalizations defines the localizations for the group description.
+	DescriptionLocalizations Localizations `json:"description_localizations,omitempty"`
+
 	// DefaultPermissions indicates which users should be allowed to use this command based on their permissions.  Set to 0 to disable by default. (default: all allowed)
 	DefaultPermissions *permissions.PermissionBit `json:"default_member_permissions,omitempty"`
 
@@ -114,6 +129,13 @@ type CommandGroup struct {
 	// AllowedMentions is used to set a group level rule on allowed mentions. If this is not nil, it overrides the last configuration.
 	AllowedMentions *objects.AllowedMentions `json:"allowed_mentions"`
 
+	// ErrorHandler is used to set a group level error handler. If this is not nil, it overrides the last configuration.
+	ErrorHandler ContextErrorHandler `json:"-"`
+
+	// Guilds is used to scope the group to the specified guilds. If this is empty, the group is global. Only top level
+	// groups can be scoped.
+	Guilds []objects.Snowflake `json:"guilds,omitempty"`
+
 	// Subcommands is a map of all of the subcommands. It is a any since it can be *Command or *CommandGroup. DO NOT ADD TO THIS! USE THE ATTACHED FUNCTIONS!
 	Subcommands map[string]any `json:"subcommands"`
 }
@@ -123,12 +145,33 @@ func (c *CommandGroup) Use(f MiddlewareFunc) {
 	c.Middleware = append(c.Middleware, f)
 }
 
+// UseAround is used to add middleware which wraps the response to the group. This is called after the router
+// middleware which wraps the response, and before any other middleware.
+func (c *CommandGroup) UseAround(f AroundMiddlewareFunc) {
+	c.AroundMiddleware = append(c.AroundMiddleware, f)
+}
+
 // GroupNestedTooDeep is thrown when the sub-command group would be nested too deep.
 var GroupNestedTooDeep = errors.New("sub-command group would be nested too deep")
 
+// CommandGroupOptions is used to define the options for a command group.
 type CommandGroupOptions struct {
-	DefaultPermissions permissions.PermissionBit
-	UseInDMs           bool
+	// DefaultPermissions is used to set the permissions needed to use the group by default. If this is nil, everyone can
+	// use the group.
+	DefaultPermissions *permissions.PermissionBit
+
+	// UseInDMs is used to set if the group can be used in DMs. If this is nil, Discord allows it.
+	UseInDMs *bool
+
+	// Guilds is used to scope the group to the specified guilds. If this is empty, the group is global. Only top level
+	// groups can be scoped, so this returns NestedGuildScope when set on a nested group.
+	Guilds []objects.Snowflake
+
+	// NameLocalizations defines the localizations for the group name.
+	NameLocalizations Localizations
+
+	// DescriptionLocalizations defines the localizations for the group description.
+	DescriptionLocalizations Localizations
 }
 
 // NewCommandGroup is used to create a sub-command group.
@@ -137,18 +180,25 @@ func (c *CommandGroup) NewCommandGroup(name, description string, opts *CommandGr
 	if nextLevel > 2 {
 		return nil, GroupNestedTooDeep
 	}
+	if err := validateAddToGroup(name, c.Subcommands, c.level == 0); err != nil {
+		return nil, err
+	}
+	if nextLevel > 1 && opts != nil && len(opts.Guilds) != 0 {
+		return nil, &ValidationError{Name: name, Err: NestedGuildScope}
+	}
 	var g *CommandGroup
 	if opts != nil {
-		// TODO: Validate name + description.
 		g = &CommandGroup{
-			level:              nextLevel,
-			Description:        description,
-			DefaultPermissions: &opts.DefaultPermissions,
-			UseInDMs:           &opts.UseInDMs,
-			Subcommands:        map[string]any{},
+			level:                    nextLevel,
+			Description:              description,
+			DefaultPermissions:       opts.DefaultPermissions,
+			UseInDMs:                 opts.UseInDMs,
+			Guilds:                   opts.Guilds,
+			NameLocalizations:        opts.NameLocalizations,
+			DescriptionLocalizations: opts.DescriptionLocalizations,
+			Subcommands:              map[string]any{},
 		}
 	} else {
-		// TODO: Validate name + description.
 		g = &CommandGroup{
 			level:       nextLevel,
 			Description: description,
@@ -156,6 +206,10 @@ func (c *CommandGroup) NewCommandGroup(name, description string, opts *CommandGr
 		}
 	}
 
+	if err := validateNameAndDescription(true, name, description, g.NameLocalizations, g.DescriptionLocalizations); err != nil {
+		return nil, &ValidationError{Name: name, Err: err}
+	}
+
 	g.parent = c
 	c.Subcommands[name] = g
 	return g, nil
@@ -172,8 +226,10 @@ func (c *CommandGroup) MustNewCommandGroup(name, description string, opts *Comma
 
 // CommandRouter is used to route commands.
 type CommandRouter struct {
-	roots      CommandGroup
-	middleware []MiddlewareFunc
+	roots            CommandGroup
+	middleware       []MiddlewareFunc
+	aroundMiddleware []AroundMiddlewareFunc
+	notFoundHandler  NotFoundHandler
 }
 
 // Use is used to add middleware to the router.
@@ -181,6 +237,24 @@ func (c *CommandRouter) Use(f MiddlewareFunc) {
 	c.middleware = append(c.middleware, f)
 }
 
+// UseAround is used to add middleware which wraps the response to the router. This is called before any other
+// middleware. The command context can be got by type asserting the InteractionCtx to *CommandRouterCtx.
+func (c *CommandRouter) UseAround(f AroundMiddlewareFunc) {
+	c.aroundMiddleware = append(c.aroundMiddleware, f)
+}
+
+// SetErrorHandler is used to set the error handler for the router. This overrides the error handler of the loader,
+// and is overridden by the error handler of a group or command.
+func (c *CommandRouter) SetErrorHandler(f ContextErrorHandler) {
+	c.roots.ErrorHandler = f
+}
+
+// SetNotFoundHandler is used to set the handler used when a command does not exist. This overrides the not found
+// handler of the loader.
+func (c *CommandRouter) SetNotFoundHandler(f NotFoundHandler) {
+	c.notFoundHandler = f
+}
+
 // NewCommandGroup is used to create a sub-command group. Works the same as CommandGroup.NewCommandGroup.
 func (c *CommandRouter) NewCommandGroup(name, description string, opts *CommandGroupOptions) (*CommandGroup, error) {
 	if c.roots.Subcommands == nil {
@@ -231,13 +305,46 @@ var CommandDoesNotExist = errors.New("the command does not exist")
 // NoAutoCompleteFunc is thrown when Discord sends a focused argument without an autocomplete function.
 var NoAutoCompleteFunc = errors.New("discord sent auto-complete for argument without auto-complete function")
 
+// CommandNotInGuildScope is thrown when a command is invoked from a guild that it is not scoped to.
+var CommandNotInGuildScope = errors.New("the command is not available in this guild")
+
+// Checks if the guild is within the scope specified. An empty scope is global.
+func inGuildScope(scope []objects.Snowflake, guildID objects.Snowflake) bool {
+	if len(scope) == 0 {
+		return true
+	}
+	for _, v := range scope {
+		if v == guildID {
+			return true
+		}
+	}
+	return false
+}
+
+// Gets the guild scope of a command or group.
+func guildScope(cmdOrCat any) []objects.Snowflake {
+	switch x := cmdOrCat.(type) {
+	case *Command:
+		return x.Guilds
+	case *CommandGroup:
+		return x.Guilds
+	default:
+		return nil
+	}
+}
+
 // Used to define the autocomplete handler.
 func (c *CommandRouter) autocompleteHandler(loader loaderPassthrough) interactions.HandlerFunc {
 	return func(reqCtx context.Context, interaction *objects.Interaction) *objects.InteractionResponse {
+		// Create the error scope.
+		scope := newErrorScope(loader, interaction, InteractionKindAutocomplete)
+		scope.handler = c.roots.ErrorHandler
+		routerErrHandler := wrapRouterErrors(scope.handle, &scope.info)
+
 		// Parse the data JSON.
 		var rootData objects.ApplicationCommandInteractionData
 		if err := json.Unmarshal(interaction.Data, &rootData); err != nil {
-			return loader.errHandler(err)
+			return routerErrHandler(err)
 		}
 
 		// Wrap the data to let us traverse the tree easier.
@@ -250,6 +357,13 @@ func (c *CommandRouter) autocompleteHandler(loader loaderPassthrough) interactio
 			m = map[string]any{}
 		}
 
+		// Handle middleware.
+		middlewareList := list.New()
+		for _, v := range c.middleware {
+			middlewareList.PushBack(v)
+		}
+		aroundMiddleware := append([]AroundMiddlewareFunc(nil), c.aroundMiddleware...)
+
 		// Handle the traversal.
 		var cmd *Command
 		route := []string{"testframes", "autocompletes"}
@@ -262,17 +376,27 @@ func (c *CommandRouter) autocompleteHandler(loader loaderPassthrough) interactio
 			cmdOrCat, ok := m[data.name()]
 			if !ok {
 				// No command.
+				loader.log().Warn("command not found", append(logAttrs(InteractionKindAutocomplete, "", interaction), "name", data.name())...)
 				if _, ok = data.(rootDataWrapper); !ok {
 					// Backwards compatibility.
-					loader.errHandler(CommandDoesNotExist)
+					routerErrHandler(CommandDoesNotExist)
 				}
 				return nil
 			}
 
+			// Make sure the guild is within the scope.
+			if !inGuildScope(guildScope(cmdOrCat), interaction.GuildID) {
+				routerErrHandler(CommandNotInGuildScope)
+				return nil
+			}
+
 			// Check the type of the item.
 			switch x := cmdOrCat.(type) {
 			case *Command:
 				// Set the object and break.
+				if x.ErrorHandler != nil {
+					scope.handler = x.ErrorHandler
+				}
 				cmd = x
 				break cmdFor
 			case *CommandGroup:
@@ -283,20 +407,31 @@ func (c *CommandRouter) autocompleteHandler(loader loaderPassthrough) interactio
 					// Check the type of the option to make sure it is a command.
 					if type_ != objects.TypeSubCommandGroup {
 						// If the type is anything other than a subcommand group, that does not match with this being a group.
-						return loader.errHandler(CommandIsNotSubcommand)
+						return routerErrHandler(CommandIsNotSubcommand)
 					}
 				case objects.ApplicationCommandType:
 					// If this is the case, we are in the root. Look ahead to see if we are a group or a command.
 					if len(options) != 1 || (options[0].Type != objects.TypeSubCommand && options[0].Type != objects.TypeSubCommandGroup) {
 						// We are not a group. We know this because a root command acting as a group can only have one
 						// option which is either another group or a subcommand.
-						return loader.errHandler(CommandIsNotSubcommand)
+						return routerErrHandler(CommandIsNotSubcommand)
 					}
 				default:
 					// This should never happen.
 					panic("postcord internal error - unknown command Type field type")
 				}
 
+				// Handle middleware.
+				for _, v := range x.Middleware {
+					middlewareList.PushBack(v)
+				}
+				aroundMiddleware = append(aroundMiddleware, x.AroundMiddleware...)
+
+				// Handle the error handler.
+				if x.ErrorHandler != nil {
+					scope.handler = x.ErrorHandler
+				}
+
 				// Set the map to the subcommands from this group.
 				m = x.Subcommands
 
@@ -307,11 +442,15 @@ func (c *CommandRouter) autocompleteHandler(loader loaderPassthrough) interactio
 			}
 		}
 
+		// Set the route now it is known.
+		scope.info.Route = strings.Join(route[2:], " ")
+		loader.log().Debug("dispatching interaction", logAttrs(InteractionKindAutocomplete, scope.info.Route, interaction)...)
+
 		// Create the rest tape if this is wanted.
 		r := loader.rest
 		tape := tape{}
 		var returnedErr string
-		errHandler := loader.errHandler
+		errHandler := scope.handle
 		if loader.generateFrames {
 			r = &restTape{
 				tape: &tape,
@@ -319,22 +458,39 @@ func (c *CommandRouter) autocompleteHandler(loader loaderPassthrough) interactio
 			}
 			errHandler = func(err error) *objects.InteractionResponse {
 				returnedErr = err.Error()
-				return loader.errHandler(err)
+				return scope.handle(err)
 			}
 		}
 
+		// Instrument the dispatch. This is deferred first so it runs after the panic is recovered.
+		reqCtx, r, done := loader.startDispatch(reqCtx, r, scope)
+		var dispatchResp *objects.InteractionResponse
+		defer func() { done(dispatchResp) }()
+
+		// Handle if the function panics. The error handler is still called, but auto-complete cannot respond with a
+		// message, so nothing is returned the same as any other error.
+		defer func() {
+			if errGeneric := recover(); errGeneric != nil {
+				errHandler(newPanicError(errGeneric, scope.info.Route))
+			}
+		}()
+
 		// Create the command context.
-		_, mappedOptions := cmd.mapOptions(true, &rootData, options, errHandler)
+		_, mappedOptions := cmd.mapOptions(true, &rootData, options, wrapRouterErrors(errHandler, &scope.info))
 		if mappedOptions == nil {
 			return nil
 		}
 		ctx := &CommandRouterCtx{
 			errorHandler: errHandler,
+			route:        scope.info.Route,
+			logger:       loader.log(),
 			Interaction:  interaction,
+			Context:      reqCtx,
 			Command:      cmd,
 			Options:      mappedOptions,
 			RESTClient:   r,
 		}
+		scope.builder = &ctx.responseBuilder
 
 		// Now we have the command, we can process the autocomplete.
 		for _, v := range options {
@@ -342,7 +498,7 @@ func (c *CommandRouter) autocompleteHandler(loader loaderPassthrough) interactio
 				// Get the autocomplete function.
 				f := cmd.autocomplete[v.Name]
 				if f == nil {
-					loader.errHandler(NoAutoCompleteFunc)
+					routerErrHandler(NoAutoCompleteFunc)
 					return nil
 				}
 
@@ -353,69 +509,43 @@ func (c *CommandRouter) autocompleteHandler(loader loaderPassthrough) interactio
 
 					if loader.generateFrames {
 						// Now we have all the data, we can generate the frame.
-						fr := frame{interaction, tape, returnedErr, resp}
-						go fr.write(route...)
+						loader.writeFrame(&frame{interaction, tape, returnedErr, resp}, route...)
 					}
 				}()
 
-				// Get the options.
-				var resultOptions []*objects.ApplicationCommandOptionChoice
-				switch x := f.(type) {
-				case StringAutoCompleteFunc:
-					stringifiedOptions, err := x(ctx)
-					if err != nil {
-						errHandler(err)
-						return nil
-					}
-					resultOptions = make([]*objects.ApplicationCommandOptionChoice, len(stringifiedOptions))
-					for i, v := range stringifiedOptions {
-						resultOptions[i] = &objects.ApplicationCommandOptionChoice{
-							Name:  v.Name,
-							Value: v.Value,
-						}
-					}
-				case IntAutoCompleteFunc:
-					intOptions, err := x(ctx)
-					if err != nil {
-						errHandler(err)
-						return nil
-					}
-					resultOptions = make([]*objects.ApplicationCommandOptionChoice, len(intOptions))
-					for i, v := range intOptions {
-						resultOptions[i] = &objects.ApplicationCommandOptionChoice{
-							Name:  v.Name,
-							Value: v.Value,
-						}
-					}
-				case DoubleAutoCompleteFunc:
-					doubleOptions, err := x(ctx)
+				// Get the response by running the middleware chains followed by the auto-complete function.
+				if cmd.SkipInheritedMiddleware {
+					aroundMiddleware = nil
+				}
+				var err error
+				resp, err = runAroundMiddleware(ctx, aroundMiddleware, func() (*objects.InteractionResponse, error) {
+					var resultOptions []*objects.ApplicationCommandOptionChoice
+					err := runCommandMiddleware(ctx, cmd.middlewareList(middlewareList), func(ctx *CommandRouterCtx) (err error) {
+						resultOptions, err = cmd.runAutocomplete(f, ctx, v)
+						return
+					})
 					if err != nil {
-						errHandler(err)
-						return nil
+						return nil, err
 					}
-					resultOptions = make([]*objects.ApplicationCommandOptionChoice, len(doubleOptions))
-					for i, v := range doubleOptions {
-						resultOptions[i] = &objects.ApplicationCommandOptionChoice{
-							Name:  v.Name,
-							Value: v.Value,
-						}
-					}
-				default:
-					panic("postcord internal error - unknown autocomplete type")
-				}
-
-				// We have successfully got the result.
-				resp = &objects.InteractionResponse{
-					Type: objects.ResponseCommandAutocompleteResult,
-					Data: &objects.InteractionApplicationCommandCallbackData{
-						Choices: resultOptions,
-					},
+					return &objects.InteractionResponse{
+						Type: objects.ResponseCommandAutocompleteResult,
+						Data: &objects.InteractionApplicationCommandCallbackData{
+							Choices: resultOptions,
+						},
+					}, nil
+				})
+				if err != nil {
+					resp = nil
+					errHandler(err)
+					return nil
 				}
+				dispatchResp = resp
 				return resp
 			}
 		}
 
 		// None focused. This should never happen.
+		loader.log().Warn("no focused option", logAttrs(InteractionKindAutocomplete, scope.info.Route, interaction)...)
 		return nil
 	}
 }
@@ -459,6 +589,11 @@ func (c *CommandRouter) commandHandler(loader loaderPassthrough) interactions.Ha
 
 	// Process the response.
 	return func(reqCtx context.Context, interaction *objects.Interaction) *objects.InteractionResponse {
+		// Create the error scope.
+		scope := newErrorScope(loader, interaction, InteractionKindCommand)
+		scope.handler = c.roots.ErrorHandler
+		routerErrHandler := wrapRouterErrors(scope.handle, &scope.info)
+
 		// Handle middleware.
 		middlewareList := list.New()
 		if c.middleware != nil {
@@ -466,11 +601,12 @@ func (c *CommandRouter) commandHandler(loader loaderPassthrough) interactions.Ha
 				middlewareList.PushBack(v)
 			}
 		}
+		aroundMiddleware := append([]AroundMiddlewareFunc(nil), c.aroundMiddleware...)
 
 		// Parse the data JSON.
 		var rootData objects.ApplicationCommandInteractionData
 		if err := json.Unmarshal(interaction.Data, &rootData); err != nil {
-			return loader.errHandler(err)
+			return routerErrHandler(err)
 		}
 
 		// Defines the items changed whilst traversing the tree.
@@ -488,7 +624,7 @@ func (c *CommandRouter) commandHandler(loader loaderPassthrough) interactions.Ha
 		r := loader.rest
 		tape := tape{}
 		var returnedErr string
-		errHandler := loader.errHandler
+		errHandler := scope.handle
 		if loader.generateFrames {
 			r = &restTape{
 				tape: &tape,
@@ -496,7 +632,7 @@ func (c *CommandRouter) commandHandler(loader loaderPassthrough) interactions.Ha
 			}
 			errHandler = func(err error) *objects.InteractionResponse {
 				returnedErr = err.Error()
-				return loader.errHandler(err)
+				return scope.handle(err)
 			}
 		}
 
@@ -510,26 +646,47 @@ func (c *CommandRouter) commandHandler(loader loaderPassthrough) interactions.Ha
 			cmdOrCat, ok := m[data.name()]
 			if !ok {
 				// No command.
-				return nil
+				loader.log().Warn("command not found", append(logAttrs(InteractionKindCommand, "", interaction), "name", data.name())...)
+				resp, _ := loader.notFound(c.notFoundHandler, &NotFoundInfo{
+					Interaction: interaction,
+					Kind:        InteractionKindCommand,
+					ID:          strings.Join(route[2:], " "),
+				})
+				return resp
+			}
+
+			// Make sure the guild is within the scope.
+			if !inGuildScope(guildScope(cmdOrCat), interaction.GuildID) {
+				return routerErrHandler(CommandNotInGuildScope)
 			}
 
 			// Check the type of the item.
 			switch x := cmdOrCat.(type) {
 			case *Command:
 				// In this case, we should go ahead and execute.
-				resp := x.execute(reqCtx, commandExecutionOptions{
-					restClient:       r,
+				scope.info.Route = strings.Join(route[2:], " ")
+				if x.ErrorHandler != nil {
+					scope.handler = x.ErrorHandler
+				}
+				loader.log().Debug("dispatching interaction", logAttrs(InteractionKindCommand, scope.info.Route, interaction)...)
+				cmdCtx, cmdRest, done := loader.startDispatch(reqCtx, r, scope)
+				resp := x.execute(cmdCtx, commandExecutionOptions{
+					restClient:       cmdRest,
 					exceptionHandler: errHandler,
 					allowedMentions:  allowedMentions,
 					interaction:      interaction,
 					modalRouter:      loader.modalRouter,
 					data:             &rootData,
 					options:          options,
+					aroundMiddleware: aroundMiddleware,
+					route:            scope.info.Route,
+					errorScope:       scope,
+					logger:           loader.log(),
 				}, middlewareList)
+				done(resp)
 				if loader.generateFrames {
 					// Now we have all the data, we can generate the frame.
-					f := frame{interaction, tape, returnedErr, resp}
-					go f.write(route...)
+					loader.writeFrame(&frame{interaction, tape, returnedErr, resp}, route...)
 				}
 				return resp
 			case *CommandGroup:
@@ -540,14 +697,14 @@ func (c *CommandRouter) commandHandler(loader loaderPassthrough) interactions.Ha
 					// Check the type of the option to make sure it is a command.
 					if type_ != objects.TypeSubCommandGroup {
 						// If the type is anything other than a subcommand group, that does not match with this being a group.
-						return loader.errHandler(CommandIsNotSubcommand)
+						return routerErrHandler(CommandIsNotSubcommand)
 					}
 				case objects.ApplicationCommandType:
 					// If this is the case, we are in the root. Look ahead to see if we are a group or a command.
 					if len(options) != 1 || (options[0].Type != objects.TypeSubCommand && options[0].Type != objects.TypeSubCommandGroup) {
 						// We are not a group. We know this because a root command acting as a group can only have one
 						// option which is either another group or a subcommand.
-						return loader.errHandler(CommandIsNotSubcommand)
+						return routerErrHandler(CommandIsNotSubcommand)
 					}
 				default:
 					// This should never happen.
@@ -565,6 +722,12 @@ func (c *CommandRouter) commandHandler(loader loaderPassthrough) interactions.Ha
 						middlewareList.PushBack(v)
 					}
 				}
+				aroundMiddleware = append(aroundMiddleware, x.AroundMiddleware...)
+
+				// Handle the error handler.
+				if x.ErrorHandler != nil {
+					scope.handler = x.ErrorHandler
+				}
 
 				// Set the map to the subcommands from this group.
 				m = x.Subcommands
@@ -587,69 +750,74 @@ func (c *CommandRouter) build(loader loaderPassthrough) (interactions.HandlerFun
 }
 
 // Get the options for a command or category.
-func getOptions(cmdOrCat any) []objects.ApplicationCommandOption {
+func getOptions(cmdOrCat any) []DiscordCommandOption {
 	switch x := cmdOrCat.(type) {
 	case *Command:
-		unptr := make([]objects.ApplicationCommandOption, len(x.Options))
-		for i, v := range x.Options {
-			unptr[i] = *v
-		}
-		return unptr
+		return x.discordOptions()
 	case *CommandGroup:
-		cmds := make([]objects.ApplicationCommandOption, len(x.Subcommands))
+		cmds := make([]DiscordCommandOption, len(x.Subcommands))
 		i := 0
 		for k, v := range x.Subcommands {
 			// Create a option based on the sub-command.
-			processCommand := func(cmdName, description string, options []*objects.ApplicationCommandOption) objects.ApplicationCommandOption {
+			processCommand := func(cmdName string, cmd *Command) DiscordCommandOption {
+				description := cmd.Description
 				if description == "" {
 					description = "No description provided."
 				}
-				unptr := make([]objects.ApplicationCommandOption, len(options))
-				for i, v := range options {
-					unptr[i] = *v
-				}
-				return objects.ApplicationCommandOption{
-					OptionType:  objects.TypeSubCommand,
-					Name:        cmdName,
-					Description: description,
-					Options:     unptr,
+				return DiscordCommandOption{
+					ApplicationCommandOption: objects.ApplicationCommandOption{
+						OptionType:  objects.TypeSubCommand,
+						Name:        cmdName,
+						Description: description,
+					},
+					NameLocalizations:        cmd.NameLocalizations,
+					DescriptionLocalizations: cmd.DescriptionLocalizations,
+					Options:                  cmd.discordOptions(),
 				}
 			}
 			switch y := v.(type) {
 			case *Command:
 				// Create a sub-command.
-				cmds[i] = processCommand(k, y.Description, y.Options)
+				cmds[i] = processCommand(k, y)
 			case *CommandGroup:
 				// Do some incredibly mind spiralling shit.
 				description := y.Description
 				if description == "" {
 					description = "No description provided."
 				}
-				children := make([]objects.ApplicationCommandOption, len(y.Subcommands))
+				children := make([]DiscordCommandOption, len(y.Subcommands))
 				childrenIndex := 0
 				for k, v := range y.Subcommands {
 					switch x := v.(type) {
 					case *Command:
-						children[childrenIndex] = processCommand(k, x.Description, x.Options)
+						children[childrenIndex] = processCommand(k, x)
 					case *CommandGroup:
 						description := x.Description
 						if description == "" {
 							description = "No description provided."
 						}
-						children[childrenIndex] = objects.ApplicationCommandOption{
-							OptionType:  objects.TypeSubCommandGroup,
-							Name:        k,
-							Description: description,
-							Options:     getOptions(v),
+						children[childrenIndex] = DiscordCommandOption{
+							ApplicationCommandOption: objects.ApplicationCommandOption{
+								OptionType:  objects.TypeSubCommandGroup,
+								Name:        k,
+								Description: description,
+							},
+							NameLocalizations:        x.NameLocalizations,
+							DescriptionLocalizations: x.DescriptionLocalizations,
+							Options:                  getOptions(v),
 						}
 					}
 					childrenIndex++
 				}
-				cmds[i] = objects.ApplicationCommandOption{
-					OptionType:  objects.TypeSubCommandGroup,
-					Name:        k,
-					Description: description,
-					Options:     children,
+				cmds[i] = DiscordCommandOption{
+					ApplicationCommandOption: objects.ApplicationCommandOption{
+						OptionType:  objects.TypeSubCommandGroup,
+						Name:        k,
+						Description: description,
+					},
+					NameLocalizations:        y.NameLocalizations,
+					DescriptionLocalizations: y.DescriptionLocalizations,
+					Options:                  children,
 				}
 			}
 
@@ -662,151 +830,122 @@ func getOptions(cmdOrCat any) []objects.ApplicationCommandOption {
 	}
 }
 
-// FormulateDiscordCommands is used to formulate the commands in such a way that they can be uploaded to Discord.
-func (c *CommandRouter) FormulateDiscordCommands() []*objects.ApplicationCommand {
-	cmds := make([]*objects.ApplicationCommand, len(c.roots.Subcommands))
-	i := 0
-	for k, v := range c.roots.Subcommands {
-		// Create the command.
-		description := ""
-		commandType := objects.CommandTypeChatInput
+// Formulates a root level command or group in such a way that it can be uploaded to Discord.
+func formulateDiscordCommand(name string, cmdOrCat any) *DiscordCommand {
+	// Create the command.
+	description := ""
+	commandType := objects.CommandTypeChatInput
 
-		cmd := &objects.ApplicationCommand{
-			Name:        k,
+	cmd := &DiscordCommand{
+		ApplicationCommand: objects.ApplicationCommand{
+			Name:        name,
 			Description: description,
-			Options:     getOptions(v),
-		}
+		},
+		Options: getOptions(cmdOrCat),
+	}
 
-		switch x := v.(type) {
-		case *Command:
-			cmd.Description = x.Description
-			cmd.DefaultPermissions = x.DefaultPermissions
-			cmd.AllowUseInDMs = x.UseInDMs
-			if x.commandType != 0 {
-				commandType = objects.ApplicationCommandType(x.commandType)
-			}
-		case *CommandGroup:
-			cmd.Description = x.Description
-			cmd.DefaultPermissions = x.DefaultPermissions
-			cmd.AllowUseInDMs = x.UseInDMs
+	switch x := cmdOrCat.(type) {
+	case *Command:
+		cmd.Description = x.Description
+		cmd.DefaultPermissions = x.DefaultPermissions
+		cmd.AllowUseInDMs = x.UseInDMs
+		cmd.NameLocalizations = x.NameLocalizations
+		cmd.DescriptionLocalizations = x.DescriptionLocalizations
+		if x.commandType != 0 {
+			commandType = objects.ApplicationCommandType(x.commandType)
 		}
+	case *CommandGroup:
+		cmd.Description = x.Description
+		cmd.DefaultPermissions = x.DefaultPermissions
+		cmd.AllowUseInDMs = x.UseInDMs
+		cmd.NameLocalizations = x.NameLocalizations
+		cmd.DescriptionLocalizations = x.DescriptionLocalizations
+	}
 
-		if cmd.Description == "" {
-			// If the description is mandatory, set it to a none provided message.
-			if commandType == objects.CommandTypeChatInput {
-				cmd.Description = "No description provided."
-			}
-		} else if commandType != objects.CommandTypeChatInput {
-			// If no description is mandatory, make sure it is unset.
-			cmd.Description = ""
+	if cmd.Description == "" {
+		// If the description is mandatory, set it to a none provided message.
+		if commandType == objects.CommandTypeChatInput {
+			cmd.Description = "No description provided."
 		}
+	} else if commandType != objects.CommandTypeChatInput {
+		// If no description is mandatory, make sure it is unset.
+		cmd.Description = ""
+		cmd.DescriptionLocalizations = nil
+	}
 
-		cmd.Type = &commandType
-		cmds[i] = cmd
+	cmd.Type = &commandType
+	return cmd
+}
 
-		// Add to the index.
-		i++
+// FormulateExtendedDiscordCommands is used to formulate the global commands in such a way that they can be uploaded to
+// Discord, including the fields which objects.ApplicationCommand does not support such as localizations. Commands
+// scoped to guilds are not included. Use FormulateExtendedGuildDiscordCommands to get those.
+func (c *CommandRouter) FormulateExtendedDiscordCommands() []*DiscordCommand {
+	cmds := make([]*DiscordCommand, 0, len(c.roots.Subcommands))
+	for k, v := range c.roots.Subcommands {
+		if len(guildScope(v)) == 0 {
+			cmds = append(cmds, formulateDiscordCommand(k, v))
+		}
 	}
 	return cmds
 }
 
-// Tag name for option parsing
-const selectorTagName = "discord"
-
-// Bind allows you to bind the option values to a struct for easy access
-func (c *CommandRouterCtx) Bind(data any) error {
-	structType := reflect.TypeOf(data)
-	if structType.Kind() != reflect.Ptr {
-		return errors.New("data must be a pointer")
+// FormulateExtendedGuildDiscordCommands is used to formulate the guild scoped commands in the same way as
+// FormulateExtendedDiscordCommands. The result is a map of guild ID to the commands which should be registered in that guild.
+func (c *CommandRouter) FormulateExtendedGuildDiscordCommands() map[objects.Snowflake][]*DiscordCommand {
+	guilds := map[objects.Snowflake][]*DiscordCommand{}
+	for k, v := range c.roots.Subcommands {
+		for _, guildID := range guildScope(v) {
+			guilds[guildID] = append(guilds[guildID], formulateDiscordCommand(k, v))
+		}
 	}
+	return guilds
+}
 
-	elem := structType.Elem()
-	if elem.Kind() != reflect.Struct {
-		return errors.New("value of data pointer must be a struct")
+// Converts a slice of commands into the type used by Postcord.
+func objectifyCommands(cmds []*DiscordCommand) []*objects.ApplicationCommand {
+	x := make([]*objects.ApplicationCommand, len(cmds))
+	for i, v := range cmds {
+		x[i] = v.Object()
 	}
+	return x
+}
 
-	v := reflect.ValueOf(data).Elem()
-
-	for i := 0; i < elem.NumField(); i++ {
-		field := elem.Field(i)
-		if field.Tag == "" {
-			continue
-		}
-
-		tagValue := field.Tag.Get(selectorTagName)
-		if tagValue == "" {
-			continue
-		}
-
-		kind := field.Type.Kind()
-
-		option, ok := c.Options[tagValue]
-		if !ok {
-			continue
-		}
-		optionVal := reflect.ValueOf(option)
-
-		i := -1
-		for j, v := range c.Command.Options {
-			if v.Name == tagValue {
-				i = j
-				break
-			}
-		}
-
-		if !(i < len(c.Command.Options) && c.Command.Options[i].Name == tagValue) {
-			continue
-		}
-
-		fieldPointer := v.FieldByName(field.Name)
-		if !fieldPointer.CanSet() {
-			continue
-		}
-
-		switch c.Command.Options[i].OptionType {
-		case objects.TypeString:
-			if kind != reflect.String {
-				return fmt.Errorf("option %s is a StringOption, but the struct type is not a string", tagValue)
-			}
-			fmt.Printf("setting %s to %s\n", field.Name, optionVal.String())
-			fieldPointer.Set(optionVal)
-		case objects.TypeInteger:
-			if kind != reflect.Int && kind != reflect.Int64 {
-				return fmt.Errorf("option %s is a IntegerOption, but the struct type is not an integer", tagValue)
-			}
-			fmt.Printf("setting %s to %d\n", field.Name, optionVal.Int())
-			fieldPointer.Set(optionVal)
-		case objects.TypeNumber:
-			if kind != reflect.Float64 {
-				return fmt.Errorf("option %s is a DoubleOption, but the struct type is not a float64", tagValue)
-			}
-			fmt.Printf("setting %s to %f\n", field.Name, optionVal.Float())
-			fieldPointer.Set(optionVal)
-		case objects.TypeBoolean:
-			if kind != reflect.Bool {
-				return fmt.Errorf("option %s is a BoolOption, but the struct type is not a bool", tagValue)
-			}
-			fmt.Printf("setting %s to %t\n", field.Name, optionVal.Bool())
-			fieldPointer.Set(optionVal)
-		case objects.TypeChannel, objects.TypeRole, objects.TypeUser, objects.TypeMentionable:
-			if kind != reflect.Interface {
-				return fmt.Errorf("option %s is a Resolvable type, but the interface type is not a Resolvable", tagValue)
-			}
+// FormulateDiscordCommands is used to formulate the global commands in such a way that they can be uploaded to Discord.
+// Commands scoped to guilds are not included. Use FormulateGuildDiscordCommands to get those. Note that
+// objects.ApplicationCommand does not support localizations, so use FormulateExtendedDiscordCommands if you need them.
+func (c *CommandRouter) FormulateDiscordCommands() []*objects.ApplicationCommand {
+	return objectifyCommands(c.FormulateExtendedDiscordCommands())
+}
 
-			if optionVal.Kind() == reflect.Ptr && optionVal.IsNil() {
-				return fmt.Errorf("option %s is a Resolvable type, but the value is nil", tagValue)
-			}
+// FormulateGuildDiscordCommands is used to formulate the guild scoped commands in such a way that they can be uploaded
+// to Discord. The result is a map of guild ID to the commands which should be registered in that guild.
+func (c *CommandRouter) FormulateGuildDiscordCommands() map[objects.Snowflake][]*objects.ApplicationCommand {
+	guilds := map[objects.Snowflake][]*objects.ApplicationCommand{}
+	for k, v := range c.FormulateExtendedGuildDiscordCommands() {
+		guilds[k] = objectifyCommands(v)
+	}
+	return guilds
+}
 
-			if optionVal.IsZero() {
-				return fmt.Errorf("option %s is a Resolvable type, but the type is not a Resolvable", tagValue)
-			}
+// Tag name for option parsing
+const selectorTagName = "discord"
 
-			fmt.Printf("setting %s to %s\n", field.Name, optionVal.String())
-			fieldPointer.Set(optionVal)
-		default:
-			return fmt.Errorf("option %s has an incompatible type", tagValue)
-		}
+// Bind allows you to bind the option values to a struct for easy access. Fields are bound to the option named in the
+// "discord" tag, and fields for options which were not set are left alone unless a "default" tag is set. Fields can be
+// any of the following:
+//   - A string, bool, or any numeric type (including objects.Snowflake) for options of a compatible type.
+//   - A resolvable interface, objects.Snowflake, or the resolved type (such as objects.User, objects.GuildMember,
+//     objects.Channel, objects.Role, or objects.Attachment) for resolvable options.
+//   - A pointer to any of the above, which is left nil if the option was not set. This is useful for optional options.
+//   - A struct (or pointer to one) tagged with the name of a sub-command, which is bound when that sub-command is
+//     invoked. This allows one struct to be used for every command in a group. Embedded structs are also bound.
+//
+// If an option cannot be bound, a *BindError is returned.
+func (c *CommandRouterCtx) Bind(data any) error {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return InvalidBindTarget
 	}
-
-	return nil
+	return c.bindStruct(v.Elem())
 }
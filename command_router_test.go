@@ -281,9 +281,14 @@ func TestCommandGroup_Use(t *testing.T) {
 
 var dummyRootCommandGroup = &CommandGroup{}
 
+var (
+	groupOptsPermissions = permissions.PermissionBit(69)
+	groupOptsUseInDMs    = true
+)
+
 var groupOpts = &CommandGroupOptions{
-	DefaultPermissions: 69,
-	UseInDMs:           true,
+	DefaultPermissions: &groupOptsPermissions,
+	UseInDMs:           &groupOptsUseInDMs,
 }
 
 var commandGroupTests = []struct {
@@ -310,8 +315,8 @@ var commandGroupTests = []struct {
 		expects: &CommandGroup{
 			level:              1,
 			parent:             dummyRootCommandGroup,
-			UseInDMs:           &groupOpts.UseInDMs,
-			DefaultPermissions: &groupOpts.DefaultPermissions,
+			UseInDMs:           groupOpts.UseInDMs,
+			DefaultPermissions: groupOpts.DefaultPermissions,
 			Description:        "def",
 			Subcommands:        map[string]any{},
 		},
@@ -325,8 +330,8 @@ var commandGroupTests = []struct {
 		expects: &CommandGroup{
 			level:              2,
 			parent:             dummyRootCommandGroup,
-			UseInDMs:           &groupOpts.UseInDMs,
-			DefaultPermissions: &groupOpts.DefaultPermissions,
+			UseInDMs:           groupOpts.UseInDMs,
+			DefaultPermissions: groupOpts.DefaultPermissions,
 			Description:        "def",
 			Subcommands:        map[string]any{},
 		},
@@ -411,13 +416,13 @@ func TestCommandRouter_Use(t *testing.T) {
 
 func TestCommandRouter_NewCommandGroup(t *testing.T) {
 	r := &CommandRouter{}
+	pbit := permissions.PermissionBit(1)
+	tr := true
 	group, err := r.NewCommandGroup("abc", "def", &CommandGroupOptions{
-		DefaultPermissions: 1,
-		UseInDMs:           true,
+		DefaultPermissions: &pbit,
+		UseInDMs:           &tr,
 	})
 	assert.NoError(t, err)
-	pbit := permissions.PermissionBit(1)
-	tr := true
 	assert.Equal(t, &CommandGroup{
 		level:              1,
 		Description:        "def",
@@ -429,16 +434,18 @@ func TestCommandRouter_NewCommandGroup(t *testing.T) {
 
 func TestCommandRouter_MustNewCommandGroup(t *testing.T) {
 	r := &CommandRouter{}
+	pbit := permissions.PermissionBit(69)
+	tr := true
 	opts := &CommandGroupOptions{
-		DefaultPermissions: 69,
-		UseInDMs:           true,
+		DefaultPermissions: &pbit,
+		UseInDMs:           &tr,
 	}
 	group, errResult := unpanicCommandGroup(r, "abc", "def", opts)
 	assert.Equal(t, "", errResult)
 	assert.Equal(t, &CommandGroup{
 		level:              1,
-		UseInDMs:           &opts.UseInDMs,
-		DefaultPermissions: &opts.DefaultPermissions,
+		UseInDMs:           opts.UseInDMs,
+		DefaultPermissions: opts.DefaultPermissions,
 		Description:        "def",
 		Subcommands:        map[string]any{},
 	}, group)
@@ -1663,30 +1670,30 @@ func TestCommandRouter_FormulateDiscordCommands(t *testing.T) {
 							return nil, nil
 						}),
 					).
-					StringOption("optional_string_option", "The optional string option", false, nil).
 					IntOption("req_int_option", "the required int option", true,
 						IntAutoCompleteFuncBuilder(func(ctx *CommandRouterCtx) ([]IntChoice, error) {
 							// Important to note this doesn't actually work.
 							return nil, nil
 						}),
 					).
-					IntOption("optional_int_option", "The optional int option", false, nil).
 					DoubleOption("req_double_option", "the required double option", true,
 						DoubleAutoCompleteFuncBuilder(func(ctx *CommandRouterCtx) ([]DoubleChoice, error) {
 							// Important to note this doesn't actually work.
 							return nil, nil
 						}),
 					).
-					IntOption("optional_double_option", "The optional double option", false, nil).
 					BoolOption("req_bool_option", "the required boolean option", true).
-					BoolOption("optional_bool_option", "the optional boolean option", false).
 					RoleOption("req_role_option", "the required role option", true).
-					RoleOption("optional_role_option", "the optional role option", false).
 					ChannelOption("req_channel_option", "the required channel option", true).
-					ChannelOption("optional_channel_option", "the optional channel option", false).
 					MentionableOption("req_mentionable_option", "the required mentionable option", true).
-					MentionableOption("optional_mentionable_option", "the optional mentionable option", false).
 					UserOption("req_user_option", "the required user option", true).
+					StringOption("optional_string_option", "The optional string option", false, nil).
+					IntOption("optional_int_option", "The optional int option", false, nil).
+					IntOption("optional_double_option", "The optional double option", false, nil).
+					BoolOption("optional_bool_option", "the optional boolean option", false).
+					RoleOption("optional_role_option", "the optional role option", false).
+					ChannelOption("optional_channel_option", "the optional channel option", false).
+					MentionableOption("optional_mentionable_option", "the optional mentionable option", false).
 					UserOption("optional_user_option", "the optional user option", false).
 					MustBuild()
 
@@ -1699,8 +1706,6 @@ func TestCommandRouter_FormulateDiscordCommands(t *testing.T) {
 
 				// Defines a command group with sub-groups.
 				g = r.MustNewCommandGroup("group2", "group 2", nil)
-				g.MustNewCommandGroup("subgroup1", "subgroup 1", nil).
-					NewCommandBuilder("subcmd1").Description("first command in subgroup").MustBuild()
 				s := g.MustNewCommandGroup("subgroup1", "subgroup 1", nil)
 				s.NewCommandBuilder("subcmd1").Description("first command in subgroup").MustBuild()
 				s.NewCommandBuilder("subcmd2").Description("second command in subgroup").MustBuild()
@@ -1825,3 +1830,121 @@ func TestCommandRouterCtx_Bind(t *testing.T) {
 		})
 	}
 }
+
+func TestCommandRouter_guildScope(t *testing.T) {
+	tests := []struct {
+		name string
+
+		guildID objects.Snowflake
+		cmd     string
+
+		wantsErr string
+	}{
+		{
+			name:    "global command",
+			guildID: 1,
+			cmd:     "global",
+		},
+		{
+			name:    "command in scope",
+			guildID: 1234,
+			cmd:     "scoped",
+		},
+		{
+			name:     "command out of scope",
+			guildID:  1,
+			cmd:      "scoped",
+			wantsErr: "the command is not available in this guild",
+		},
+		{
+			name:     "command in dm",
+			cmd:      "scoped",
+			wantsErr: "the command is not available in this guild",
+		},
+		{
+			name:    "group in scope",
+			guildID: 1234,
+			cmd:     "group",
+		},
+		{
+			name:     "group out of scope",
+			guildID:  1,
+			cmd:      "group",
+			wantsErr: "the command is not available in this guild",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(ctx *CommandRouterCtx) error {
+				ctx.SetContent("hello world")
+				return nil
+			}
+			r := &CommandRouter{}
+			r.NewCommandBuilder("global").Handler(handler).MustBuild()
+			r.NewCommandBuilder("scoped").Guilds(1234, 5678).Handler(handler).MustBuild()
+			r.MustNewCommandGroup("group", "", &CommandGroupOptions{Guilds: []objects.Snowflake{1234}}).
+				NewCommandBuilder("sub").Handler(handler).MustBuild()
+
+			// Setting only the guilds must not change the permissions or DM setting of the group.
+			b, err := json.Marshal(r.FormulateGuildDiscordCommands()[1234])
+			require.NoError(t, err)
+			assert.NotContains(t, string(b), "default_member_permissions")
+			assert.NotContains(t, string(b), "dm_permission")
+
+			var errResult error
+			cmdHandler, _ := r.build(loaderPassthrough{
+				rest: dummyRestClient,
+				errHandler: func(err error) *objects.InteractionResponse {
+					errResult = err
+					return nil
+				},
+			})
+			data := &objects.ApplicationCommandInteractionData{Name: tt.cmd, Type: objects.CommandTypeChatInput}
+			if tt.cmd == "group" {
+				data.Options = []*objects.ApplicationCommandInteractionDataOption{
+					{Type: objects.TypeSubCommand, Name: "sub"},
+				}
+			}
+			interaction := mockInteraction(data)
+			interaction.GuildID = tt.guildID
+			resp := cmdHandler(context.Background(), interaction)
+			if tt.wantsErr == "" {
+				assert.NoError(t, errResult)
+				require.NotNil(t, resp)
+				assert.Equal(t, "hello world", resp.Data.Content)
+			} else {
+				assert.EqualError(t, errResult, tt.wantsErr)
+			}
+		})
+	}
+}
+
+func TestCommandRouter_FormulateGuildDiscordCommands(t *testing.T) {
+	r := &CommandRouter{}
+	r.NewCommandBuilder("global").Description("global command").MustBuild()
+	r.NewCommandBuilder("scoped").Description("scoped command").Guilds(1, 2).MustBuild()
+	r.MustNewCommandGroup("group", "scoped group", &CommandGroupOptions{Guilds: []objects.Snowflake{2}}).
+		NewCommandBuilder("sub").MustBuild()
+
+	global := r.FormulateDiscordCommands()
+	require.Len(t, global, 1)
+	assert.Equal(t, "global", global[0].Name)
+
+	guilds := r.FormulateGuildDiscordCommands()
+	require.Len(t, guilds, 2)
+	require.Len(t, guilds[1], 1)
+	assert.Equal(t, "scoped", guilds[1][0].Name)
+	require.Len(t, guilds[2], 2)
+	names := []string{guilds[2][0].Name, guilds[2][1].Name}
+	assert.ElementsMatch(t, []string{"scoped", "group"}, names)
+
+	// Check the group only has the fields which were set.
+	group := guilds[2][0]
+	if group.Name != "group" {
+		group = guilds[2][1]
+	}
+	b, err := json.Marshal(group)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":1,"name":"group","description":"scoped group","options":[`+
+		`{"type":1,"name":"sub","description":"No description provided."}]}`, string(b))
+}
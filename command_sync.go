@@ -0,0 +1,313 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Postcord/objects"
+	"github.com/Postcord/rest"
+)
+
+// SyncAction is used to define the action a sync takes on a command.
+type SyncAction int
+
+const (
+	// SyncActionCreate is used when a command is in the router but not registered with Discord.
+	SyncActionCreate SyncAction = iota + 1
+
+	// SyncActionUpdate is used when a command is registered with Discord but differs from the router.
+	SyncActionUpdate
+
+	// SyncActionDelete is used when a command is registered with Discord but is not in the router.
+	SyncActionDelete
+)
+
+// String implements the fmt.Stringer interface.
+func (a SyncAction) String() string {
+	switch a {
+	case SyncActionCreate:
+		return "create"
+	case SyncActionUpdate:
+		return "update"
+	case SyncActionDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a SyncAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// CommandChange is used to define a change that a sync made (or would make during a dry run).
+type CommandChange struct {
+	// Action is the action taken on the command.
+	Action SyncAction `json:"action"`
+
+	// Name is the name of the command.
+	Name string `json:"name"`
+
+	// Type is the type of the command.
+	Type objects.ApplicationCommandType `json:"type"`
+
+	// Existing is the command registered with Discord. This is nil when the command is being created.
+	Existing *DiscordCommand `json:"existing,omitempty"`
+
+	// Desired is the command formulated from the router. This is nil when the command is being deleted.
+	Desired *DiscordCommand `json:"desired,omitempty"`
+}
+
+// SyncReport is used to report the outcome of a sync.
+type SyncReport struct {
+	// DryRun defines if this report is a plan rather than a record of changes made.
+	DryRun bool `json:"dry_run"`
+
+	// Changes defines the changes made to the commands, sorted by name.
+	Changes []*CommandChange `json:"changes"`
+
+	// Unchanged defines the names of the commands which already matched Discord.
+	Unchanged []string `json:"unchanged"`
+
+	// Guilds defines the reports for each guild which was synced. This is only set on the top level report.
+	Guilds map[objects.Snowflake]*SyncReport `json:"guilds,omitempty"`
+}
+
+// HasChanges is used to check if the sync made (or would make) any changes.
+func (r *SyncReport) HasChanges() bool {
+	if len(r.Changes) != 0 {
+		return true
+	}
+	for _, v := range r.Guilds {
+		if v.HasChanges() {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncOptions is used to define the options for a sync.
+type SyncOptions struct {
+	// DryRun is used to compute the plan without making any mutating REST calls.
+	DryRun bool
+
+	// KeepUnknown is used to stop commands which are registered with Discord but are not in the router from being deleted.
+	KeepUnknown bool
+
+	// Guilds is used to define additional guilds to sync. Guilds with scoped commands are always synced, so this is
+	// used to clean up guilds which no longer have any commands scoped to them.
+	Guilds []objects.Snowflake
+}
+
+// Defines the key used to match commands. Discord allows the same name to be used across command types.
+type syncKey struct {
+	name  string
+	type_ objects.ApplicationCommandType
+}
+
+// Gets the type of the command, defaulting to chat input as Discord does.
+func syncCommandType(cmd *DiscordCommand) objects.ApplicationCommandType {
+	if cmd.Type == nil || *cmd.Type == 0 {
+		return objects.CommandTypeChatInput
+	}
+	return *cmd.Type
+}
+
+// Normalizes the options into a canonical form. Sub-command and sub-command group ordering comes from map iteration
+// and has no meaning to Discord, so they are sorted by name. Other options are ordered by the user and are left alone.
+// Empty slices and maps are omitted when marshalled, so they compare equal to the nil values Discord sends.
+func normalizeSyncOptions(options []DiscordCommandOption) []DiscordCommandOption {
+	normalized := make([]DiscordCommandOption, len(options))
+	subcommands := true
+	for i, v := range options {
+		if v.OptionType != objects.TypeSubCommand && v.OptionType != objects.TypeSubCommandGroup {
+			subcommands = false
+		}
+		v.Options = normalizeSyncOptions(v.Options)
+		normalized[i] = v
+	}
+	if subcommands {
+		sort.SliceStable(normalized, func(i, j int) bool {
+			return normalized[i].Name < normalized[j].Name
+		})
+	}
+	return normalized
+}
+
+// Returns the canonical JSON for a command. Fields which are set by Discord are stripped and defaults are filled in,
+// so that a command fetched from Discord and a formulated command compare equal if they are semantically the same.
+func canonicalSyncCommand(cmd *DiscordCommand) []byte {
+	type_ := syncCommandType(cmd)
+	useInDMs := true
+	if cmd.AllowUseInDMs != nil {
+		useInDMs = *cmd.AllowUseInDMs
+	}
+	canonical := DiscordCommand{
+		ApplicationCommand: objects.ApplicationCommand{
+			Type:               &type_,
+			Name:               cmd.Name,
+			Description:        cmd.Description,
+			DefaultPermissions: cmd.DefaultPermissions,
+			AllowUseInDMs:      &useInDMs,
+		},
+		NameLocalizations:        cmd.NameLocalizations,
+		DescriptionLocalizations: cmd.DescriptionLocalizations,
+		Options:                  normalizeSyncOptions(cmd.Options),
+	}
+	b, err := json.Marshal(&canonical)
+	if err != nil {
+		// This should never happen since it was either unmarshalled or built by us.
+		panic(err)
+	}
+	return b
+}
+
+// Used to compute the changes between the commands registered with Discord and the commands in the router.
+func diffCommands(existing, desired []*DiscordCommand, keepUnknown bool) (changes []*CommandChange, unchanged []string) {
+	// Map out the commands registered with Discord.
+	existingMap := make(map[syncKey]*DiscordCommand, len(existing))
+	for _, v := range existing {
+		existingMap[syncKey{v.Name, syncCommandType(v)}] = v
+	}
+
+	// Handle any creates or updates.
+	changes = []*CommandChange{}
+	unchanged = []string{}
+	for _, v := range desired {
+		key := syncKey{v.Name, syncCommandType(v)}
+		current, ok := existingMap[key]
+		if !ok {
+			changes = append(changes, &CommandChange{Action: SyncActionCreate, Name: v.Name, Type: key.type_, Desired: v})
+			continue
+		}
+		delete(existingMap, key)
+		if bytes.Equal(canonicalSyncCommand(current), canonicalSyncCommand(v)) {
+			unchanged = append(unchanged, v.Name)
+		} else {
+			changes = append(changes, &CommandChange{Action: SyncActionUpdate, Name: v.Name, Type: key.type_, Existing: current, Desired: v})
+		}
+	}
+
+	// Anything left over is not in the router.
+	if !keepUnknown {
+		for key, v := range existingMap {
+			changes = append(changes, &CommandChange{Action: SyncActionDelete, Name: key.name, Type: key.type_, Existing: v})
+		}
+	}
+
+	// Sort the results so the report is stable.
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Name == changes[j].Name {
+			return changes[i].Type < changes[j].Type
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	sort.Strings(unchanged)
+	return
+}
+
+// Used to apply the changes specified with the sync client. If the guild ID is 0, the changes are made to global commands.
+func applyCommandChanges(ctx context.Context, restClient CommandSyncClient, appID, guildID objects.Snowflake, changes []*CommandChange) error {
+	for _, v := range changes {
+		var err error
+		switch v.Action {
+		case SyncActionCreate:
+			if guildID == 0 {
+				_, err = restClient.CreateDiscordCommand(ctx, appID, v.Desired)
+			} else {
+				_, err = restClient.AddGuildDiscordCommand(ctx, appID, guildID, v.Desired)
+			}
+		case SyncActionUpdate:
+			if guildID == 0 {
+				_, err = restClient.UpdateDiscordCommand(ctx, appID, v.Existing.ID, v.Desired)
+			} else {
+				_, err = restClient.UpdateGuildDiscordCommand(ctx, appID, guildID, v.Existing.ID, v.Desired)
+			}
+		case SyncActionDelete:
+			if guildID == 0 {
+				err = restClient.DeleteCommand(ctx, appID, v.Existing.ID)
+			} else {
+				err = restClient.DeleteGuildCommand(ctx, appID, guildID, v.Existing.ID)
+			}
+		}
+		if err != nil {
+			if guildID == 0 {
+				return fmt.Errorf("failed to %s command %s: %w", v.Action, v.Name, err)
+			}
+			return fmt.Errorf("failed to %s command %s in guild %s: %w", v.Action, v.Name, guildID, err)
+		}
+	}
+	return nil
+}
+
+// Sync is used to make the commands registered with Discord match the router. Only commands which have changed are
+// created, updated, or deleted. Global commands are synced along with the commands in every guild which has commands
+// scoped to it. If opts is nil, the default options are used. Note that during a dry run, the only REST calls made are
+// to fetch the registered commands, which makes it suitable for running against a recorded REST tape. On error, the
+// report contains the changes which were planned. The commands are compared and sent with the fields which the Postcord
+// types do not support, such as localizations, if the REST client is a *rest.Client or implements CommandSyncClient.
+// Other REST clients can only send the fields supported by the Postcord types, so the other fields are ignored.
+func (c *CommandRouter) Sync(ctx context.Context, restClient rest.RESTClient, appID objects.Snowflake, opts *SyncOptions) (*SyncReport, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+	syncClient := newCommandSyncClient(restClient)
+	formulate := func(cmds []*DiscordCommand) []*DiscordCommand { return cmds }
+	if x, ok := syncClient.(objectCommandSyncClient); ok {
+		formulate = x.strip
+	}
+
+	// Get the global commands registered with Discord and compute the difference.
+	existing, err := syncClient.GetDiscordCommands(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commands: %w", err)
+	}
+	changes, unchanged := diffCommands(existing, formulate(c.FormulateExtendedDiscordCommands()), opts.KeepUnknown)
+	report := &SyncReport{
+		DryRun:    opts.DryRun,
+		Changes:   changes,
+		Unchanged: unchanged,
+		Guilds:    map[objects.Snowflake]*SyncReport{},
+	}
+
+	// Get the guilds we need to sync in a stable order.
+	guildCmds := c.FormulateExtendedGuildDiscordCommands()
+	for _, v := range opts.Guilds {
+		if _, ok := guildCmds[v]; !ok {
+			guildCmds[v] = nil
+		}
+	}
+	guildIDs := make([]objects.Snowflake, 0, len(guildCmds))
+	for k := range guildCmds {
+		guildIDs = append(guildIDs, k)
+	}
+	sort.Slice(guildIDs, func(i, j int) bool { return guildIDs[i] < guildIDs[j] })
+
+	// Compute the difference for each guild.
+	for _, guildID := range guildIDs {
+		existing, err = syncClient.GetGuildDiscordCommands(ctx, appID, guildID)
+		if err != nil {
+			return report, fmt.Errorf("failed to get commands for guild %s: %w", guildID, err)
+		}
+		changes, unchanged = diffCommands(existing, formulate(guildCmds[guildID]), opts.KeepUnknown)
+		report.Guilds[guildID] = &SyncReport{DryRun: opts.DryRun, Changes: changes, Unchanged: unchanged}
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	// Apply the changes.
+	if err = applyCommandChanges(ctx, syncClient, appID, 0, report.Changes); err != nil {
+		return report, err
+	}
+	for _, guildID := range guildIDs {
+		if err = applyCommandChanges(ctx, syncClient, appID, guildID, report.Guilds[guildID].Changes); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,313 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Postcord/objects"
+	"github.com/Postcord/rest"
+)
+
+// CommandSyncClient is used to define the REST calls a sync makes. Unlike rest.RESTClient, the commands include the
+// fields which the Postcord types do not support, such as localizations and string length limits. A rest.RESTClient
+// passed to Sync which also implements this interface is used directly.
+type CommandSyncClient interface {
+	GetDiscordCommands(ctx context.Context, app objects.SnowflakeObject) ([]*DiscordCommand, error)
+	CreateDiscordCommand(ctx context.Context, app objects.SnowflakeObject, command *DiscordCommand) (*DiscordCommand, error)
+	UpdateDiscordCommand(ctx context.Context, app, commandID objects.SnowflakeObject, command *DiscordCommand) (*DiscordCommand, error)
+	DeleteCommand(ctx context.Context, app, commandID objects.SnowflakeObject) error
+	GetGuildDiscordCommands(ctx context.Context, app, guild objects.SnowflakeObject) ([]*DiscordCommand, error)
+	AddGuildDiscordCommand(ctx context.Context, app, guild objects.SnowflakeObject, command *DiscordCommand) (*DiscordCommand, error)
+	UpdateGuildDiscordCommand(ctx context.Context, app, guild, commandID objects.SnowflakeObject, command *DiscordCommand) (*DiscordCommand, error)
+	DeleteGuildCommand(ctx context.Context, app, guild, commandID objects.SnowflakeObject) error
+}
+
+// Gets the command sync client for the REST client. The Postcord client is wrapped so the commands are sent as they are,
+// and any other client is wrapped with one which converts the commands to the Postcord types.
+func newCommandSyncClient(restClient rest.RESTClient) CommandSyncClient {
+	switch x := restClient.(type) {
+	case CommandSyncClient:
+		return x
+	case *rest.Client:
+		return restCommandSyncClient{x}
+	default:
+		return objectCommandSyncClient{x}
+	}
+}
+
+// Used to make the command requests directly with the Postcord client so that the extended fields are sent.
+type restCommandSyncClient struct {
+	*rest.Client
+}
+
+// Gets the commands from the path including the localizations, which Discord leaves out by default.
+func (r restCommandSyncClient) getCommands(ctx context.Context, path string) ([]*DiscordCommand, error) {
+	var cmds []*DiscordCommand
+	err := rest.NewRequest().
+		Method(http.MethodGet).
+		WithContext(ctx).
+		Path(path + "?with_localizations=true").
+		ContentType(rest.JsonContentType).
+		Expect(http.StatusOK).
+		Bind(&cmds).
+		Send(r.Client)
+	return cmds, err
+}
+
+// Sends the command to the path and returns the command Discord responds with. The expected status codes match the
+// ones used by the Postcord client.
+func (r restCommandSyncClient) sendCommand(ctx context.Context, method, path string, status int, command *DiscordCommand) (*DiscordCommand, error) {
+	data, err := json.Marshal(command)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &DiscordCommand{}
+	err = rest.NewRequest().
+		Method(method).
+		WithContext(ctx).
+		Path(path).
+		ContentType(rest.JsonContentType).
+		Expect(status).
+		Bind(cmd).
+		Body(data).
+		Send(r.Client)
+	return cmd, err
+}
+
+// GetDiscordCommands implements the CommandSyncClient interface.
+func (r restCommandSyncClient) GetDiscordCommands(ctx context.Context, app objects.SnowflakeObject) ([]*DiscordCommand, error) {
+	return r.getCommands(ctx, fmt.Sprintf(rest.GlobalApplicationsFmt, app.GetID()))
+}
+
+// CreateDiscordCommand implements the CommandSyncClient interface.
+func (r restCommandSyncClient) CreateDiscordCommand(ctx context.Context, app objects.SnowflakeObject, command *DiscordCommand) (*DiscordCommand, error) {
+	return r.sendCommand(ctx, http.MethodPost, fmt.Sprintf(rest.GlobalApplicationsFmt, app.GetID()), http.StatusCreated, command)
+}
+
+// UpdateDiscordCommand implements the CommandSyncClient interface.
+func (r restCommandSyncClient) UpdateDiscordCommand(ctx context.Context, app, commandID objects.SnowflakeObject, command *DiscordCommand) (*DiscordCommand, error) {
+	return r.sendCommand(ctx, http.MethodPatch, fmt.Sprintf(rest.GlobalApplicationsUpdateFmt, app.GetID(), commandID.GetID()), http.StatusOK, command)
+}
+
+// GetGuildDiscordCommands implements the CommandSyncClient interface.
+func (r restCommandSyncClient) GetGuildDiscordCommands(ctx context.Context, app, guild objects.SnowflakeObject) ([]*DiscordCommand, error) {
+	return r.getCommands(ctx, fmt.Sprintf(rest.GuildApplicationsFmt, app.GetID(), guild.GetID()))
+}
+
+// AddGuildDiscordCommand implements the CommandSyncClient interface.
+func (r restCommandSyncClient) AddGuildDiscordCommand(ctx context.Context, app, guild objects.SnowflakeObject, command *DiscordCommand) (*DiscordCommand, error) {
+	return r.sendCommand(ctx, http.MethodPost, fmt.Sprintf(rest.GuildApplicationsFmt, app.GetID(), guild.GetID()), http.StatusOK, command)
+}
+
+// UpdateGuildDiscordCommand implements the CommandSyncClient interface.
+func (r restCommandSyncClient) UpdateGuildDiscordCommand(ctx context.Context, app, guild, commandID objects.SnowflakeObject, command *DiscordCommand) (*DiscordCommand, error) {
+	return r.sendCommand(ctx, http.MethodPatch, fmt.Sprintf(rest.GuildApplicationsUpdateFmt, app.GetID(), guild.GetID(), commandID.GetID()), http.StatusOK, command)
+}
+
+// Used to sync with a REST client which only supports the Postcord types. The extended fields are dropped when sending
+// commands, and are not set on the commands which are fetched.
+type objectCommandSyncClient struct {
+	rest.RESTClient
+}
+
+// Converts the option from the type used by Postcord.
+func discordCommandOption(o objects.ApplicationCommandOption) DiscordCommandOption {
+	x := DiscordCommandOption{ApplicationCommandOption: o, Options: discordCommandOptions(o.Options)}
+	if o.Choices != nil {
+		x.Choices = make([]DiscordCommandOptionChoice, len(o.Choices))
+		for i, v := range o.Choices {
+			x.Choices[i] = DiscordCommandOptionChoice{ApplicationCommandOptionChoice: v}
+		}
+	}
+	x.ApplicationCommandOption.Choices = nil
+	x.ApplicationCommandOption.Options = nil
+	return x
+}
+
+// Converts a slice of options from the type used by Postcord.
+func discordCommandOptions(options []objects.ApplicationCommandOption) []DiscordCommandOption {
+	if options == nil {
+		return nil
+	}
+	x := make([]DiscordCommandOption, len(options))
+	for i, v := range options {
+		x[i] = discordCommandOption(v)
+	}
+	return x
+}
+
+// Converts the command from the type used by Postcord.
+func discordCommand(cmd *objects.ApplicationCommand) *DiscordCommand {
+	if cmd == nil {
+		return nil
+	}
+	x := &DiscordCommand{ApplicationCommand: *cmd, Options: discordCommandOptions(cmd.Options)}
+	x.ApplicationCommand.Options = nil
+	return x
+}
+
+// Converts a slice of commands from the type used by Postcord.
+func discordCommands(cmds []*objects.ApplicationCommand) []*DiscordCommand {
+	if cmds == nil {
+		return nil
+	}
+	x := make([]*DiscordCommand, len(cmds))
+	for i, v := range cmds {
+		x[i] = discordCommand(v)
+	}
+	return x
+}
+
+// GetDiscordCommands implements the CommandSyncClient interface.
+func (r objectCommandSyncClient) GetDiscordCommands(ctx context.Context, app objects.SnowflakeObject) ([]*DiscordCommand, error) {
+	cmds, err := r.GetCommands(ctx, app)
+	return discordCommands(cmds), err
+}
+
+// CreateDiscordCommand implements the CommandSyncClient interface.
+func (r objectCommandSyncClient) CreateDiscordCommand(ctx context.Context, app objects.SnowflakeObject, command *DiscordCommand) (*DiscordCommand, error) {
+	cmd, err := r.CreateCommand(ctx, app, command.Object())
+	return discordCommand(cmd), err
+}
+
+// UpdateDiscordCommand implements the CommandSyncClient interface.
+func (r objectCommandSyncClient) UpdateDiscordCommand(ctx context.Context, app, commandID objects.SnowflakeObject, command *DiscordCommand) (*DiscordCommand, error) {
+	cmd, err := r.UpdateCommand(ctx, app, commandID, command.Object())
+	return discordCommand(cmd), err
+}
+
+// GetGuildDiscordCommands implements the CommandSyncClient interface.
+func (r objectCommandSyncClient) GetGuildDiscordCommands(ctx context.Context, app, guild objects.SnowflakeObject) ([]*DiscordCommand, error) {
+	cmds, err := r.GetGuildCommands(ctx, app, guild)
+	return discordCommands(cmds), err
+}
+
+// AddGuildDiscordCommand implements the CommandSyncClient interface.
+func (r objectCommandSyncClient) AddGuildDiscordCommand(ctx context.Context, app, guild objects.SnowflakeObject, command *DiscordCommand) (*DiscordCommand, error) {
+	cmd, err := r.AddGuildCommand(ctx, app, guild, command.Object())
+	return discordCommand(cmd), err
+}
+
+// UpdateGuildDiscordCommand implements the CommandSyncClient interface.
+func (r objectCommandSyncClient) UpdateGuildDiscordCommand(ctx context.Context, app, guild, commandID objects.SnowflakeObject, command *DiscordCommand) (*DiscordCommand, error) {
+	cmd, err := r.UpdateGuildCommand(ctx, app, guild, commandID, command.Object())
+	return discordCommand(cmd), err
+}
+
+// Strips the fields which the client cannot send from the commands so they are compared with what would be registered.
+func (objectCommandSyncClient) strip(cmds []*DiscordCommand) []*DiscordCommand {
+	x := make([]*DiscordCommand, len(cmds))
+	for i, v := range cmds {
+		x[i] = discordCommand(v.Object())
+	}
+	return x
+}
+
+// GetDiscordCommands implements the CommandSyncClient interface.
+func (r restTape) GetDiscordCommands(a context.Context, b objects.SnowflakeObject) ([]*DiscordCommand, error) {
+	result := r.tape.write("GetDiscordCommands", false, a, b)
+	c, d := newCommandSyncClient(r.rest).GetDiscordCommands(a, b)
+	result.end(c, d)
+	return c, d
+}
+
+// CreateDiscordCommand implements the CommandSyncClient interface.
+func (r restTape) CreateDiscordCommand(a context.Context, b objects.SnowflakeObject, c *DiscordCommand) (*DiscordCommand, error) {
+	result := r.tape.write("CreateDiscordCommand", false, a, b, c)
+	d, e := newCommandSyncClient(r.rest).CreateDiscordCommand(a, b, c)
+	result.end(d, e)
+	return d, e
+}
+
+// UpdateDiscordCommand implements the CommandSyncClient interface.
+func (r restTape) UpdateDiscordCommand(a context.Context, b, c objects.SnowflakeObject, d *DiscordCommand) (*DiscordCommand, error) {
+	result := r.tape.write("UpdateDiscordCommand", false, a, b, c, d)
+	e, f := newCommandSyncClient(r.rest).UpdateDiscordCommand(a, b, c, d)
+	result.end(e, f)
+	return e, f
+}
+
+// GetGuildDiscordCommands implements the CommandSyncClient interface.
+func (r restTape) GetGuildDiscordCommands(a context.Context, b, c objects.SnowflakeObject) ([]*DiscordCommand, error) {
+	result := r.tape.write("GetGuildDiscordCommands", false, a, b, c)
+	d, e := newCommandSyncClient(r.rest).GetGuildDiscordCommands(a, b, c)
+	result.end(d, e)
+	return d, e
+}
+
+// AddGuildDiscordCommand implements the CommandSyncClient interface.
+func (r restTape) AddGuildDiscordCommand(a context.Context, b, c objects.SnowflakeObject, d *DiscordCommand) (*DiscordCommand, error) {
+	result := r.tape.write("AddGuildDiscordCommand", false, a, b, c, d)
+	e, f := newCommandSyncClient(r.rest).AddGuildDiscordCommand(a, b, c, d)
+	result.end(e, f)
+	return e, f
+}
+
+// UpdateGuildDiscordCommand implements the CommandSyncClient interface.
+func (r restTape) UpdateGuildDiscordCommand(a context.Context, b, c, d objects.SnowflakeObject, e *DiscordCommand) (*DiscordCommand, error) {
+	result := r.tape.write("UpdateGuildDiscordCommand", false, a, b, c, d, e)
+	f, g := newCommandSyncClient(r.rest).UpdateGuildDiscordCommand(a, b, c, d, e)
+	result.end(f, g)
+	return f, g
+}
+
+// Gets the next action from the tape for the function, failing if the tape has ended.
+func (r *restTapePlayer) next(funcName string) *tapeItem {
+	if r.index == len(r.tape) {
+		r.t.Fatal("unexpected " + funcName + " at end of tape")
+		return nil // Here for unit tests - in production this will never be hit.
+	}
+	action := r.tape[r.index]
+	r.index++
+	return action
+}
+
+// GetDiscordCommands implements the CommandSyncClient interface.
+func (r *restTapePlayer) GetDiscordCommands(a context.Context, b objects.SnowflakeObject) (c []*DiscordCommand, d error) {
+	if action := r.next("GetDiscordCommands"); action != nil {
+		action.match(r.t, "GetDiscordCommands", false, 2, a, b, &c, &d)
+	}
+	return
+}
+
+// CreateDiscordCommand implements the CommandSyncClient interface.
+func (r *restTapePlayer) CreateDiscordCommand(a context.Context, b objects.SnowflakeObject, c *DiscordCommand) (d *DiscordCommand, e error) {
+	if action := r.next("CreateDiscordCommand"); action != nil {
+		action.match(r.t, "CreateDiscordCommand", false, 3, a, b, c, &d, &e)
+	}
+	return
+}
+
+// UpdateDiscordCommand implements the CommandSyncClient interface.
+func (r *restTapePlayer) UpdateDiscordCommand(a context.Context, b, c objects.SnowflakeObject, d *DiscordCommand) (e *DiscordCommand, f error) {
+	if action := r.next("UpdateDiscordCommand"); action != nil {
+		action.match(r.t, "UpdateDiscordCommand", false, 4, a, b, c, d, &e, &f)
+	}
+	return
+}
+
+// GetGuildDiscordCommands implements the CommandSyncClient interface.
+func (r *restTapePlayer) GetGuildDiscordCommands(a context.Context, b, c objects.SnowflakeObject) (d []*DiscordCommand, e error) {
+	if action := r.next("GetGuildDiscordCommands"); action != nil {
+		action.match(r.t, "GetGuildDiscordCommands", false, 3, a, b, c, &d, &e)
+	}
+	return
+}
+
+// AddGuildDiscordCommand implements the CommandSyncClient interface.
+func (r *restTapePlayer) AddGuildDiscordCommand(a context.Context, b, c objects.SnowflakeObject, d *DiscordCommand) (e *DiscordCommand, f error) {
+	if action := r.next("AddGuildDiscordCommand"); action != nil {
+		action.match(r.t, "AddGuildDiscordCommand", false, 4, a, b, c, d, &e, &f)
+	}
+	return
+}
+
+// UpdateGuildDiscordCommand implements the CommandSyncClient interface.
+func (r *restTapePlayer) UpdateGuildDiscordCommand(a context.Context, b, c, d objects.SnowflakeObject, e *DiscordCommand) (f *DiscordCommand, g error) {
+	if action := r.next("UpdateGuildDiscordCommand"); action != nil {
+		action.match(r.t, "UpdateGuildDiscordCommand", false, 5, a, b, c, d, e, &f, &g)
+	}
+	return
+}
@@ -0,0 +1,427 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Postcord/objects"
+	"github.com/Postcord/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSyncRESTClient struct {
+	rest.RESTClient
+
+	commands []*objects.ApplicationCommand
+	getErr   error
+	calls    []string
+}
+
+func (f *fakeSyncRESTClient) GetCommands(_ context.Context, app objects.SnowflakeObject) ([]*objects.ApplicationCommand, error) {
+	f.calls = append(f.calls, "get "+app.GetID().String())
+	return f.commands, f.getErr
+}
+
+func (f *fakeSyncRESTClient) CreateCommand(_ context.Context, _ objects.SnowflakeObject, cmd *objects.ApplicationCommand) (*objects.ApplicationCommand, error) {
+	f.calls = append(f.calls, "create "+cmd.Name)
+	return cmd, nil
+}
+
+func (f *fakeSyncRESTClient) UpdateCommand(_ context.Context, _, id objects.SnowflakeObject, cmd *objects.ApplicationCommand) (*objects.ApplicationCommand, error) {
+	f.calls = append(f.calls, "update "+cmd.Name+" "+id.GetID().String())
+	return cmd, nil
+}
+
+func (f *fakeSyncRESTClient) DeleteCommand(_ context.Context, _, id objects.SnowflakeObject) error {
+	f.calls = append(f.calls, "delete "+id.GetID().String())
+	return nil
+}
+
+// Round trips the commands through JSON to simulate them coming back from Discord.
+func discordifyCommands(t *testing.T, cmds []*DiscordCommand, startID objects.Snowflake) []*DiscordCommand {
+	t.Helper()
+	var res []*DiscordCommand
+	require.NoError(t, json.Unmarshal(jsonify(t, cmds), &res))
+	for i, v := range res {
+		v.ID = startID + objects.Snowflake(i)
+		v.ApplicationID = 1
+		v.Version = 1
+	}
+	return res
+}
+
+func syncTestRouter() *CommandRouter {
+	r := &CommandRouter{}
+	r.NewCommandBuilder("ping").Description("pong").
+		StringOption("a", "a", true, nil).
+		IntOption("b", "b", false, IntStaticChoicesBuilder([]IntChoice{{Name: "one", Value: 1}})).
+		MustBuild()
+	g := r.MustNewCommandGroup("group", "group", nil)
+	g.NewCommandBuilder("one").Description("one").MustBuild()
+	g.NewCommandBuilder("two").Description("two").MustBuild()
+	g.NewCommandBuilder("three").Description("three").MustBuild()
+	r.NewCommandBuilder("message").MessageCommand().MustBuild()
+	return r
+}
+
+func TestSyncAction_String(t *testing.T) {
+	assert.Equal(t, "create", SyncActionCreate.String())
+	assert.Equal(t, "update", SyncActionUpdate.String())
+	assert.Equal(t, "delete", SyncActionDelete.String())
+	assert.Equal(t, "unknown", SyncAction(0).String())
+}
+
+func Test_diffCommands(t *testing.T) {
+	tests := []struct {
+		name string
+
+		existing    func(t *testing.T) []*DiscordCommand
+		keepUnknown bool
+
+		expectedChanges   []SyncAction
+		expectedNames     []string
+		expectedUnchanged []string
+	}{
+		{
+			name: "nothing registered",
+			existing: func(*testing.T) []*DiscordCommand {
+				return nil
+			},
+			expectedChanges:   []SyncAction{SyncActionCreate, SyncActionCreate, SyncActionCreate},
+			expectedNames:     []string{"group", "message", "ping"},
+			expectedUnchanged: []string{},
+		},
+		{
+			name: "in sync with different map ordering",
+			existing: func(t *testing.T) []*DiscordCommand {
+				cmds := discordifyCommands(t, syncTestRouter().FormulateExtendedDiscordCommands(), 10)
+				for _, v := range cmds {
+					// Reverse the sub-commands to make sure ordering is ignored.
+					for i, j := 0, len(v.Options)-1; i < j; i, j = i+1, j-1 {
+						if v.Options[i].OptionType == objects.TypeSubCommand {
+							v.Options[i], v.Options[j] = v.Options[j], v.Options[i]
+						}
+					}
+				}
+				return cmds
+			},
+			expectedChanges:   []SyncAction{},
+			expectedNames:     []string{},
+			expectedUnchanged: []string{"group", "message", "ping"},
+		},
+		{
+			name: "changed and unknown",
+			existing: func(t *testing.T) []*DiscordCommand {
+				cmds := discordifyCommands(t, syncTestRouter().FormulateExtendedDiscordCommands(), 10)
+				for _, v := range cmds {
+					if v.Name == "ping" {
+						v.Description = "old"
+					}
+				}
+				return append(cmds, &DiscordCommand{ApplicationCommand: objects.ApplicationCommand{
+					DiscordBaseObject: objects.DiscordBaseObject{ID: 100},
+					Name:              "old",
+				}})
+			},
+			expectedChanges:   []SyncAction{SyncActionDelete, SyncActionUpdate},
+			expectedNames:     []string{"old", "ping"},
+			expectedUnchanged: []string{"group", "message"},
+		},
+		{
+			name: "localization only change",
+			existing: func(t *testing.T) []*DiscordCommand {
+				cmds := discordifyCommands(t, syncTestRouter().FormulateExtendedDiscordCommands(), 10)
+				for _, v := range cmds {
+					if v.Name == "ping" {
+						v.DescriptionLocalizations = Localizations{"fr": "pong"}
+					}
+					if v.Name == "group" {
+						v.Options[0].NameLocalizations = Localizations{"fr": "un"}
+					}
+				}
+				return cmds
+			},
+			expectedChanges:   []SyncAction{SyncActionUpdate, SyncActionUpdate},
+			expectedNames:     []string{"group", "ping"},
+			expectedUnchanged: []string{"message"},
+		},
+		{
+			name: "choice localization only change",
+			existing: func(t *testing.T) []*DiscordCommand {
+				cmds := discordifyCommands(t, syncTestRouter().FormulateExtendedDiscordCommands(), 10)
+				for _, v := range cmds {
+					if v.Name == "ping" {
+						v.Options[1].Choices[0].NameLocalizations = Localizations{"fr": "un"}
+					}
+				}
+				return cmds
+			},
+			expectedChanges:   []SyncAction{SyncActionUpdate},
+			expectedNames:     []string{"ping"},
+			expectedUnchanged: []string{"group", "message"},
+		},
+		{
+			name: "length only change",
+			existing: func(t *testing.T) []*DiscordCommand {
+				cmds := discordifyCommands(t, syncTestRouter().FormulateExtendedDiscordCommands(), 10)
+				for _, v := range cmds {
+					if v.Name == "ping" {
+						maxLength := 10
+						v.Options[0].MaxLength = &maxLength
+					}
+				}
+				return cmds
+			},
+			expectedChanges:   []SyncAction{SyncActionUpdate},
+			expectedNames:     []string{"ping"},
+			expectedUnchanged: []string{"group", "message"},
+		},
+		{
+			name: "keep unknown",
+			existing: func(t *testing.T) []*DiscordCommand {
+				cmds := discordifyCommands(t, syncTestRouter().FormulateExtendedDiscordCommands(), 10)
+				return append(cmds, &DiscordCommand{ApplicationCommand: objects.ApplicationCommand{
+					DiscordBaseObject: objects.DiscordBaseObject{ID: 100},
+					Name:              "old",
+				}})
+			},
+			keepUnknown:       true,
+			expectedChanges:   []SyncAction{},
+			expectedNames:     []string{},
+			expectedUnchanged: []string{"group", "message", "ping"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, unchanged := diffCommands(tt.existing(t), syncTestRouter().FormulateExtendedDiscordCommands(), tt.keepUnknown)
+			actions := []SyncAction{}
+			names := []string{}
+			for _, v := range changes {
+				actions = append(actions, v.Action)
+				names = append(names, v.Name)
+			}
+			assert.Equal(t, tt.expectedChanges, actions)
+			assert.Equal(t, tt.expectedNames, names)
+			assert.Equal(t, tt.expectedUnchanged, unchanged)
+		})
+	}
+}
+
+func TestCommandRouter_Sync(t *testing.T) {
+	tests := []struct {
+		name string
+
+		dryRun bool
+		getErr error
+
+		expectedCalls []string
+		expectedErr   string
+	}{
+		{
+			name:          "dry run",
+			dryRun:        true,
+			expectedCalls: []string{"get 1"},
+		},
+		{
+			name:          "apply",
+			expectedCalls: []string{"get 1", "delete 100", "create ping"},
+		},
+		{
+			name:          "get error",
+			getErr:        errors.New("cat tripped on wire"),
+			expectedCalls: []string{"get 1"},
+			expectedErr:   "failed to get commands: cat tripped on wire",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &CommandRouter{}
+			r.NewCommandBuilder("ping").Description("pong").MustBuild()
+			restClient := &fakeSyncRESTClient{
+				commands: []*objects.ApplicationCommand{
+					{DiscordBaseObject: objects.DiscordBaseObject{ID: 100}, Name: "old"},
+				},
+				getErr: tt.getErr,
+			}
+			report, err := r.Sync(context.Background(), restClient, 1, &SyncOptions{DryRun: tt.dryRun})
+			assert.Equal(t, tt.expectedCalls, restClient.calls)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.dryRun, report.DryRun)
+			assert.True(t, report.HasChanges())
+			assert.Len(t, report.Changes, 2)
+		})
+	}
+}
+
+type fakeGuildSyncRESTClient struct {
+	fakeSyncRESTClient
+
+	guildCommands map[objects.Snowflake][]*objects.ApplicationCommand
+}
+
+func (f *fakeGuildSyncRESTClient) GetGuildCommands(_ context.Context, _, guild objects.SnowflakeObject) ([]*objects.ApplicationCommand, error) {
+	f.calls = append(f.calls, "get guild "+guild.GetID().String())
+	return f.guildCommands[guild.GetID()], nil
+}
+
+func (f *fakeGuildSyncRESTClient) AddGuildCommand(_ context.Context, _, guild objects.SnowflakeObject, cmd *objects.ApplicationCommand) (*objects.ApplicationCommand, error) {
+	f.calls = append(f.calls, "create guild "+guild.GetID().String()+" "+cmd.Name)
+	return cmd, nil
+}
+
+func (f *fakeGuildSyncRESTClient) DeleteGuildCommand(_ context.Context, _, guild, id objects.SnowflakeObject) error {
+	f.calls = append(f.calls, "delete guild "+guild.GetID().String()+" "+id.GetID().String())
+	return nil
+}
+
+func TestCommandRouter_Sync_guilds(t *testing.T) {
+	r := &CommandRouter{}
+	r.NewCommandBuilder("staff").Description("staff only").Guilds(20, 10).MustBuild()
+	restClient := &fakeGuildSyncRESTClient{
+		guildCommands: map[objects.Snowflake][]*objects.ApplicationCommand{
+			30: {{DiscordBaseObject: objects.DiscordBaseObject{ID: 100}, Name: "staff"}},
+		},
+	}
+	report, err := r.Sync(context.Background(), restClient, 1, &SyncOptions{Guilds: []objects.Snowflake{30}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"get 1", "get guild 10", "get guild 20", "get guild 30",
+		"create guild 10 staff", "create guild 20 staff", "delete guild 30 100",
+	}, restClient.calls)
+	assert.True(t, report.HasChanges())
+	assert.Empty(t, report.Changes)
+	require.Len(t, report.Guilds, 3)
+	assert.Equal(t, SyncActionDelete, report.Guilds[30].Changes[0].Action)
+}
+
+type fakeCommandSyncClient struct {
+	fakeSyncRESTClient
+
+	discordCommands []*DiscordCommand
+	sent            []*DiscordCommand
+}
+
+func (f *fakeCommandSyncClient) GetDiscordCommands(_ context.Context, app objects.SnowflakeObject) ([]*DiscordCommand, error) {
+	f.calls = append(f.calls, "get discord "+app.GetID().String())
+	return f.discordCommands, nil
+}
+
+func (f *fakeCommandSyncClient) CreateDiscordCommand(_ context.Context, _ objects.SnowflakeObject, cmd *DiscordCommand) (*DiscordCommand, error) {
+	f.calls = append(f.calls, "create discord "+cmd.Name)
+	f.sent = append(f.sent, cmd)
+	return cmd, nil
+}
+
+func (f *fakeCommandSyncClient) UpdateDiscordCommand(_ context.Context, _, id objects.SnowflakeObject, cmd *DiscordCommand) (*DiscordCommand, error) {
+	f.calls = append(f.calls, "update discord "+cmd.Name+" "+id.GetID().String())
+	f.sent = append(f.sent, cmd)
+	return cmd, nil
+}
+
+func (f *fakeCommandSyncClient) GetGuildDiscordCommands(context.Context, objects.SnowflakeObject, objects.SnowflakeObject) ([]*DiscordCommand, error) {
+	panic("not implemented")
+}
+
+func (f *fakeCommandSyncClient) AddGuildDiscordCommand(context.Context, objects.SnowflakeObject, objects.SnowflakeObject, *DiscordCommand) (*DiscordCommand, error) {
+	panic("not implemented")
+}
+
+func (f *fakeCommandSyncClient) UpdateGuildDiscordCommand(context.Context, objects.SnowflakeObject, objects.SnowflakeObject, objects.SnowflakeObject, *DiscordCommand) (*DiscordCommand, error) {
+	panic("not implemented")
+}
+
+var _ CommandSyncClient = (*fakeCommandSyncClient)(nil)
+
+func localizedSyncTestRouter() *CommandRouter {
+	r := &CommandRouter{}
+	r.NewCommandBuilder("ping").Description("pong").
+		DescriptionLocalizations(Localizations{"fr": "pong"}).
+		StringOption("a", "a", true, nil, OptionMaxLength(10)).
+		OptionLocalizations("a", Localizations{"fr": "b"}, nil).
+		MustBuild()
+	return r
+}
+
+func TestCommandRouter_Sync_localizations(t *testing.T) {
+	existing := []*DiscordCommand{{
+		ApplicationCommand: objects.ApplicationCommand{
+			DiscordBaseObject: objects.DiscordBaseObject{ID: 100},
+			Name:              "ping",
+			Description:       "pong",
+		},
+		Options: []DiscordCommandOption{{
+			ApplicationCommandOption: objects.ApplicationCommandOption{
+				OptionType:  objects.TypeString,
+				Name:        "a",
+				Description: "a",
+				Required:    true,
+			},
+		}},
+	}}
+
+	t.Run("sync client", func(t *testing.T) {
+		restClient := &fakeCommandSyncClient{discordCommands: existing}
+		report, err := localizedSyncTestRouter().Sync(context.Background(), restClient, 1, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"get discord 1", "update discord ping 100"}, restClient.calls)
+		require.Len(t, report.Changes, 1)
+		assert.Equal(t, SyncActionUpdate, report.Changes[0].Action)
+		require.Len(t, restClient.sent, 1)
+		assert.Equal(t, Localizations{"fr": "pong"}, restClient.sent[0].DescriptionLocalizations)
+		assert.Equal(t, Localizations{"fr": "b"}, restClient.sent[0].Options[0].NameLocalizations)
+		require.NotNil(t, restClient.sent[0].Options[0].MaxLength)
+		assert.Equal(t, 10, *restClient.sent[0].Options[0].MaxLength)
+	})
+
+	t.Run("postcord types only", func(t *testing.T) {
+		// The localizations cannot be sent, so they should not cause a change every sync.
+		objs := make([]*objects.ApplicationCommand, len(existing))
+		for i, v := range existing {
+			objs[i] = v.Object()
+		}
+		restClient := &fakeSyncRESTClient{commands: objs}
+		report, err := localizedSyncTestRouter().Sync(context.Background(), restClient, 1, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"get 1"}, restClient.calls)
+		assert.False(t, report.HasChanges())
+	})
+}
+
+func TestCommandRouter_Sync_restClient(t *testing.T) {
+	var requests []string
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`[{"id":"100","name":"ping","description":"pong","options":[{"type":3,"name":"a","description":"a","required":true,"name_localizations":{"fr":"b"}}]}]`))
+			return
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{"id":"100","name":"ping","description":"pong"}`))
+	}))
+	defer srv.Close()
+	proxy, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	restClient := rest.New(&rest.Config{Proxy: http.ProxyURL(proxy)})
+
+	report, err := localizedSyncTestRouter().Sync(context.Background(), restClient, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 1)
+	assert.Equal(t, SyncActionUpdate, report.Changes[0].Action)
+	assert.Equal(t, []string{
+		"GET /api/v9/applications/1/commands?with_localizations=true",
+		"PATCH /api/v9/applications/1/commands/100",
+	}, requests)
+	assert.Equal(t, map[string]any{"fr": "pong"}, body["description_localizations"])
+}
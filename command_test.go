@@ -62,6 +62,18 @@ func Test_findOption(t *testing.T) {
 	}
 }
 
+func TestCommand_discordOptions(t *testing.T) {
+	r := &CommandRouter{}
+	cmd := r.NewCommandBuilder("test").
+		StringOption("described", "the described option", true, nil).
+		StringOption("undescribed", "", false, nil).
+		MustBuild()
+	options := cmd.discordOptions()
+	assert.Equal(t, "the described option", options[0].Description)
+	assert.Equal(t, "No description provided.", options[1].Description)
+	assert.Equal(t, "", cmd.Options[1].Description)
+}
+
 func TestCommand_mapOptions(t *testing.T) {
 	tests := []struct {
 		name string
@@ -155,6 +167,29 @@ func TestCommand_mapOptions(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name: "attachment option",
+			data: &objects.ApplicationCommandInteractionData{DiscordBaseObject: objects.DiscordBaseObject{ID: 6921}},
+			cmdOptions: []*objects.ApplicationCommandOption{
+				{
+					OptionType: objects.TypeAttachment,
+					Name:       "opt1",
+				},
+			},
+			retOptions: []*objects.ApplicationCommandInteractionDataOption{
+				{
+					Type:  objects.TypeAttachment,
+					Name:  "opt1",
+					Value: "123",
+				},
+			},
+			expects: map[string]any{
+				"opt1": (ResolvableAttachment)(resolvable[objects.Attachment]{
+					id:   "123",
+					data: &objects.ApplicationCommandInteractionData{DiscordBaseObject: objects.DiscordBaseObject{ID: 6921}},
+				}),
+			},
+		},
 		{
 			name: "role option",
 			data: &objects.ApplicationCommandInteractionData{DiscordBaseObject: objects.DiscordBaseObject{ID: 6921}},
@@ -372,6 +407,11 @@ func TestCommand_execute(t *testing.T) {
 		noMiddleware bool
 		noFunc       bool
 
+		cmdMiddleware    []MiddlewareFunc
+		skipInherited    bool
+		aroundMiddleware []AroundMiddlewareFunc
+		expectsResp      *objects.InteractionResponse
+
 		throwsErr  string
 		panic      bool
 		expectsErr string
@@ -443,6 +483,76 @@ func TestCommand_execute(t *testing.T) {
 			throwsErr:    "cat tripped on wire",
 			expectsErr:   "cat tripped on wire",
 		},
+		{
+			name: "command middleware",
+			cmdMiddleware: []MiddlewareFunc{func(ctx MiddlewareCtx) error {
+				// This should be called after the inherited middleware.
+				if ctx.Options["middleware2"] != 2 {
+					return errors.New("inherited middleware not called first")
+				}
+				ctx.Options["command"] = true
+				return ctx.Next()
+			}},
+			paramsCheck: func(t *testing.T, m map[string]any) {
+				t.Helper()
+				assert.Equal(t, true, m["command"])
+			},
+		},
+		{
+			name:          "skip inherited middleware",
+			skipInherited: true,
+			cmdMiddleware: []MiddlewareFunc{func(ctx MiddlewareCtx) error {
+				ctx.Options["command"] = true
+				return ctx.Next()
+			}},
+			paramsCheck: func(t *testing.T, m map[string]any) {
+				t.Helper()
+				assert.Equal(t, map[string]any{"command": true}, m)
+			},
+		},
+		{
+			name: "around middleware",
+			aroundMiddleware: []AroundMiddlewareFunc{func(ctx AroundMiddlewareCtx) (*objects.InteractionResponse, error) {
+				resp, err := ctx.Next()
+				if err != nil {
+					return nil, err
+				}
+				resp.Data.Flags = 64
+				return resp, nil
+			}},
+			expectsResp: &objects.InteractionResponse{
+				Type: 4,
+				Data: &objects.InteractionApplicationCommandCallbackData{
+					Content: "hello world",
+					Flags:   64,
+				},
+			},
+		},
+		{
+			name:      "around middleware handles error",
+			throwsErr: "cat tripped on wire",
+			aroundMiddleware: []AroundMiddlewareFunc{func(ctx AroundMiddlewareCtx) (*objects.InteractionResponse, error) {
+				_, err := ctx.Next()
+				return &objects.InteractionResponse{
+					Type: 4,
+					Data: &objects.InteractionApplicationCommandCallbackData{Content: err.Error(), Flags: 64},
+				}, nil
+			}},
+			expectsResp: &objects.InteractionResponse{
+				Type: 4,
+				Data: &objects.InteractionApplicationCommandCallbackData{
+					Content: "cat tripped on wire",
+					Flags:   64,
+				},
+			},
+		},
+		{
+			name:          "skip inherited around middleware",
+			skipInherited: true,
+			aroundMiddleware: []AroundMiddlewareFunc{func(ctx AroundMiddlewareCtx) (*objects.InteractionResponse, error) {
+				return nil, errors.New("should not be called")
+			}},
+		},
 		{
 			name:       "no handler",
 			noFunc:     true,
@@ -464,7 +574,12 @@ func TestCommand_execute(t *testing.T) {
 			}
 
 			// Defines the command.
-			c := &Command{Options: tt.cmdOptions, AllowedMentions: tt.cmdAllowed}
+			c := &Command{
+				Options:                 tt.cmdOptions,
+				AllowedMentions:         tt.cmdAllowed,
+				Middleware:              tt.cmdMiddleware,
+				SkipInheritedMiddleware: tt.skipInherited,
+			}
 
 			// Defines the dummy interaction.
 			dummyInteraction := &objects.Interaction{}
@@ -481,7 +596,7 @@ func TestCommand_execute(t *testing.T) {
 				assert.Same(t, tt.cmdAllowed, ctx.globalAllowedMentions)
 
 				// Check the 2 expected middlewares were ran.
-				if !tt.noMiddleware {
+				if !tt.noMiddleware && !tt.skipInherited {
 					assert.Equal(t, "middleware1", ctx.Options["middleware1"])
 					assert.Equal(t, 2, ctx.Options["middleware2"])
 				}
@@ -521,8 +636,12 @@ func TestCommand_execute(t *testing.T) {
 				interaction:      dummyInteraction,
 				data:             tt.data,
 				options:          tt.retOptions,
+				aroundMiddleware: tt.aroundMiddleware,
 			}, l)
-			if tt.expectsErr == "" {
+			if tt.expectsResp != nil {
+				assert.NoError(t, errResult)
+				assert.Equal(t, tt.expectsResp, resp)
+			} else if tt.expectsErr == "" {
 				assert.NoError(t, errResult)
 				if tt.noFunc {
 					assert.Nil(t, resp)
@@ -0,0 +1,206 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Postcord/objects"
+)
+
+// InvalidName is thrown when a command, group, option, or localized name does not meet the requirements of Discord.
+var InvalidName = errors.New("name must be 1-32 lower case letters, numbers, dashes, or underscores")
+
+// InvalidContextMenuName is thrown when the name of a message or user command is not 1-32 characters.
+var InvalidContextMenuName = errors.New("context menu command name must be 1-32 characters")
+
+// DescriptionTooLong is thrown when a description is longer than 100 characters.
+var DescriptionTooLong = errors.New("description must be 100 characters or less")
+
+// TooManyOptions is thrown when a command has more than 25 options.
+var TooManyOptions = errors.New("command cannot have more than 25 options")
+
+// TooManyChoices is thrown when an option has more than 25 static choices.
+var TooManyChoices = errors.New("option cannot have more than 25 choices")
+
+// InvalidChoice is thrown when the name or string value of a choice is not 1-100 characters.
+var InvalidChoice = errors.New("choice name and string value must be 1-100 characters")
+
+// RequiredOptionAfterOptional is thrown when a required option is defined after an optional option.
+var RequiredOptionAfterOptional = errors.New("required options must be defined before optional options")
+
+// InvalidOptionConstraint is thrown when an option has a constraint which is not supported by its type or is out of range.
+var InvalidOptionConstraint = errors.New("option constraint is not supported by the option type or is out of range")
+
+// DuplicateName is thrown when a name is already in use by a command, group, option, or choice at the same level.
+var DuplicateName = errors.New("name is already in use")
+
+// TooManySubcommands is thrown when a group would have more than 25 sub-commands and sub-command groups.
+var TooManySubcommands = errors.New("group cannot have more than 25 sub-commands and sub-command groups")
+
+// InvalidAutocompleteOption is thrown when an auto-complete handler is set on an option which does not exist, is not a
+// string, int, or double option, or has static choices.
+var InvalidAutocompleteOption = errors.New("auto-complete can only be set on string, int, and double options without static choices")
+
+// NestedGuildScope is thrown when guilds are set on a sub-command or sub-command group. Discord registers the whole
+// command in a guild, so only top level commands and groups can be scoped.
+var NestedGuildScope = errors.New("only top level commands and groups can be scoped to guilds")
+
+// ValidationError is thrown when a command or group fails validation. The underlying error is one of the errors above,
+// so errors.Is can be used to check what went wrong.
+type ValidationError struct {
+	// Name is the name of the command or group which failed validation.
+	Name string
+
+	// Option is the name of the option which failed validation. This is blank if the error is not with an option.
+	Option string
+
+	// Err is the validation error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	if e.Option == "" {
+		return fmt.Sprintf("invalid command %q: %v", e.Name, e.Err)
+	}
+	return fmt.Sprintf("invalid command %q option %q: %v", e.Name, e.Option, e.Err)
+}
+
+// Unwrap is used to get the validation error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Defines the regex Discord uses for chat input command, group, and option names.
+var chatInputNameRegex = regexp.MustCompile(`^[-_\p{L}\p{N}\p{Devanagari}\p{Thai}]{1,32}$`)
+
+// Validates a chat input name. Names must also be lower case where the script has cases.
+func validateChatInputName(name string) error {
+	if !chatInputNameRegex.MatchString(name) || strings.ToLower(name) != name {
+		return InvalidName
+	}
+	return nil
+}
+
+// Validates a description. Empty descriptions are allowed since they are filled in when formulating the command or
+// option.
+func validateDescription(description string) error {
+	if utf8.RuneCountInString(description) > 100 {
+		return DescriptionTooLong
+	}
+	return nil
+}
+
+// Validates a name, description, and the localizations for them.
+func validateNameAndDescription(chatInput bool, name, description string, names, descriptions Localizations) error {
+	validateName := validateChatInputName
+	if !chatInput {
+		validateName = func(name string) error {
+			if l := utf8.RuneCountInString(name); l == 0 || l > 32 {
+				return InvalidContextMenuName
+			}
+			return nil
+		}
+	}
+	if err := validateName(name); err != nil {
+		return err
+	}
+	for _, v := range names {
+		if err := validateName(v); err != nil {
+			return err
+		}
+	}
+	if err := validateDescription(description); err != nil {
+		return err
+	}
+	for _, v := range descriptions {
+		if err := validateDescription(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validates the choices of an option.
+func validateChoices(choices []objects.ApplicationCommandOptionChoice) error {
+	if len(choices) > 25 {
+		return TooManyChoices
+	}
+	names := make(map[string]struct{}, len(choices))
+	for _, v := range choices {
+		if l := utf8.RuneCountInString(v.Name); l == 0 || l > 100 {
+			return InvalidChoice
+		}
+		if s, ok := v.Value.(string); ok {
+			if l := utf8.RuneCountInString(s); l == 0 || l > 100 {
+				return InvalidChoice
+			}
+		}
+		if _, ok := names[v.Name]; ok {
+			return DuplicateName
+		}
+		names[v.Name] = struct{}{}
+	}
+	return nil
+}
+
+// Validates the command against the requirements of Discord. Note this does not check if the name is in use.
+func (c *Command) validate() error {
+	chatInput := c.commandType == 0 || c.commandType == int(objects.CommandTypeChatInput)
+	if err := validateNameAndDescription(chatInput, c.Name, c.Description, c.NameLocalizations, c.DescriptionLocalizations); err != nil {
+		return &ValidationError{Name: c.Name, Err: err}
+	}
+	if c.parent != nil && len(c.Guilds) != 0 {
+		return &ValidationError{Name: c.Name, Err: NestedGuildScope}
+	}
+	if len(c.Options) > 25 {
+		return &ValidationError{Name: c.Name, Err: TooManyOptions}
+	}
+	names := make(map[string]struct{}, len(c.Options))
+	optional := false
+	for _, v := range c.Options {
+		var nameLocalizations, descriptionLocalizations Localizations
+		if l := c.OptionLocalizations[v.Name]; l != nil {
+			nameLocalizations, descriptionLocalizations = l.Name, l.Description
+		}
+		err := validateNameAndDescription(true, v.Name, v.Description, nameLocalizations, descriptionLocalizations)
+		if err == nil {
+			if _, ok := names[v.Name]; ok {
+				err = DuplicateName
+			} else if v.Required && optional {
+				err = RequiredOptionAfterOptional
+			} else if err = validateChoices(v.Choices); err == nil {
+				if constraints := c.OptionConstraints[v.Name]; constraints != nil {
+					err = constraints.validate(v.OptionType)
+				}
+			}
+		}
+		if err != nil {
+			return &ValidationError{Name: c.Name, Option: v.Name, Err: err}
+		}
+		names[v.Name] = struct{}{}
+		optional = optional || !v.Required
+	}
+	for name := range c.autocomplete {
+		v := findOption(name, c.Options)
+		if v == nil || !v.Autocomplete || len(v.Choices) != 0 || (v.OptionType != objects.TypeString &&
+			v.OptionType != objects.TypeInteger && v.OptionType != objects.TypeNumber) {
+			return &ValidationError{Name: c.Name, Option: name, Err: InvalidAutocompleteOption}
+		}
+	}
+	return nil
+}
+
+// Validates that the name can be added to the group specified.
+func validateAddToGroup(name string, group map[string]any, root bool) error {
+	if _, ok := group[name]; ok {
+		return &ValidationError{Name: name, Err: DuplicateName}
+	}
+	if !root && len(group) >= 25 {
+		return &ValidationError{Name: name, Err: TooManySubcommands}
+	}
+	return nil
+}
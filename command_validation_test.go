@@ -0,0 +1,332 @@
+package router
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationError_Error(t *testing.T) {
+	assert.Equal(t, `invalid command "abc": name is already in use`,
+		(&ValidationError{Name: "abc", Err: DuplicateName}).Error())
+	assert.Equal(t, `invalid command "abc" option "def": name is already in use`,
+		(&ValidationError{Name: "abc", Option: "def", Err: DuplicateName}).Error())
+}
+
+func Test_commandBuilder_Build_validation(t *testing.T) {
+	tests := []struct {
+		name string
+
+		build func(r *CommandRouter) error
+
+		expectsErr    error
+		expectsOption string
+	}{
+		{
+			name: "valid",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("héllo-world_1").Description("Says hello.").
+					StringOption("a", "a", true, nil).
+					IntOption("b", "b", false, IntStaticChoicesBuilder([]IntChoice{{Name: "one", Value: 1}})).
+					Build()
+				return err
+			},
+		},
+		{
+			name: "upper case name",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("Hello").Build()
+				return err
+			},
+			expectsErr: InvalidName,
+		},
+		{
+			name: "name with spaces",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("hello world").Build()
+				return err
+			},
+			expectsErr: InvalidName,
+		},
+		{
+			name: "name too long",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder(strings.Repeat("a", 33)).Build()
+				return err
+			},
+			expectsErr: InvalidName,
+		},
+		{
+			name: "invalid localized name",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("hello").NameLocalizations(Localizations{"fr": "Bonjour"}).Build()
+				return err
+			},
+			expectsErr: InvalidName,
+		},
+		{
+			name: "context menu name with spaces",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("Hello World").MessageCommand().Build()
+				return err
+			},
+		},
+		{
+			name: "context menu name too long",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder(strings.Repeat("a", 33)).UserCommand().Build()
+				return err
+			},
+			expectsErr: InvalidContextMenuName,
+		},
+		{
+			name: "description too long",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("hello").Description(strings.Repeat("a", 120)).Build()
+				return err
+			},
+			expectsErr: DescriptionTooLong,
+		},
+		{
+			name: "option description too long",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("hello").BoolOption("a", strings.Repeat("a", 101), false).Build()
+				return err
+			},
+			expectsErr:    DescriptionTooLong,
+			expectsOption: "a",
+		},
+		{
+			name: "too many options",
+			build: func(r *CommandRouter) error {
+				b := r.NewCommandBuilder("hello")
+				for i := 0; i < 26; i++ {
+					b = b.BoolOption(string(rune('a'+i)), "a", false)
+				}
+				_, err := b.Build()
+				return err
+			},
+			expectsErr: TooManyOptions,
+		},
+		{
+			name: "duplicate option",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("hello").BoolOption("a", "a", false).BoolOption("a", "a", false).Build()
+				return err
+			},
+			expectsErr:    DuplicateName,
+			expectsOption: "a",
+		},
+		{
+			name: "required after optional",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("hello").BoolOption("a", "a", false).BoolOption("b", "b", true).Build()
+				return err
+			},
+			expectsErr:    RequiredOptionAfterOptional,
+			expectsOption: "b",
+		},
+		{
+			name: "too many choices",
+			build: func(r *CommandRouter) error {
+				choices := make([]IntChoice, 26)
+				for i := range choices {
+					choices[i] = IntChoice{Name: string(rune('a' + i)), Value: i}
+				}
+				_, err := r.NewCommandBuilder("hello").IntOption("a", "a", true, IntStaticChoicesBuilder(choices)).Build()
+				return err
+			},
+			expectsErr:    TooManyChoices,
+			expectsOption: "a",
+		},
+		{
+			name: "empty string choice value",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("hello").
+					StringOption("a", "a", true, StringStaticChoicesBuilder([]StringChoice{{Name: "a"}})).
+					Build()
+				return err
+			},
+			expectsErr:    InvalidChoice,
+			expectsOption: "a",
+		},
+		{
+			name: "duplicate choice",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("hello").
+					IntOption("a", "a", true, IntStaticChoicesBuilder([]IntChoice{{Name: "a", Value: 1}, {Name: "a", Value: 2}})).
+					Build()
+				return err
+			},
+			expectsErr:    DuplicateName,
+			expectsOption: "a",
+		},
+		{
+			name: "autocomplete on missing option",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("hello").
+					Autocomplete("a", func(*AutocompleteCtx) error { return nil }).
+					StringOption("a", "a", true, nil).
+					Build()
+				return err
+			},
+			expectsErr:    InvalidAutocompleteOption,
+			expectsOption: "a",
+		},
+		{
+			name: "autocomplete on bool option",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("hello").
+					BoolOption("a", "a", true).
+					Autocomplete("a", func(*AutocompleteCtx) error { return nil }).
+					Build()
+				return err
+			},
+			expectsErr:    InvalidAutocompleteOption,
+			expectsOption: "a",
+		},
+		{
+			name: "autocomplete on option with choices",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("hello").
+					IntOption("a", "a", true, IntStaticChoicesBuilder([]IntChoice{{Name: "a", Value: 1}})).
+					Autocomplete("a", func(*AutocompleteCtx) error { return nil }).
+					Build()
+				return err
+			},
+			expectsErr:    InvalidAutocompleteOption,
+			expectsOption: "a",
+		},
+		{
+			name: "duplicate command",
+			build: func(r *CommandRouter) error {
+				r.NewCommandBuilder("hello").MustBuild()
+				_, err := r.NewCommandBuilder("hello").Build()
+				return err
+			},
+			expectsErr: DuplicateName,
+		},
+		{
+			name: "command with the same name as a group",
+			build: func(r *CommandRouter) error {
+				r.MustNewCommandGroup("hello", "", nil)
+				_, err := r.NewCommandBuilder("hello").Build()
+				return err
+			},
+			expectsErr: DuplicateName,
+		},
+		{
+			name: "too many sub-commands",
+			build: func(r *CommandRouter) error {
+				g := r.MustNewCommandGroup("hello", "", nil)
+				for i := 0; i < 25; i++ {
+					g.NewCommandBuilder(string(rune('a' + i))).MustBuild()
+				}
+				_, err := g.NewCommandBuilder("z").Build()
+				return err
+			},
+			expectsErr: TooManySubcommands,
+		},
+		{
+			name: "scoped sub-command",
+			build: func(r *CommandRouter) error {
+				b := r.MustNewCommandGroup("hello", "", nil).NewCommandBuilder("world").(subcommandBuilder)
+				b.cmd.Guilds = []objects.Snowflake{1}
+				_, err := b.Build()
+				return err
+			},
+			expectsErr: NestedGuildScope,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.build(&CommandRouter{})
+			if tt.expectsErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expectsErr)
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.expectsOption, validationErr.Option)
+		})
+	}
+}
+
+func TestCommandRouter_NewCommandGroup_validation(t *testing.T) {
+	tests := []struct {
+		name string
+
+		build func(r *CommandRouter) error
+
+		expectsErr error
+	}{
+		{
+			name: "invalid name",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandGroup("Hello", "", nil)
+				return err
+			},
+			expectsErr: InvalidName,
+		},
+		{
+			name: "description too long",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandGroup("hello", strings.Repeat("a", 101), nil)
+				return err
+			},
+			expectsErr: DescriptionTooLong,
+		},
+		{
+			name: "invalid localized description",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandGroup("hello", "", &CommandGroupOptions{
+					DescriptionLocalizations: Localizations{"fr": strings.Repeat("a", 101)},
+				})
+				return err
+			},
+			expectsErr: DescriptionTooLong,
+		},
+		{
+			name: "duplicate group",
+			build: func(r *CommandRouter) error {
+				r.MustNewCommandGroup("hello", "", nil)
+				_, err := r.NewCommandGroup("hello", "", nil)
+				return err
+			},
+			expectsErr: DuplicateName,
+		},
+		{
+			name: "duplicate sub-group",
+			build: func(r *CommandRouter) error {
+				g := r.MustNewCommandGroup("hello", "", nil)
+				g.NewCommandBuilder("world").MustBuild()
+				_, err := g.NewCommandGroup("world", "", nil)
+				return err
+			},
+			expectsErr: DuplicateName,
+		},
+		{
+			name: "nested scoped group",
+			build: func(r *CommandRouter) error {
+				g := r.MustNewCommandGroup("hello", "", &CommandGroupOptions{Guilds: []objects.Snowflake{1}})
+				_, err := g.NewCommandGroup("world", "", &CommandGroupOptions{Guilds: []objects.Snowflake{1}})
+				return err
+			},
+			expectsErr: NestedGuildScope,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &CommandRouter{}
+			err := tt.build(r)
+			assert.ErrorIs(t, err, tt.expectsErr)
+			var validationErr *ValidationError
+			assert.True(t, errors.As(err, &validationErr))
+		})
+	}
+}
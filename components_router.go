@@ -15,6 +15,26 @@ import (
 // ComponentRouter is used to route components.
 type ComponentRouter struct {
 	routes map[string]any
+
+	// Defines the middleware for the router, prefixes, and routes.
+	middleware       []InteractionMiddlewareFunc
+	prefixMiddleware []prefixMiddleware
+	routeMiddleware  map[string][]InteractionMiddlewareFunc
+	aroundMiddleware []AroundMiddlewareFunc
+
+	// Defines the error handlers for the router and routes.
+	errorHandler       ContextErrorHandler
+	routeErrorHandlers map[string]ContextErrorHandler
+
+	// Defines the handler used when a custom ID does not match a route.
+	notFoundHandler NotFoundHandler
+
+	// Defines the signer used to verify custom IDs.
+	signer *CustomIDSigner
+
+	// Defines the state store and the handler used when state has expired.
+	stateStore          StateStore
+	stateExpiredHandler NotFoundHandler
 }
 
 // ComponentRouterCtx is used to define a components router context.
@@ -35,6 +55,15 @@ type ComponentRouterCtx struct {
 	// Defines the void ID generator.
 	voidGenerator
 
+	// Defines the route which is being handled. This is used when recovering from a panic.
+	route string
+
+	// Defines the logger used for errors when updating the response later.
+	logger Logger
+
+	// Defines the state from the state store. This is nil if the component was not made with StoreState.
+	state json.RawMessage
+
 	// Context is a context.Context passed from the HTTP handler.
 	Context context.Context
 
@@ -72,34 +101,177 @@ func (c *ComponentRouter) prep() {
 	}
 }
 
-// RegisterSelectMenu is used to register a select menu route.
-func (c *ComponentRouter) RegisterSelectMenu(route string, cb SelectMenuFunc) {
+// Sets the middleware for the route.
+func (c *ComponentRouter) setRouteMiddleware(route string, middleware []InteractionMiddlewareFunc) {
+	if len(middleware) == 0 {
+		delete(c.routeMiddleware, route)
+		return
+	}
+	if c.routeMiddleware == nil {
+		c.routeMiddleware = map[string][]InteractionMiddlewareFunc{}
+	}
+	c.routeMiddleware[route] = middleware
+}
+
+// Use is used to add middleware which is used for all routes in the router.
+func (c *ComponentRouter) Use(f InteractionMiddlewareFunc) {
+	c.middleware = append(c.middleware, f)
+}
+
+// UseAround is used to add middleware which wraps the response for all routes in the router. This is called before any
+// other middleware.
+func (c *ComponentRouter) UseAround(f AroundMiddlewareFunc) {
+	c.aroundMiddleware = append(c.aroundMiddleware, f)
+}
+
+// UsePrefix is used to add middleware which is used for routes starting with the prefix specified. This is checked
+// against the route which was registered rather than the custom ID.
+func (c *ComponentRouter) UsePrefix(prefix string, f InteractionMiddlewareFunc) {
+	c.prefixMiddleware = append(c.prefixMiddleware, prefixMiddleware{prefix: prefix, f: f})
+}
+
+// SetErrorHandler is used to set the error handler for the router. This overrides the error handler of the loader,
+// and is overridden by the error handler of a route.
+func (c *ComponentRouter) SetErrorHandler(f ContextErrorHandler) {
+	c.errorHandler = f
+}
+
+// SetNotFoundHandler is used to set the handler used when a custom ID does not match a route or a modal. This
+// overrides the not found handler of the loader.
+func (c *ComponentRouter) SetNotFoundHandler(f NotFoundHandler) {
+	c.notFoundHandler = f
+}
+
+// SetSigner is used to turn on signed custom IDs. When this is set, every custom ID must be signed by the signer (see
+// SignCustomID), and if one is not, the error from verifying it is passed to the error handler as a router error.
+func (c *ComponentRouter) SetSigner(s *CustomIDSigner) {
+	c.signer = s
+}
+
+// SignCustomID is used to sign the path with the signer of the router. The options can be nil. If signing is not turned
+// on, the path is returned as is.
+func (c *ComponentRouter) SignCustomID(path string, opts *SignOptions) string {
+	if c.signer == nil {
+		return path
+	}
+	return c.signer.Sign(path, opts)
+}
+
+// SetRouteErrorHandler is used to set the error handler for the route specified. This overrides the error handler of
+// the router.
+func (c *ComponentRouter) SetRouteErrorHandler(route string, f ContextErrorHandler) {
+	if c.routeErrorHandlers == nil {
+		c.routeErrorHandlers = map[string]ContextErrorHandler{}
+	}
+	c.routeErrorHandlers[route] = f
+}
+
+// RegisterSelectMenu is used to register a select menu route. Any middleware specified is only used for this route,
+// and is called after the router and prefix middleware.
+func (c *ComponentRouter) RegisterSelectMenu(route string, cb SelectMenuFunc, middleware ...InteractionMiddlewareFunc) {
 	c.prep()
 	c.routes[route] = cb
+	c.setRouteMiddleware(route, middleware)
 }
 
 // ButtonFunc is the function dispatched when a button is used.
 type ButtonFunc func(ctx *ComponentRouterCtx) error
 
-// RegisterButton is used to register a button route.
-func (c *ComponentRouter) RegisterButton(route string, cb ButtonFunc) {
+// RegisterButton is used to register a button route. Any middleware specified is only used for this route, and is
+// called after the router and prefix middleware.
+func (c *ComponentRouter) RegisterButton(route string, cb ButtonFunc, middleware ...InteractionMiddlewareFunc) {
+	c.prep()
+	c.routes[route] = cb
+	c.setRouteMiddleware(route, middleware)
+}
+
+const (
+	// ComponentTypeUserSelect is the component type of a select menu for users. Postcord/objects does not define this yet.
+	ComponentTypeUserSelect objects.ComponentType = 5
+
+	// ComponentTypeRoleSelect is the component type of a select menu for roles.
+	ComponentTypeRoleSelect objects.ComponentType = 6
+
+	// ComponentTypeMentionableSelect is the component type of a select menu for users and roles.
+	ComponentTypeMentionableSelect objects.ComponentType = 7
+
+	// ComponentTypeChannelSelect is the component type of a select menu for channels.
+	ComponentTypeChannelSelect objects.ComponentType = 8
+)
+
+// UserSelectMenuFunc is the function dispatched when a user select menu is used.
+type UserSelectMenuFunc func(ctx *ComponentRouterCtx, users []ResolvableUser) error
+
+// RegisterUserSelectMenu is used to register a user select menu route. The users are resolved from the data Discord
+// sends with the interaction. Middleware works the same as RegisterSelectMenu.
+func (c *ComponentRouter) RegisterUserSelectMenu(route string, cb UserSelectMenuFunc, middleware ...InteractionMiddlewareFunc) {
+	c.prep()
+	c.routes[route] = cb
+	c.setRouteMiddleware(route, middleware)
+}
+
+// RoleSelectMenuFunc is the function dispatched when a role select menu is used.
+type RoleSelectMenuFunc func(ctx *ComponentRouterCtx, roles []ResolvableRole) error
+
+// RegisterRoleSelectMenu is used to register a role select menu route. The roles are resolved from the data Discord
+// sends with the interaction. Middleware works the same as RegisterSelectMenu.
+func (c *ComponentRouter) RegisterRoleSelectMenu(route string, cb RoleSelectMenuFunc, middleware ...InteractionMiddlewareFunc) {
+	c.prep()
+	c.routes[route] = cb
+	c.setRouteMiddleware(route, middleware)
+}
+
+// MentionableSelectMenuFunc is the function dispatched when a mentionable select menu is used.
+type MentionableSelectMenuFunc func(ctx *ComponentRouterCtx, mentionables []ResolvableMentionable) error
+
+// RegisterMentionableSelectMenu is used to register a mentionable select menu route. The users and roles are resolved
+// from the data Discord sends with the interaction. Middleware works the same as RegisterSelectMenu.
+func (c *ComponentRouter) RegisterMentionableSelectMenu(route string, cb MentionableSelectMenuFunc, middleware ...InteractionMiddlewareFunc) {
+	c.prep()
+	c.routes[route] = cb
+	c.setRouteMiddleware(route, middleware)
+}
+
+// ChannelSelectMenuFunc is the function dispatched when a channel select menu is used.
+type ChannelSelectMenuFunc func(ctx *ComponentRouterCtx, channels []ResolvableChannel) error
+
+// RegisterChannelSelectMenu is used to register a channel select menu route. The channels are resolved from the data
+// Discord sends with the interaction. Middleware works the same as RegisterSelectMenu.
+func (c *ComponentRouter) RegisterChannelSelectMenu(route string, cb ChannelSelectMenuFunc, middleware ...InteractionMiddlewareFunc) {
 	c.prep()
 	c.routes[route] = cb
+	c.setRouteMiddleware(route, middleware)
+}
+
+// Gets the resolved data for a user, role, mentionable, or channel select menu. Postcord/objects does not include this
+// in the component data, so it is parsed from the raw data. The resolvables use command data, so it is parsed into that.
+func selectMenuResolvedData(interaction *objects.Interaction) (*objects.ApplicationCommandInteractionData, error) {
+	var data objects.ApplicationCommandInteractionData
+	if err := json.Unmarshal(interaction.Data, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
 }
 
 // NotSelectionMenu is returned when Discord returns data that is not a selection menu.
 var NotSelectionMenu = errors.New("the data returned is not that of a selection menu")
 
+// WrongSelectMenuType is returned when Discord returns data for a different type of select menu than the one registered.
+var WrongSelectMenuType = errors.New("the data returned is not that of the type of select menu registered")
+
 // NotButton is returned when Discord returns data that is not a button.
 var NotButton = errors.New("the data returned is not that of a button")
 
 // Adds the argument context to the handler.
-type contextCallback = func(context.Context, *objects.Interaction, *objects.ApplicationComponentInteractionData, map[string]string, rest.RESTClient, ErrorHandler) *objects.InteractionResponse
+type contextCallback = func(context.Context, *objects.Interaction, *objects.ApplicationComponentInteractionData, map[string]string, json.RawMessage, rest.RESTClient, *errorScope, ErrorHandler) *objects.InteractionResponse
 
 // Defines the data for the context for the route.
 type routeContext struct {
 	i any
 	r string
+
+	// Defines the parameters which have type annotations.
+	params []routeParam
 }
 
 // Used to ungeneric an error.
@@ -116,13 +288,54 @@ func ungenericError(errGeneric any) error {
 	return err
 }
 
+// Creates the callback for a route. If the component type matches, f is called with the context after the middleware.
+func (c *ComponentRouter) routeCallback(
+	loader loaderPassthrough, route string, componentType objects.ComponentType, typeErr error,
+	f func(ctx *ComponentRouterCtx, data *objects.ApplicationComponentInteractionData) error,
+) contextCallback {
+	middleware := routeMiddleware(route, c.middleware, c.prefixMiddleware, c.routeMiddleware[route])
+	return func(reqCtx context.Context, ctx *objects.Interaction, data *objects.ApplicationComponentInteractionData, params map[string]string, state json.RawMessage, rest rest.RESTClient, scope *errorScope, errHandler ErrorHandler) (resp *objects.InteractionResponse) {
+		if data.ComponentType != componentType {
+			return wrapRouterErrors(scope.handle, &scope.info)(typeErr)
+		}
+		defer func() {
+			if errGeneric := recover(); errGeneric != nil {
+				resp = errHandler(newPanicError(errGeneric, route))
+			}
+		}()
+		rctx := &ComponentRouterCtx{
+			errorHandler:          scope.handle,
+			globalAllowedMentions: loader.globalAllowedMentions,
+			modalRouter:           loader.modalRouter,
+			Interaction:           ctx,
+			Context:               reqCtx,
+			Params:                params,
+			RESTClient:            rest,
+			route:                 route,
+			logger:                loader.log(),
+			state:                 state,
+		}
+		scope.builder = &rctx.responseBuilder
+		resp, err := runAroundMiddleware(rctx, c.aroundMiddleware, func() (*objects.InteractionResponse, error) {
+			if err := runInteractionMiddleware(rctx, middleware, func() error { return f(rctx, data) }); err != nil {
+				return nil, err
+			}
+			return rctx.buildResponse(true, wrapRouterErrors(errHandler, &scope.info), loader.globalAllowedMentions), nil
+		})
+		if err != nil {
+			return errHandler(err)
+		}
+		return resp
+	}
+}
+
 // Used to build the component router by the parent.
 func (c *ComponentRouter) build(modalRouter *ModalRouter, loader loaderPassthrough) interactions.HandlerFunc {
 	// Build the router tree.
 	c.prep()
 	root := new(node)
 	root.addRoute("/_postcord/void/:number", &routeContext{
-		i: func(reqCtx context.Context, ctx *objects.Interaction, _ *objects.ApplicationComponentInteractionData, _ map[string]string, _ rest.RESTClient, _ ErrorHandler) *objects.InteractionResponse {
+		i: func(reqCtx context.Context, ctx *objects.Interaction, _ *objects.ApplicationComponentInteractionData, _ map[string]string, _ json.RawMessage, _ rest.RESTClient, _ *errorScope, _ ErrorHandler) *objects.InteractionResponse {
 			// The point of this route is to just return the default handler.
 			rctx := &ComponentRouterCtx{
 				globalAllowedMentions: loader.globalAllowedMentions,
@@ -136,73 +349,89 @@ func (c *ComponentRouter) build(modalRouter *ModalRouter, loader loaderPassthrou
 		var cb contextCallback
 		switch x := v.(type) {
 		case ButtonFunc:
-			cb = func(reqCtx context.Context, ctx *objects.Interaction, data *objects.ApplicationComponentInteractionData, params map[string]string, rest rest.RESTClient, errHandler ErrorHandler) *objects.InteractionResponse {
-				if data.ComponentType != objects.ComponentTypeButton {
-					return loader.errHandler(NotButton)
-				}
-				defer func() {
-					if errGeneric := recover(); errGeneric != nil {
-						// Shouldn't try and return from defer.
-						errHandler(ungenericError(errGeneric))
-					}
-				}()
-				rctx := &ComponentRouterCtx{
-					errorHandler:          loader.errHandler,
-					globalAllowedMentions: loader.globalAllowedMentions,
-					modalRouter:           loader.modalRouter,
-					Interaction:           ctx,
-					Context:               reqCtx,
-					Params:                params,
-					RESTClient:            rest,
-				}
-				if err := x(rctx); err != nil {
-					return errHandler(err)
-				}
-				return rctx.buildResponse(true, loader.errHandler, loader.globalAllowedMentions)
-			}
+			cb = c.routeCallback(loader, k, objects.ComponentTypeButton, NotButton,
+				func(ctx *ComponentRouterCtx, _ *objects.ApplicationComponentInteractionData) error {
+					return x(ctx)
+				})
 		case SelectMenuFunc:
-			cb = func(reqCtx context.Context, ctx *objects.Interaction, data *objects.ApplicationComponentInteractionData, params map[string]string, rest rest.RESTClient, errHandler ErrorHandler) *objects.InteractionResponse {
-				values := data.Values
-				if values == nil {
-					// This is a blank result from Discord.
-					values = []string{}
-				}
-				if data.ComponentType != objects.ComponentTypeSelectMenu {
-					return loader.errHandler(NotSelectionMenu)
-				}
-				defer func() {
-					if errGeneric := recover(); errGeneric != nil {
-						// Shouldn't try and return from defer.
-						errHandler(ungenericError(errGeneric))
+			cb = c.routeCallback(loader, k, objects.ComponentTypeSelectMenu, NotSelectionMenu,
+				func(ctx *ComponentRouterCtx, data *objects.ApplicationComponentInteractionData) error {
+					values := data.Values
+					if values == nil {
+						// This is a blank result from Discord.
+						values = []string{}
 					}
-				}()
-				rctx := &ComponentRouterCtx{
-					globalAllowedMentions: loader.globalAllowedMentions,
-					errorHandler:          loader.errHandler,
-					modalRouter:           loader.modalRouter,
-					Interaction:           ctx,
-					Context:               reqCtx,
-					Params:                params,
-					RESTClient:            rest,
-				}
-				if err := x(rctx, values); err != nil {
-					return errHandler(err)
-				}
-				return rctx.buildResponse(true, loader.errHandler, loader.globalAllowedMentions)
-			}
+					return x(ctx, values)
+				})
+		case UserSelectMenuFunc:
+			cb = c.routeCallback(loader, k, ComponentTypeUserSelect, WrongSelectMenuType,
+				func(ctx *ComponentRouterCtx, data *objects.ApplicationComponentInteractionData) error {
+					resolved, err := selectMenuResolvedData(ctx.Interaction)
+					if err != nil {
+						return err
+					}
+					users := make([]ResolvableUser, len(data.Values))
+					for i, id := range data.Values {
+						users[i] = resolvableUser{resolvable[objects.User]{id: id, data: resolved}}
+					}
+					return x(ctx, users)
+				})
+		case RoleSelectMenuFunc:
+			cb = c.routeCallback(loader, k, ComponentTypeRoleSelect, WrongSelectMenuType,
+				func(ctx *ComponentRouterCtx, data *objects.ApplicationComponentInteractionData) error {
+					resolved, err := selectMenuResolvedData(ctx.Interaction)
+					if err != nil {
+						return err
+					}
+					roles := make([]ResolvableRole, len(data.Values))
+					for i, id := range data.Values {
+						roles[i] = resolvable[objects.Role]{id: id, data: resolved}
+					}
+					return x(ctx, roles)
+				})
+		case MentionableSelectMenuFunc:
+			cb = c.routeCallback(loader, k, ComponentTypeMentionableSelect, WrongSelectMenuType,
+				func(ctx *ComponentRouterCtx, data *objects.ApplicationComponentInteractionData) error {
+					resolved, err := selectMenuResolvedData(ctx.Interaction)
+					if err != nil {
+						return err
+					}
+					mentionables := make([]ResolvableMentionable, len(data.Values))
+					for i, id := range data.Values {
+						mentionables[i] = resolvableMentionable{resolvable[any]{id: id, data: resolved}}
+					}
+					return x(ctx, mentionables)
+				})
+		case ChannelSelectMenuFunc:
+			cb = c.routeCallback(loader, k, ComponentTypeChannelSelect, WrongSelectMenuType,
+				func(ctx *ComponentRouterCtx, data *objects.ApplicationComponentInteractionData) error {
+					resolved, err := selectMenuResolvedData(ctx.Interaction)
+					if err != nil {
+						return err
+					}
+					channels := make([]ResolvableChannel, len(data.Values))
+					for i, id := range data.Values {
+						channels[i] = resolvable[objects.Channel]{id: id, data: resolved}
+					}
+					return x(ctx, channels)
+				})
 		default:
 			panic("postcord internal error - invalid interaction type")
 		}
-		root.addRoute(k, &routeContext{cb, k})
+		root.addTypedRoute(k, &routeContext{i: cb, r: k})
 	}
 
 	// Return the router.
 	return func(reqCtx context.Context, ctx *objects.Interaction) *objects.InteractionResponse {
+		// Create the error scope.
+		scope := newErrorScope(loader, ctx, InteractionKindComponent)
+		scope.handler = c.errorHandler
+
 		// Create the rest tape if this is wanted.
 		r := loader.rest
 		tape := tape{}
 		var returnedErr string
-		errHandler := loader.errHandler
+		errHandler := scope.handle
 		if loader.generateFrames {
 			r = &restTape{
 				tape: &tape,
@@ -210,7 +439,7 @@ func (c *ComponentRouter) build(modalRouter *ModalRouter, loader loaderPassthrou
 			}
 			errHandler = func(err error) *objects.InteractionResponse {
 				returnedErr = err.Error()
-				return loader.errHandler(err)
+				return scope.handle(err)
 			}
 		}
 
@@ -218,34 +447,81 @@ func (c *ComponentRouter) build(modalRouter *ModalRouter, loader loaderPassthrou
 		params := map[string]string{}
 		var data objects.ApplicationComponentInteractionData
 		if err := json.Unmarshal(ctx.Data, &data); err != nil {
-			return loader.errHandler(err)
+			return wrapRouterErrors(scope.handle, &scope.info)(err)
+		}
+		customID, skipped, err := verifyCustomID(c.signer, c.stateStore != nil, data.CustomID, ctx)
+		if err != nil {
+			loader.log().Warn("invalid custom ID", append(logAttrs(InteractionKindComponent, "", ctx), "custom_id", data.CustomID, "error", err)...)
+			return wrapRouterErrors(scope.handle, &scope.info)(err)
+		}
+		var state json.RawMessage
+		if c.stateStore != nil && strings.HasPrefix(customID, stateCustomIDPrefix) {
+			stored, ok, err := c.loadState(reqCtx, customID)
+			if err != nil {
+				return wrapRouterErrors(scope.handle, &scope.info)(err)
+			}
+			if !ok {
+				loader.log().Warn("component state expired", append(logAttrs(InteractionKindComponent, "", ctx), "custom_id", customID)...)
+				if c.stateExpiredHandler != nil {
+					return c.stateExpiredHandler(&NotFoundInfo{
+						Interaction: ctx,
+						Kind:        InteractionKindComponent,
+						ID:          customID,
+					})
+				}
+				return wrapRouterErrors(scope.handle, &scope.info)(StateExpired)
+			}
+			customID, state = stored.Path, stored.State
 		}
-		route := root.getValue(data.CustomID, params)
+		route, paramErr := root.match(customID, params)
 		if route == nil {
-			if modalRouter != nil {
-				// Check the modal router. This will essentially just act as a proxy to the modal dispatcher.
+			if modalRouter != nil && !skipped {
+				// Check the modal router. This will essentially just act as a proxy to the modal dispatcher. Custom IDs
+				// which were not verified are not passed on since the modal router would trust them.
 				b := &ComponentRouterCtx{
 					globalAllowedMentions: loader.globalAllowedMentions,
-					errorHandler:          loader.errHandler,
+					errorHandler:          scope.handle,
 					Interaction:           ctx,
 					Params:                params,
 					RESTClient:            loader.rest,
+					logger:                loader.log(),
 				}
-				if err := modalRouter.SendModalResponse(b, data.CustomID); err != nil {
-					// There is only one error here, and it is when the modal is not found.
-					return nil
+				// The errors here are when the modal is not found or a parameter is invalid.
+				err = modalRouter.SendModalResponse(b, customID)
+				if err == nil {
+					return b.buildResponse(false, scope.handle, loader.globalAllowedMentions)
+				}
+				if errors.Is(err, InvalidRouteParam) {
+					loader.log().Warn("invalid route parameter", append(logAttrs(InteractionKindComponent, "", ctx), "error", err)...)
+					return wrapRouterErrors(scope.handle, &scope.info)(err)
 				}
-				return b.buildResponse(false, loader.errHandler, loader.globalAllowedMentions)
 			}
-			return nil
+			loader.log().Warn("component route not found", append(logAttrs(InteractionKindComponent, "", ctx), "custom_id", data.CustomID)...)
+			resp, _ := loader.notFound(c.notFoundHandler, &NotFoundInfo{
+				Interaction: ctx,
+				Kind:        InteractionKindComponent,
+				ID:          customID,
+			})
+			return resp
 		}
 
 		// Handle calling the route function.
-		resp := route.i.(contextCallback)(reqCtx, ctx, &data, params, r, errHandler)
+		scope.info.Route = route.r
+		loader.log().Debug("dispatching interaction", logAttrs(InteractionKindComponent, route.r, ctx)...)
+		if h := c.routeErrorHandlers[route.r]; h != nil {
+			scope.handler = h
+		}
+		if paramErr != nil {
+			loader.log().Warn("invalid route parameter", append(logAttrs(InteractionKindComponent, route.r, ctx), "error", paramErr)...)
+			return wrapRouterErrors(scope.handle, &scope.info)(paramErr)
+		}
+		reqCtx, r, done := loader.startDispatch(reqCtx, r, scope)
+		resp := route.i.(contextCallback)(reqCtx, ctx, &data, params, state, r, scope, errHandler)
+		done(resp)
 		if loader.generateFrames {
 			// Now we have all the data, we can generate the frame.
 			fr := frame{ctx, tape, returnedErr, resp}
-			go fr.write("testframes", "components", strings.ReplaceAll(route.r, "/", "_"))
+			loader.writeFrame(&fr, "testframes", "components", strings.ReplaceAll(routePath(route.r), "/", "_"))
 		}
 		return resp
 	}
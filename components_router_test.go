@@ -41,6 +41,22 @@ func TestComponentRouter_RegisterSelectMenu(t *testing.T) {
 	assert.Equal(t, reflect.Indirect(reflect.ValueOf(x.routes["/"])).Pointer(), reflect.ValueOf(f).Pointer())
 }
 
+func TestComponentRouter_RegisterTypedSelectMenus(t *testing.T) {
+	user := func(*ComponentRouterCtx, []ResolvableUser) error { return nil }
+	role := func(*ComponentRouterCtx, []ResolvableRole) error { return nil }
+	mentionable := func(*ComponentRouterCtx, []ResolvableMentionable) error { return nil }
+	channel := func(*ComponentRouterCtx, []ResolvableChannel) error { return nil }
+	x := &ComponentRouter{}
+	x.RegisterUserSelectMenu("/user", user)
+	x.RegisterRoleSelectMenu("/role", role)
+	x.RegisterMentionableSelectMenu("/mentionable", mentionable)
+	x.RegisterChannelSelectMenu("/channel", channel)
+	assert.Equal(t, reflect.ValueOf(user).Pointer(), reflect.ValueOf(x.routes["/user"]).Pointer())
+	assert.Equal(t, reflect.ValueOf(role).Pointer(), reflect.ValueOf(x.routes["/role"]).Pointer())
+	assert.Equal(t, reflect.ValueOf(mentionable).Pointer(), reflect.ValueOf(x.routes["/mentionable"]).Pointer())
+	assert.Equal(t, reflect.ValueOf(channel).Pointer(), reflect.ValueOf(x.routes["/channel"]).Pointer())
+}
+
 func TestComponentRouter_RegisterButton(t *testing.T) {
 	f := func(ctx *ComponentRouterCtx) error {
 		return nil
@@ -186,6 +202,78 @@ func TestComponentRouter_build(t *testing.T) {
 			},
 			expectsErr: "wumpus fled the scene",
 		},
+		{
+			name: "button middleware",
+			interaction: &objects.Interaction{
+				Data: jsonify(t, objects.ApplicationComponentInteractionData{
+					CustomID:      "/a/hello",
+					ComponentType: objects.ComponentTypeButton,
+				}),
+			},
+			init: func(_ *testing.T, r *ComponentRouter, _ **ModalRouter) {
+				r.Use(appendContentMiddleware("a"))
+				r.UsePrefix("/a/", appendContentMiddleware("b"))
+				r.UsePrefix("/b/", appendContentMiddleware("x"))
+				r.RegisterButton("/a/:content", func(ctx *ComponentRouterCtx) error {
+					ctx.SetContent(ctx.ResponseData().Content + ctx.Params["content"])
+					return nil
+				}, appendContentMiddleware("c"))
+			},
+			expects: &objects.InteractionResponse{
+				Type: objects.ResponseUpdateMessage,
+				Data: &objects.InteractionApplicationCommandCallbackData{
+					Content: "abchello",
+				},
+			},
+		},
+		{
+			name: "button middleware error",
+			interaction: &objects.Interaction{
+				Data: jsonify(t, objects.ApplicationComponentInteractionData{
+					CustomID:      "/a",
+					ComponentType: objects.ComponentTypeButton,
+				}),
+			},
+			init: func(_ *testing.T, r *ComponentRouter, _ **ModalRouter) {
+				r.Use(func(ctx InteractionMiddlewareCtx) error {
+					return errors.New("wumpus is blocked")
+				})
+				r.RegisterButton("/a", func(ctx *ComponentRouterCtx) error {
+					panic("this should not be called")
+				})
+			},
+			expectsErr: "wumpus is blocked",
+		},
+		{
+			name: "button around middleware",
+			interaction: &objects.Interaction{
+				Data: jsonify(t, objects.ApplicationComponentInteractionData{
+					CustomID:      "/a",
+					ComponentType: objects.ComponentTypeButton,
+				}),
+			},
+			init: func(_ *testing.T, r *ComponentRouter, _ **ModalRouter) {
+				r.UseAround(func(ctx AroundMiddlewareCtx) (*objects.InteractionResponse, error) {
+					resp, err := ctx.Next()
+					if err != nil {
+						return nil, err
+					}
+					resp.Data.Content += "!"
+					return resp, nil
+				})
+				r.Use(appendContentMiddleware("a"))
+				r.RegisterButton("/a", func(ctx *ComponentRouterCtx) error {
+					ctx.SetContent(ctx.ResponseData().Content + "b")
+					return nil
+				})
+			},
+			expects: &objects.InteractionResponse{
+				Type: objects.ResponseUpdateMessage,
+				Data: &objects.InteractionApplicationCommandCallbackData{
+					Content: "ab!",
+				},
+			},
+		},
 		{
 			name: "button panic",
 			interaction: &objects.Interaction{
@@ -253,6 +341,29 @@ func TestComponentRouter_build(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "select menu middleware",
+			interaction: &objects.Interaction{
+				Data: jsonify(t, objects.ApplicationComponentInteractionData{
+					CustomID:      "/a",
+					ComponentType: objects.ComponentTypeSelectMenu,
+					Values:        []string{"c"},
+				}),
+			},
+			init: func(t *testing.T, r *ComponentRouter, _ **ModalRouter) {
+				r.Use(appendContentMiddleware("a"))
+				r.RegisterSelectMenu("/a", func(ctx *ComponentRouterCtx, values []string) error {
+					ctx.SetContent(ctx.ResponseData().Content + values[0])
+					return nil
+				}, appendContentMiddleware("b"))
+			},
+			expects: &objects.InteractionResponse{
+				Type: objects.ResponseUpdateMessage,
+				Data: &objects.InteractionApplicationCommandCallbackData{
+					Content: "abc",
+				},
+			},
+		},
 		{
 			name: "select menu error",
 			interaction: &objects.Interaction{
@@ -381,6 +492,161 @@ func TestComponentRouter_build(t *testing.T) {
 				(*mr).build(loaderPassthrough{})
 			},
 		},
+		{
+			name: "user select success",
+			interaction: &objects.Interaction{
+				Data: jsonify(t, map[string]any{
+					"custom_id":      "/a",
+					"component_type": ComponentTypeUserSelect,
+					"values":         []string{"1", "2", "3"},
+					"resolved": objects.ApplicationCommandInteractionDataResolved{
+						Users: map[objects.Snowflake]objects.User{
+							1: {DiscordBaseObject: objects.DiscordBaseObject{ID: 1}, Username: "wumpus"},
+							2: {DiscordBaseObject: objects.DiscordBaseObject{ID: 2}, Username: "nelly"},
+						},
+						Members: map[objects.Snowflake]objects.GuildMember{
+							1: {Nick: "wumpy"},
+						},
+					},
+				}),
+			},
+			init: func(_ *testing.T, r *ComponentRouter, _ **ModalRouter) {
+				r.RegisterUserSelectMenu("/a", func(ctx *ComponentRouterCtx, users []ResolvableUser) error {
+					content := ""
+					for _, v := range users {
+						content += v.String() + ":"
+						if u := v.Resolve(); u != nil {
+							content += u.Username
+						}
+						if m := v.ResolveMember(); m != nil {
+							content += "/" + m.Nick + "/" + m.User.Username
+						}
+						content += " "
+					}
+					ctx.SetContent(content)
+					return nil
+				})
+			},
+			expects: &objects.InteractionResponse{
+				Type: objects.ResponseUpdateMessage,
+				Data: &objects.InteractionApplicationCommandCallbackData{
+					Content: "1:wumpus/wumpy/wumpus 2:nelly 3: ",
+				},
+			},
+		},
+		{
+			name: "role select success",
+			interaction: &objects.Interaction{
+				Data: jsonify(t, map[string]any{
+					"custom_id":      "/a",
+					"component_type": ComponentTypeRoleSelect,
+					"values":         []string{"1"},
+					// Roles are written like Discord does since the permissions of a role marshal differently.
+					"resolved": json.RawMessage(`{"roles": {"1": {"id": "1", "name": "mods", "permissions": "0"}}}`),
+				}),
+			},
+			init: func(_ *testing.T, r *ComponentRouter, _ **ModalRouter) {
+				r.RegisterRoleSelectMenu("/a", func(ctx *ComponentRouterCtx, roles []ResolvableRole) error {
+					ctx.SetContent(roles[0].Resolve().Name)
+					return nil
+				})
+			},
+			expects: &objects.InteractionResponse{
+				Type: objects.ResponseUpdateMessage,
+				Data: &objects.InteractionApplicationCommandCallbackData{
+					Content: "mods",
+				},
+			},
+		},
+		{
+			name: "mentionable select success",
+			interaction: &objects.Interaction{
+				Data: jsonify(t, map[string]any{
+					"custom_id":      "/a",
+					"component_type": ComponentTypeMentionableSelect,
+					"values":         []string{"1", "2"},
+					"resolved": json.RawMessage(`{
+						"users": {"1": {"id": "1", "username": "wumpus"}},
+						"roles": {"2": {"id": "2", "name": "mods", "permissions": "0"}}
+					}`),
+				}),
+			},
+			init: func(_ *testing.T, r *ComponentRouter, _ **ModalRouter) {
+				r.RegisterMentionableSelectMenu("/a", func(ctx *ComponentRouterCtx, mentionables []ResolvableMentionable) error {
+					ctx.SetContent(mentionables[0].Resolve().(*objects.User).Username + " " +
+						mentionables[1].Resolve().(*objects.Role).Name)
+					return nil
+				})
+			},
+			expects: &objects.InteractionResponse{
+				Type: objects.ResponseUpdateMessage,
+				Data: &objects.InteractionApplicationCommandCallbackData{
+					Content: "wumpus mods",
+				},
+			},
+		},
+		{
+			name: "channel select success",
+			interaction: &objects.Interaction{
+				Data: jsonify(t, map[string]any{
+					"custom_id":      "/a",
+					"component_type": ComponentTypeChannelSelect,
+					"values":         []string{"1"},
+					"resolved": objects.ApplicationCommandInteractionDataResolved{
+						Channels: map[objects.Snowflake]objects.Channel{
+							1: {DiscordBaseObject: objects.DiscordBaseObject{ID: 1}, Name: "general"},
+						},
+					},
+				}),
+			},
+			init: func(_ *testing.T, r *ComponentRouter, _ **ModalRouter) {
+				r.RegisterChannelSelectMenu("/a", func(ctx *ComponentRouterCtx, channels []ResolvableChannel) error {
+					ctx.SetContent(channels[0].Snowflake().String() + " " + channels[0].Resolve().Name)
+					return nil
+				})
+			},
+			expects: &objects.InteractionResponse{
+				Type: objects.ResponseUpdateMessage,
+				Data: &objects.InteractionApplicationCommandCallbackData{
+					Content: "1 general",
+				},
+			},
+		},
+		{
+			name: "user select wrong type",
+			interaction: &objects.Interaction{
+				Data: jsonify(t, objects.ApplicationComponentInteractionData{
+					CustomID:      "/a",
+					ComponentType: ComponentTypeRoleSelect,
+				}),
+			},
+			init: func(_ *testing.T, r *ComponentRouter, _ **ModalRouter) {
+				r.RegisterUserSelectMenu("/a", func(*ComponentRouterCtx, []ResolvableUser) error {
+					return nil
+				})
+			},
+			expectsErr: WrongSelectMenuType.Error(),
+		},
+		{
+			name: "channel select no values",
+			interaction: &objects.Interaction{
+				Data: jsonify(t, objects.ApplicationComponentInteractionData{
+					CustomID:      "/a",
+					ComponentType: ComponentTypeChannelSelect,
+				}),
+			},
+			init: func(_ *testing.T, r *ComponentRouter, _ **ModalRouter) {
+				r.RegisterChannelSelectMenu("/a", func(ctx *ComponentRouterCtx, channels []ResolvableChannel) error {
+					if channels == nil || len(channels) != 0 {
+						return errors.New("expected no channels")
+					}
+					return nil
+				})
+			},
+			expects: &objects.InteractionResponse{
+				Type: objects.ResponseDeferredMessageUpdate,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
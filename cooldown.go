@@ -0,0 +1,203 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Postcord/objects"
+)
+
+// CooldownBucket is used to define what a cooldown is tracked against.
+type CooldownBucket int
+
+const (
+	// CooldownBucketUser is used to track the cooldown per user.
+	CooldownBucketUser CooldownBucket = iota
+
+	// CooldownBucketGuild is used to track the cooldown per guild. In DMs, this is tracked per channel.
+	CooldownBucketGuild
+
+	// CooldownBucketChannel is used to track the cooldown per channel.
+	CooldownBucketChannel
+
+	// CooldownBucketGlobal is used to track one cooldown for everyone.
+	CooldownBucketGlobal
+)
+
+// String implements the fmt.Stringer interface.
+func (b CooldownBucket) String() string {
+	switch b {
+	case CooldownBucketUser:
+		return "user"
+	case CooldownBucketGuild:
+		return "guild"
+	case CooldownBucketChannel:
+		return "channel"
+	case CooldownBucketGlobal:
+		return "global"
+	default:
+		return "CooldownBucket(" + strconv.Itoa(int(b)) + ")"
+	}
+}
+
+// CooldownStore is used to define a store for cooldowns. This allows the cooldowns to be shared between processes.
+type CooldownStore interface {
+	// Take is used to take a use from the key specified. The key can be used burst times, and each use is refilled
+	// after the period specified. If there are no uses left, false is returned with the time until the next use is
+	// available.
+	Take(ctx context.Context, key string, burst int, period time.Duration) (ok bool, retryAfter time.Duration, err error)
+}
+
+// MemoryCooldownStore is used to store cooldowns in memory. The zero value is ready to use.
+type MemoryCooldownStore struct {
+	mu sync.Mutex
+
+	// Defines the time at which each key is fully refilled.
+	refilled map[string]time.Time
+
+	// Defines how many times Take has been called. This is used to decide when to remove old keys.
+	takes uint
+
+	// Defines the function used to get the current time. This is used for testing.
+	now func() time.Time
+}
+
+// Take implements the CooldownStore interface.
+func (m *MemoryCooldownStore) Take(_ context.Context, key string, burst int, period time.Duration) (bool, time.Duration, error) {
+	now := time.Now()
+	if m.now != nil {
+		now = m.now()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.refilled == nil {
+		m.refilled = map[string]time.Time{}
+	}
+
+	// Occasionally remove the keys which are fully refilled so the map does not grow forever.
+	m.takes++
+	if m.takes%1024 == 0 {
+		for k, v := range m.refilled {
+			if !v.After(now) {
+				delete(m.refilled, k)
+			}
+		}
+	}
+
+	// Each use pushes the time it is fully refilled back by the period. If that would be more than the burst away,
+	// there are no uses left.
+	refilled := m.refilled[key]
+	if refilled.Before(now) {
+		refilled = now
+	}
+	if wait := refilled.Sub(now) - period*time.Duration(burst-1); wait > 0 {
+		return false, wait, nil
+	}
+	m.refilled[key] = refilled.Add(period)
+	return true, 0, nil
+}
+
+// CooldownError is thrown when a cooldown is hit. The error handler can use this to tell the user when to try again.
+type CooldownError struct {
+	// Bucket is the bucket the cooldown is tracked against.
+	Bucket CooldownBucket
+
+	// RetryAfter is the time until it can be used again.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("%s cooldown hit, retry after %s", e.Bucket, e.RetryAfter)
+}
+
+// CooldownOptions is used to define the options for a cooldown.
+type CooldownOptions struct {
+	// Bucket is what the cooldown is tracked against. Defaults to per user.
+	Bucket CooldownBucket
+
+	// Burst is how many times it can be used before the cooldown is hit. Defaults to 1.
+	Burst int
+
+	// Period is how long it takes for a use to be refilled.
+	Period time.Duration
+
+	// Key is used to share a cooldown between middleware. If this is blank, the cooldown is only shared between the
+	// places this middleware is used.
+	Key string
+
+	// Store is the store used for the cooldowns. Defaults to a store in memory which is shared by all cooldowns.
+	Store CooldownStore
+}
+
+// Used to create a unique key for each cooldown without a key set.
+var cooldownID uint64
+
+// Defines the store used when one is not set.
+var defaultCooldownStore = &MemoryCooldownStore{}
+
+// Gets the key for the bucket from the interaction.
+func cooldownBucketKey(bucket CooldownBucket, interaction *objects.Interaction) string {
+	switch bucket {
+	case CooldownBucketUser:
+		user := interaction.User
+		if interaction.Member != nil && interaction.Member.User != nil {
+			user = interaction.Member.User
+		}
+		if user == nil {
+			return "0"
+		}
+		return strconv.FormatUint(uint64(user.ID), 10)
+	case CooldownBucketGuild:
+		if interaction.GuildID == 0 {
+			return "dm:" + strconv.FormatUint(uint64(interaction.ChannelID), 10)
+		}
+		return strconv.FormatUint(uint64(interaction.GuildID), 10)
+	case CooldownBucketChannel:
+		return strconv.FormatUint(uint64(interaction.ChannelID), 10)
+	default:
+		return ""
+	}
+}
+
+// Cooldown is used to create middleware which limits how often something can be used. This can be used with the
+// component and modal routers, or with commands and groups by wrapping it with CommandMiddleware. The cooldown is
+// shared between everywhere the middleware returned is used, so call this for each command or route which needs its
+// own cooldown. Auto-complete does not use the cooldown. When the cooldown is hit, a *CooldownError is returned.
+func Cooldown(opts CooldownOptions) InteractionMiddlewareFunc {
+	if opts.Burst < 1 {
+		opts.Burst = 1
+	}
+	if opts.Store == nil {
+		opts.Store = defaultCooldownStore
+	}
+	prefix := opts.Key
+	if prefix == "" {
+		prefix = "cooldown:" + strconv.FormatUint(atomic.AddUint64(&cooldownID, 1), 10)
+	}
+	prefix += ":" + opts.Bucket.String() + ":"
+
+	return func(ctx InteractionMiddlewareCtx) error {
+		interaction := ctx.GetInteraction()
+		if interaction.Type == objects.InteractionAutoComplete {
+			return ctx.Next()
+		}
+		reqCtx := ctx.GetContext()
+		if reqCtx == nil {
+			reqCtx = context.Background()
+		}
+		ok, retryAfter, err := opts.Store.Take(reqCtx, prefix+cooldownBucketKey(opts.Bucket, interaction), opts.Burst, opts.Period)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return &CooldownError{Bucket: opts.Bucket, RetryAfter: retryAfter}
+		}
+		return ctx.Next()
+	}
+}
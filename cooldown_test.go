@@ -0,0 +1,191 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCooldownBucket_String(t *testing.T) {
+	assert.Equal(t, "user", CooldownBucketUser.String())
+	assert.Equal(t, "guild", CooldownBucketGuild.String())
+	assert.Equal(t, "channel", CooldownBucketChannel.String())
+	assert.Equal(t, "global", CooldownBucketGlobal.String())
+	assert.Equal(t, "CooldownBucket(69)", CooldownBucket(69).String())
+}
+
+func TestMemoryCooldownStore_Take(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := &MemoryCooldownStore{now: func() time.Time { return now }}
+	take := func(key string) (bool, time.Duration) {
+		t.Helper()
+		ok, retryAfter, err := s.Take(context.Background(), key, 2, 10*time.Second)
+		require.NoError(t, err)
+		return ok, retryAfter
+	}
+
+	// Use up the burst.
+	ok, _ := take("a")
+	assert.True(t, ok)
+	now = now.Add(2 * time.Second)
+	ok, _ = take("a")
+	assert.True(t, ok)
+	ok, retryAfter := take("a")
+	assert.False(t, ok)
+	assert.Equal(t, 8*time.Second, retryAfter)
+
+	// Other keys are separate.
+	ok, _ = take("b")
+	assert.True(t, ok)
+
+	// One use is refilled after the period.
+	now = now.Add(8 * time.Second)
+	ok, _ = take("a")
+	assert.True(t, ok)
+	ok, retryAfter = take("a")
+	assert.False(t, ok)
+	assert.Equal(t, 10*time.Second, retryAfter)
+
+	// Everything is refilled after the burst periods.
+	now = now.Add(20 * time.Second)
+	ok, _ = take("a")
+	assert.True(t, ok)
+	ok, _ = take("a")
+	assert.True(t, ok)
+}
+
+func TestMemoryCooldownStore_Take_sweep(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := &MemoryCooldownStore{now: func() time.Time { return now }}
+	_, _, err := s.Take(context.Background(), "a", 1, time.Second)
+	require.NoError(t, err)
+	now = now.Add(time.Second)
+	s.takes = 1023
+	_, _, err = s.Take(context.Background(), "b", 1, time.Second)
+	require.NoError(t, err)
+	assert.Len(t, s.refilled, 1)
+	assert.Contains(t, s.refilled, "b")
+}
+
+func TestCooldownError_Error(t *testing.T) {
+	err := &CooldownError{Bucket: CooldownBucketGuild, RetryAfter: 5 * time.Second}
+	assert.EqualError(t, err, "guild cooldown hit, retry after 5s")
+}
+
+func Test_cooldownBucketKey(t *testing.T) {
+	tests := []struct {
+		name string
+
+		bucket      CooldownBucket
+		interaction *objects.Interaction
+
+		expects string
+	}{
+		{
+			name:   "member",
+			bucket: CooldownBucketUser,
+			interaction: &objects.Interaction{
+				Member: &objects.GuildMember{User: &objects.User{DiscordBaseObject: objects.DiscordBaseObject{ID: 1}}},
+			},
+			expects: "1",
+		},
+		{
+			name:   "user",
+			bucket: CooldownBucketUser,
+			interaction: &objects.Interaction{
+				User: &objects.User{DiscordBaseObject: objects.DiscordBaseObject{ID: 2}},
+			},
+			expects: "2",
+		},
+		{
+			name:        "guild",
+			bucket:      CooldownBucketGuild,
+			interaction: &objects.Interaction{GuildID: 3, ChannelID: 4},
+			expects:     "3",
+		},
+		{
+			name:        "guild in dms",
+			bucket:      CooldownBucketGuild,
+			interaction: &objects.Interaction{ChannelID: 4},
+			expects:     "dm:4",
+		},
+		{
+			name:        "channel",
+			bucket:      CooldownBucketChannel,
+			interaction: &objects.Interaction{GuildID: 3, ChannelID: 4},
+			expects:     "4",
+		},
+		{
+			name:        "global",
+			bucket:      CooldownBucketGlobal,
+			interaction: &objects.Interaction{GuildID: 3, ChannelID: 4},
+			expects:     "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expects, cooldownBucketKey(tt.bucket, tt.interaction))
+		})
+	}
+}
+
+// Defines a cooldown store which returns an error.
+type errorCooldownStore struct{}
+
+func (errorCooldownStore) Take(context.Context, string, int, time.Duration) (bool, time.Duration, error) {
+	return false, 0, errors.New("store is down")
+}
+
+func TestCooldown(t *testing.T) {
+	call := func(mw InteractionMiddlewareFunc, interaction *objects.Interaction) error {
+		ctx := &ComponentRouterCtx{Interaction: interaction}
+		return runInteractionMiddleware(ctx, []InteractionMiddlewareFunc{mw}, func() error { return nil })
+	}
+	user1 := &objects.Interaction{ChannelID: 1, User: &objects.User{DiscordBaseObject: objects.DiscordBaseObject{ID: 1}}}
+	user2 := &objects.Interaction{ChannelID: 1, User: &objects.User{DiscordBaseObject: objects.DiscordBaseObject{ID: 2}}}
+
+	t.Run("user bucket", func(t *testing.T) {
+		mw := Cooldown(CooldownOptions{Period: time.Hour})
+		assert.NoError(t, call(mw, user1))
+		err := call(mw, user1)
+		var cooldownErr *CooldownError
+		require.True(t, errors.As(err, &cooldownErr))
+		assert.Equal(t, CooldownBucketUser, cooldownErr.Bucket)
+		assert.Greater(t, cooldownErr.RetryAfter, 59*time.Minute)
+		assert.NoError(t, call(mw, user2))
+	})
+
+	t.Run("channel bucket with burst", func(t *testing.T) {
+		mw := Cooldown(CooldownOptions{Bucket: CooldownBucketChannel, Burst: 2, Period: time.Hour})
+		assert.NoError(t, call(mw, user1))
+		assert.NoError(t, call(mw, user2))
+		assert.Error(t, call(mw, user1))
+	})
+
+	t.Run("separate middleware", func(t *testing.T) {
+		assert.NoError(t, call(Cooldown(CooldownOptions{Period: time.Hour}), user1))
+		assert.NoError(t, call(Cooldown(CooldownOptions{Period: time.Hour}), user1))
+	})
+
+	t.Run("shared key", func(t *testing.T) {
+		assert.NoError(t, call(Cooldown(CooldownOptions{Period: time.Hour, Key: "TestCooldown"}), user1))
+		assert.Error(t, call(Cooldown(CooldownOptions{Period: time.Hour, Key: "TestCooldown"}), user1))
+	})
+
+	t.Run("auto-complete", func(t *testing.T) {
+		mw := Cooldown(CooldownOptions{Period: time.Hour})
+		interaction := &objects.Interaction{Type: objects.InteractionAutoComplete, User: user1.User}
+		assert.NoError(t, call(mw, interaction))
+		assert.NoError(t, call(mw, interaction))
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		mw := Cooldown(CooldownOptions{Period: time.Hour, Store: errorCooldownStore{}})
+		assert.EqualError(t, call(mw, user1), "store is down")
+	})
+}
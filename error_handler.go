@@ -0,0 +1,142 @@
+package router
+
+import (
+	"strconv"
+
+	"github.com/Postcord/objects"
+)
+
+// InteractionKind is used to define the kind of interaction which was being handled.
+type InteractionKind int
+
+const (
+	// InteractionKindCommand is used when a command was being handled.
+	InteractionKindCommand InteractionKind = iota + 1
+
+	// InteractionKindAutocomplete is used when auto-complete was being handled.
+	InteractionKindAutocomplete
+
+	// InteractionKindComponent is used when a component was being handled.
+	InteractionKindComponent
+
+	// InteractionKindModal is used when a modal was being handled.
+	InteractionKindModal
+)
+
+// String implements the fmt.Stringer interface.
+func (k InteractionKind) String() string {
+	switch k {
+	case InteractionKindCommand:
+		return "command"
+	case InteractionKindAutocomplete:
+		return "autocomplete"
+	case InteractionKindComponent:
+		return "component"
+	case InteractionKindModal:
+		return "modal"
+	default:
+		return "InteractionKind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// ErrorInfo is used to define information about where an error happened.
+type ErrorInfo struct {
+	// Interaction is the interaction which was being handled. This can be used to get the user, the interaction ID, or
+	// the locale to use for the error message.
+	Interaction *objects.Interaction
+
+	// Route is what was being handled. For commands, this is the command name including any groups separated by spaces.
+	// For components and modals, this is the route which was registered. This is blank if the error happened before
+	// the route was found.
+	Route string
+
+	// Kind is the kind of interaction which was being handled.
+	Kind InteractionKind
+
+	// Response is the response which was built before the error happened. This is nil if nothing was built.
+	Response *objects.InteractionResponse
+}
+
+// ContextErrorHandler defines an error handler which is also given information about where the error happened.
+type ContextErrorHandler = func(err error, info *ErrorInfo) *objects.InteractionResponse
+
+// RouterError is used to wrap errors which come from the router (such as NonExistentOption) with where they happened.
+// errors.Is can be used to check what the error is.
+type RouterError struct {
+	// Interaction is the interaction which was being handled.
+	Interaction *objects.Interaction
+
+	// Route is what was being handled. See ErrorInfo for more information.
+	Route string
+
+	// Kind is the kind of interaction which was being handled.
+	Kind InteractionKind
+
+	// Err is the error from the router.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RouterError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap is used to get the error from the router.
+func (e *RouterError) Unwrap() error {
+	return e.Err
+}
+
+// Used to handle the errors for an interaction. The route and response builder are set as the interaction is routed,
+// and are read when an error is handled.
+type errorScope struct {
+	loader  loaderPassthrough
+	info    ErrorInfo
+	builder *responseBuilder
+
+	// Defines the error handler nearest to the route. If this is set, it is used instead of the loaders.
+	handler ContextErrorHandler
+
+	// Defines the errors which were handled. This is used for instrumentation.
+	errs dispatchErrors
+}
+
+// Creates the error scope for an interaction.
+func newErrorScope(loader loaderPassthrough, interaction *objects.Interaction, kind InteractionKind) *errorScope {
+	return &errorScope{
+		loader: loader,
+		info:   ErrorInfo{Interaction: interaction, Kind: kind},
+	}
+}
+
+// Handles an error. This is an ErrorHandler.
+func (s *errorScope) handle(err error) *objects.InteractionResponse {
+	s.errs.set(err)
+	if s.loader.errHook != nil {
+		s.loader.errHook(err)
+	}
+	handler := s.handler
+	if handler == nil {
+		handler = s.loader.contextErrHandler
+	}
+	if handler == nil {
+		return s.loader.errHandler(err)
+	}
+	info := s.info
+	if s.builder != nil {
+		info.Response = s.builder.partialResponse()
+	}
+	return handler(err, &info)
+}
+
+// Wraps an error handler so errors from the router are wrapped with where they happened. The info is read when an
+// error is handled.
+func wrapRouterErrors(errHandler ErrorHandler, info *ErrorInfo) ErrorHandler {
+	return func(err error) *objects.InteractionResponse {
+		return errHandler(&RouterError{
+			Interaction: info.Interaction,
+			Route:       info.Route,
+			Kind:        info.Kind,
+			Err:         err,
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Postcord/interactions"
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInteractionKind_String(t *testing.T) {
+	assert.Equal(t, "command", InteractionKindCommand.String())
+	assert.Equal(t, "autocomplete", InteractionKindAutocomplete.String())
+	assert.Equal(t, "component", InteractionKindComponent.String())
+	assert.Equal(t, "modal", InteractionKindModal.String())
+	assert.Equal(t, "InteractionKind(0)", InteractionKind(0).String())
+}
+
+func TestRouterError(t *testing.T) {
+	err := &RouterError{Route: "a", Kind: InteractionKindCommand, Err: NonExistentOption}
+	assert.EqualError(t, err, NonExistentOption.Error())
+	assert.ErrorIs(t, err, NonExistentOption)
+}
+
+func Test_errorScope_handle(t *testing.T) {
+	interaction := &objects.Interaction{Locale: "en-GB"}
+
+	t.Run("error handler", func(t *testing.T) {
+		var errResult error
+		scope := newErrorScope(loaderPassthrough{
+			errHandler: func(err error) *objects.InteractionResponse {
+				errResult = err
+				return &objects.InteractionResponse{Type: 69}
+			},
+		}, interaction, InteractionKindModal)
+		assert.Equal(t, &objects.InteractionResponse{Type: 69}, scope.handle(NotButton))
+		assert.Equal(t, NotButton, errResult)
+	})
+
+	t.Run("context error handler", func(t *testing.T) {
+		var infoResult *ErrorInfo
+		scope := newErrorScope(loaderPassthrough{
+			errHandler: func(err error) *objects.InteractionResponse {
+				panic("should not be called")
+			},
+			contextErrHandler: func(err error, info *ErrorInfo) *objects.InteractionResponse {
+				infoResult = info
+				return &objects.InteractionResponse{Type: 69}
+			},
+		}, interaction, InteractionKindModal)
+		scope.info.Route = "/a"
+		rctx := &ModalRouterCtx{}
+		scope.builder = &rctx.responseBuilder
+		assert.Equal(t, &objects.InteractionResponse{Type: 69}, scope.handle(NotButton))
+		assert.Equal(t, &ErrorInfo{Interaction: interaction, Route: "/a", Kind: InteractionKindModal}, infoResult)
+
+		rctx.SetContent("hello")
+		scope.handle(NotButton)
+		assert.Equal(t, &objects.InteractionResponse{
+			Data: &objects.InteractionApplicationCommandCallbackData{Content: "hello"},
+		}, infoResult.Response)
+	})
+}
+
+func TestContextErrorHandler(t *testing.T) {
+	errResp := &objects.InteractionResponse{Type: 69}
+
+	tests := []struct {
+		name string
+
+		handler     func(loader loaderPassthrough) interactions.HandlerFunc
+		interaction *objects.Interaction
+
+		expectsErr      error
+		expectsRouteErr bool
+		expectsRoute    string
+		expectsKind     InteractionKind
+		expectsResponse *objects.InteractionResponse
+	}{
+		{
+			name: "command option does not exist",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				r := &CommandRouter{}
+				r.MustNewCommandGroup("a", "", nil).NewCommandBuilder("b").
+					Handler(func(*CommandRouterCtx) error { return nil }).
+					MustBuild()
+				h, _ := r.build(loader)
+				return h
+			},
+			interaction: mockInteraction(&objects.ApplicationCommandInteractionData{
+				Name: "a",
+				Type: objects.CommandTypeChatInput,
+				Options: []*objects.ApplicationCommandInteractionDataOption{
+					{
+						Name: "b",
+						Type: objects.TypeSubCommand,
+						Options: []*objects.ApplicationCommandInteractionDataOption{
+							{Name: "c", Type: objects.TypeString, Value: "d"},
+						},
+					},
+				},
+			}),
+			expectsErr:      NonExistentOption,
+			expectsRouteErr: true,
+			expectsRoute:    "a b",
+			expectsKind:     InteractionKindCommand,
+		},
+		{
+			name: "command handler error with partial response",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				r := &CommandRouter{}
+				r.NewCommandBuilder("a").
+					Handler(func(ctx *CommandRouterCtx) error {
+						ctx.SetContent("hello")
+						return NotOwner
+					}).
+					MustBuild()
+				h, _ := r.build(loader)
+				return h
+			},
+			interaction: mockInteraction(&objects.ApplicationCommandInteractionData{
+				Name: "a",
+				Type: objects.CommandTypeChatInput,
+			}),
+			expectsErr:   NotOwner,
+			expectsRoute: "a",
+			expectsKind:  InteractionKindCommand,
+			expectsResponse: &objects.InteractionResponse{
+				Data: &objects.InteractionApplicationCommandCallbackData{Content: "hello"},
+			},
+		},
+		{
+			name: "autocomplete function missing",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				r := &CommandRouter{}
+				r.NewCommandBuilder("a").
+					StringOption("b", "b", true, nil).
+					Handler(func(*CommandRouterCtx) error { return nil }).
+					MustBuild()
+				_, h := r.build(loader)
+				return h
+			},
+			interaction: mockInteraction(&objects.ApplicationCommandInteractionData{
+				Name: "a",
+				Type: objects.CommandTypeChatInput,
+				Options: []*objects.ApplicationCommandInteractionDataOption{
+					{Name: "b", Type: objects.TypeString, Value: "x", Focused: true},
+				},
+			}),
+			expectsErr:      NoAutoCompleteFunc,
+			expectsRouteErr: true,
+			expectsRoute:    "a",
+			expectsKind:     InteractionKindAutocomplete,
+		},
+		{
+			name: "component not button",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				r := &ComponentRouter{}
+				r.RegisterButton("/a/:id", func(*ComponentRouterCtx) error { return nil })
+				return r.build(nil, loader)
+			},
+			interaction: mockInteraction(objects.ApplicationComponentInteractionData{
+				CustomID:      "/a/1",
+				ComponentType: objects.ComponentTypeSelectMenu,
+			}),
+			expectsErr:      NotButton,
+			expectsRouteErr: true,
+			expectsRoute:    "/a/:id",
+			expectsKind:     InteractionKindComponent,
+		},
+		{
+			name: "modal path not found",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				return (&ModalRouter{}).build(loader)
+			},
+			interaction: mockInteraction(objects.ApplicationModalInteractionData{
+				CustomID: "/a",
+			}),
+			expectsErr:      ModalPathNotFound,
+			expectsRouteErr: true,
+			expectsKind:     InteractionKindModal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errResult error
+			var infoResult *ErrorInfo
+			handler := tt.handler(loaderPassthrough{
+				rest: dummyRestClient,
+				contextErrHandler: func(err error, info *ErrorInfo) *objects.InteractionResponse {
+					errResult = err
+					infoResult = info
+					return errResp
+				},
+			})
+			resp := handler(context.Background(), tt.interaction)
+			if tt.expectsKind != InteractionKindAutocomplete {
+				assert.Equal(t, errResp, resp)
+			}
+			assert.ErrorIs(t, errResult, tt.expectsErr)
+			require.NotNil(t, infoResult)
+			assert.Same(t, tt.interaction, infoResult.Interaction)
+			assert.Equal(t, tt.expectsRoute, infoResult.Route)
+			assert.Equal(t, tt.expectsKind, infoResult.Kind)
+			assert.Equal(t, tt.expectsResponse, infoResult.Response)
+
+			var routerErr *RouterError
+			if tt.expectsRouteErr {
+				require.True(t, errors.As(errResult, &routerErr))
+				assert.Same(t, tt.interaction, routerErr.Interaction)
+				assert.Equal(t, tt.expectsRoute, routerErr.Route)
+				assert.Equal(t, tt.expectsKind, routerErr.Kind)
+			} else {
+				assert.False(t, errors.As(errResult, &routerErr))
+			}
+		})
+	}
+}
+
+func TestScopedErrorHandlers(t *testing.T) {
+	// Creates an error handler which responds with the type specified.
+	handler := func(respType objects.ResponseType) ContextErrorHandler {
+		return func(error, *ErrorInfo) *objects.InteractionResponse {
+			return &objects.InteractionResponse{Type: respType}
+		}
+	}
+	fail := func(*CommandRouterCtx) error { return NotOwner }
+	commandInteraction := func(name string, options ...*objects.ApplicationCommandInteractionDataOption) *objects.Interaction {
+		return mockInteraction(&objects.ApplicationCommandInteractionData{
+			Name:    name,
+			Type:    objects.CommandTypeChatInput,
+			Options: options,
+		})
+	}
+
+	commandRouter := &CommandRouter{}
+	commandRouter.SetErrorHandler(handler(1))
+	commandRouter.NewCommandBuilder("router").Handler(fail).MustBuild()
+	commandRouter.NewCommandBuilder("command").ErrorHandler(handler(3)).Handler(fail).MustBuild()
+	group := commandRouter.MustNewCommandGroup("group", "", nil)
+	group.ErrorHandler = handler(2)
+	group.NewCommandBuilder("group").Handler(fail).MustBuild()
+	group.NewCommandBuilder("command").ErrorHandler(handler(3)).Handler(fail).MustBuild()
+
+	componentRouter := &ComponentRouter{}
+	componentRouter.SetErrorHandler(handler(1))
+	componentRouter.RegisterButton("/router", func(*ComponentRouterCtx) error { return NotOwner })
+	componentRouter.RegisterButton("/route", func(*ComponentRouterCtx) error { return NotOwner })
+	componentRouter.SetRouteErrorHandler("/route", handler(3))
+
+	modalRouter := &ModalRouter{}
+	modalRouter.SetErrorHandler(handler(1))
+	modalRouter.AddModal(&ModalContent{Path: "/router", Function: func(*ModalRouterCtx) error { return NotOwner }})
+	modalRouter.AddModal(&ModalContent{
+		Path:         "/modal",
+		Function:     func(*ModalRouterCtx) error { return NotOwner },
+		ErrorHandler: handler(3),
+	})
+
+	loader := loaderPassthrough{
+		rest:              dummyRestClient,
+		contextErrHandler: handler(69),
+	}
+	commandHandler, _ := commandRouter.build(loader)
+	componentHandler := componentRouter.build(nil, loader)
+	modalHandler := modalRouter.build(loader)
+
+	tests := []struct {
+		name string
+
+		handler     interactions.HandlerFunc
+		interaction *objects.Interaction
+
+		expectsType objects.ResponseType
+	}{
+		{
+			name:        "command router",
+			handler:     commandHandler,
+			interaction: commandInteraction("router"),
+			expectsType: 1,
+		},
+		{
+			name:        "command",
+			handler:     commandHandler,
+			interaction: commandInteraction("command"),
+			expectsType: 3,
+		},
+		{
+			name:        "group",
+			handler:     commandHandler,
+			interaction: commandInteraction("group", &objects.ApplicationCommandInteractionDataOption{Name: "group", Type: objects.TypeSubCommand}),
+			expectsType: 2,
+		},
+		{
+			name:        "command in group",
+			handler:     commandHandler,
+			interaction: commandInteraction("group", &objects.ApplicationCommandInteractionDataOption{Name: "command", Type: objects.TypeSubCommand}),
+			expectsType: 3,
+		},
+		{
+			name:        "component router",
+			handler:     componentHandler,
+			interaction: mockInteraction(objects.ApplicationComponentInteractionData{CustomID: "/router", ComponentType: objects.ComponentTypeButton}),
+			expectsType: 1,
+		},
+		{
+			name:        "component route",
+			handler:     componentHandler,
+			interaction: mockInteraction(objects.ApplicationComponentInteractionData{CustomID: "/route", ComponentType: objects.ComponentTypeButton}),
+			expectsType: 3,
+		},
+		{
+			name:        "modal router",
+			handler:     modalHandler,
+			interaction: mockInteraction(objects.ApplicationModalInteractionData{CustomID: "/router"}),
+			expectsType: 1,
+		},
+		{
+			name:        "modal",
+			handler:     modalHandler,
+			interaction: mockInteraction(objects.ApplicationModalInteractionData{CustomID: "/modal"}),
+			expectsType: 3,
+		},
+		{
+			name:        "loader",
+			handler:     (&ModalRouter{}).build(loader),
+			interaction: mockInteraction(objects.ApplicationModalInteractionData{CustomID: "/modal"}),
+			expectsType: 69,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := tt.handler(context.Background(), tt.interaction)
+			require.NotNil(t, resp)
+			assert.Equal(t, tt.expectsType, resp.Type)
+		})
+	}
+}
@@ -35,12 +35,9 @@ func builder() (*router.CommandRouter, *interactions.App, router.LoaderBuilder)
 
 	// Creates the component router.
 	componentRouter := &router.ComponentRouter{}
-	componentRouter.RegisterButton("/set/:number", func(ctx *router.ComponentRouterCtx) error {
-		number, err := strconv.ParseUint(ctx.Params["number"], 10, 64)
-		if err != nil {
-			// This would make the route specified invalid.
-			return nil
-		}
+	componentRouter.RegisterButton("/set/:number<uint>", func(ctx *router.ComponentRouterCtx) error {
+		// The number is validated by the route before this is called, so the error can be ignored.
+		number, _ := ctx.ParamUint("number")
 		embed, row := createResponse(number)
 		ctx.Ephemeral().SetEmbed(embed).AddComponentRow(row)
 		return nil
@@ -8,7 +8,7 @@ import (
 
 func TestComponent_set(t *testing.T) {
 	_, _, b := builder()
-	router.TestComponent(t, b, "/set/:number")
+	router.TestComponent(t, b, "/set/:number<uint>")
 }
 
 func Test_subgroups_group2_autocomplete(t *testing.T) {
@@ -25,21 +25,27 @@ type frame struct {
 }
 
 // Used to write the frame.
-func (f *frame) write(subfolders ...string) {
+func (f *frame) write(subfolders ...string) error {
 	// Ensure the folder exists.
 	joined := filepath.Join(subfolders...)
 	if err := os.MkdirAll(joined, 0777); err != nil {
-		panic(err)
+		return err
 	}
 
 	// Defines the filename.
 	filename := filepath.Join(joined, time.Now().In(time.UTC).Format("02-01-2006_15-04-03")+"_untitled_frame.json")
 	b, err := json.MarshalIndent(f, "", "  ")
 	if err != nil {
-		panic(err)
-	}
-	err = os.WriteFile(filename, b, 0666)
-	if err != nil {
-		panic(err)
+		return err
 	}
+	return os.WriteFile(filename, b, 0666)
+}
+
+// Used to write the frame in the background. If this fails, the error is logged.
+func (l loaderPassthrough) writeFrame(f *frame, subfolders ...string) {
+	go func() {
+		if err := f.write(subfolders...); err != nil {
+			l.log().Error("failed to write test frame", "path", filepath.Join(subfolders...), "error", err)
+		}
+	}()
 }
@@ -27,7 +27,7 @@ func Test_frame_write(t *testing.T) {
 		Error:    "test!",
 		Response: &objects.InteractionResponse{},
 	}
-	f.write(folderPath...)
+	require.NoError(t, f.write(folderPath...))
 
 	// Check the folder exists.
 	x, err := os.ReadDir(folderPathJoined)
@@ -47,7 +47,7 @@ func {{ .TypeName }}AutoCompleteFuncBuilder(f {{ .TypeName }}AutoCompleteFunc) {
 	}
 }
 
-func (c *commandBuilder[T]) {{ .TypeName }}Option(name, description string, required bool, choiceBuilder {{ .TypeName }}ChoiceBuilder) T {
+func (c *commandBuilder[T]) {{ .TypeName }}Option(name, description string, required bool, choiceBuilder {{ .TypeName }}ChoiceBuilder, configs ...OptionConfig) T {
 	var discordifiedChoices []objects.ApplicationCommandOptionChoice
 	var f {{ .TypeName }}AutoCompleteFunc
 	if choiceBuilder != nil {
@@ -58,6 +58,7 @@ func (c *commandBuilder[T]) {{ .TypeName }}Option(name, description string, requ
 			discordifiedChoices = make([]objects.ApplicationCommandOptionChoice, len(choices))
 			for i, v := range choices {
 				discordifiedChoices[i] = objects.ApplicationCommandOptionChoice{Name: v.Name, Value: v.Value}
+				c.cmd.localizeChoice(name, v.Name, v.NameLocalizations)
 			}
 		}, func(autoCompleteFunc {{ .TypeName }}AutoCompleteFunc) {
 			if discordifiedChoices != nil {
@@ -67,14 +68,16 @@ func (c *commandBuilder[T]) {{ .TypeName }}Option(name, description string, requ
 		})
 	}
 
-	c.cmd.Options = append(c.cmd.Options, &objects.ApplicationCommandOption{
+	option := &objects.ApplicationCommandOption{
 		OptionType:   objects.Type{{ .InteractionsTypeName }},
 		Name:         name,
 		Description:  description,
 		Required:     required,
 		Choices:      discordifiedChoices,
 		Autocomplete: f != nil,
-	})
+	}
+	c.cmd.configureOption(option, configs)
+	c.cmd.Options = append(c.cmd.Options, option)
 	if f != nil {
 		if c.cmd.autocomplete == nil {
 			c.cmd.autocomplete = map[string]any{}
@@ -5,6 +5,7 @@ package main
 
 import (
 	"bytes"
+	"go/format"
 	"io/ioutil"
 	"strings"
 	"text/template"
@@ -26,23 +27,29 @@ import (
 
 const singleStructureTemplate = `// UpdateLater is used to spawn the function specified in a goroutine. When the function is returned, the result is set as a message update.
 func (c *{{ .Type }}) UpdateLater(f func(*{{ .Type }}) error) *{{ .Type }} {
-	cpy := *c
-	cpy.responseBuilder = responseBuilder{}
+	// Copy everything but the response builder. The struct cannot be copied directly since the response builder has a lock.
+	cpy := &{{ .Type }}{ {{- range .Fields }}
+		{{ . }}: c.{{ . }},{{ end }}
+	}
 	go func() {
+		var response *objects.InteractionResponse
 		defer func() {
 			if errGeneric := recover(); errGeneric != nil {
-				cpy.errorHandler(ungenericError(errGeneric))
+				response = cpy.errorHandler(newPanicError(errGeneric, cpy.route))
+			}
+			// Need a better way to handle this context - the one on the RouterCtx will have been cancelled already
+			// and can't be used
+			err := processUpdateLaterResponse(context.Background(), cpy.RESTClient, cpy.ApplicationID, cpy.Token, response)
+			if err != nil {
+				attrs := logAttrs({{ .Kind }}, cpy.route, cpy.Interaction)
+				loggerOrNop(cpy.logger).Error("failed to update the response", append(attrs, "error", err)...)
 			}
 		}()
-		var response *objects.InteractionResponse
-		if err := f(&cpy); err == nil {
+		if err := f(cpy); err == nil {
 			response = cpy.buildResponse(false, cpy.errorHandler, cpy.globalAllowedMentions)
 		} else {
 			response = cpy.errorHandler(err)
 		}
-		// Need a better way to handle this context - the one on the RouterCtx will have been cancelled already
-		// and can't be used
-		processUpdateLaterResponse(context.Background(), cpy.RESTClient, cpy.ApplicationID, cpy.Token, response)
 	}()
 	return c
 }
@@ -62,27 +69,56 @@ func (c *{{ .Type }}) WithModalPath(path string) error {
 	return c.modalRouter.SendModalResponse(c, path)
 }{{ end }}`
 
-var types = []string{
+// Defines the fields (other than the response builder) of each type. These are copied by UpdateLater, and
+// Test_UpdateLater_fields fails if a field is missing.
+var types = map[string][]string{
+	"ComponentRouterCtx": {
+		"errorHandler", "globalAllowedMentions", "modalRouter", "voidGenerator", "route", "logger", "state",
+		"Context", "Interaction", "Params", "RESTClient",
+	},
+	"CommandRouterCtx": {
+		"errorHandler", "modalRouter", "globalAllowedMentions", "voidGenerator", "route", "logger",
+		"Interaction", "Context", "Command", "Options", "RESTClient",
+	},
+	"ModalRouterCtx": {
+		"errorHandler", "globalAllowedMentions", "voidGenerator", "route", "logger",
+		"Context", "Interaction", "Params", "ModalItems", "RESTClient",
+	},
+}
+
+// Defines the interaction kind of each type. This is used when logging.
+var kinds = map[string]string{
+	"ComponentRouterCtx": "InteractionKindComponent",
+	"CommandRouterCtx":   "InteractionKindCommand",
+	"ModalRouterCtx":     "InteractionKindModal",
+}
+
+// Defines the order the types are generated in.
+var typeOrder = []string{
 	"ComponentRouterCtx", "CommandRouterCtx",
 	"ModalRouterCtx",
 }
 
 func main() {
 	file := start
-	parts := make([]string, len(types))
+	parts := make([]string, len(typeOrder))
 	t, err := template.New("_").Parse(singleStructureTemplate)
 	if err != nil {
 		panic(err)
 	}
-	for i, v := range types {
+	for i, v := range typeOrder {
 		buf := &bytes.Buffer{}
-		if err := t.Execute(buf, map[string]any{"Type": v}); err != nil {
+		if err := t.Execute(buf, map[string]any{"Type": v, "Fields": types[v], "Kind": kinds[v]}); err != nil {
 			panic(err)
 		}
 		parts[i] = buf.String()
 	}
 	file += strings.Join(parts, "\n\n") + "\n"
-	if err := ioutil.WriteFile("response_builder_gen.go", []byte(file), 0666); err != nil {
+	formatted, err := format.Source([]byte(file))
+	if err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile("response_builder_gen.go", formatted, 0666); err != nil {
 		panic(err)
 	}
 }
@@ -0,0 +1,101 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"context"
+	"go/format"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/Postcord/rest"
+)
+
+const start = `// Code generated by generate_rest_instrumentation.go; DO NOT EDIT.
+
+package router
+
+//go:generate go run generate_rest_instrumentation.go
+
+import (
+	"context"
+	"image"
+
+	"github.com/Postcord/objects"
+	"github.com/Postcord/rest"
+)
+
+`
+
+func generateRestFunctions() string {
+	letters := []string{"", "a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	ctxType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+	t := reflect.TypeOf((*rest.Client)(nil))
+	methodNum := t.NumMethod()
+	funcs := make([]string, methodNum)
+	for i := 0; i < methodNum; i++ {
+		method := t.Method(i)
+		inNum := method.Type.NumIn()
+		numOut := method.Type.NumOut()
+		if inNum < 2 || method.Type.In(1) != ctxType || numOut == 0 || method.Type.Out(numOut-1) != errType {
+			panic("expected " + method.Name + " to take a context and return an error")
+		}
+
+		f := "func (r restInstrumented) " + method.Name + "("
+		params := ""
+		for j := 1; j < inNum; j++ {
+			if j > 1 {
+				f += ", "
+				params += ", "
+			}
+			fVard := ""
+			inStr := method.Type.In(j).String()
+			vard := method.Type.IsVariadic() && j == inNum-1
+			if vard {
+				fVard = "..."
+				inStr = inStr[2:]
+			}
+			f += letters[j] + " " + fVard + inStr
+			params += letters[j]
+			if vard {
+				params += "..."
+			}
+		}
+		f += ")"
+
+		retOutputs := make([]string, numOut)
+		outTypes := make([]string, numOut)
+		for j := 0; j < numOut; j++ {
+			retOutputs[j] = letters[j+inNum]
+			outTypes[j] = method.Type.Out(j).String()
+		}
+		if numOut == 1 {
+			retOutputs[0] = "x"
+			f += " " + outTypes[0]
+		} else {
+			f += " (" + strings.Join(outTypes, ", ") + ")"
+		}
+		outCall := strings.Join(retOutputs, ", ")
+		f += ` {
+	a, call := r.start(a, "` + method.Name + `")
+	` + outCall + " := r.rest." + method.Name + `(` + params + `)
+	call.end(` + retOutputs[numOut-1] + `)
+	return ` + outCall + `
+}`
+		funcs[i] = f
+	}
+	return start + strings.Join(funcs, "\n\n") + "\n"
+}
+
+func main() {
+	formatted, err := format.Source([]byte(generateRestFunctions()))
+	if err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile("rest_instrumentation_gen.go", formatted, 0666); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,215 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/Postcord/objects"
+	"github.com/Postcord/objects/permissions"
+)
+
+// NotInGuild is thrown when something which can only be used in a guild is used in a DM.
+var NotInGuild = errors.New("this can only be used in a guild")
+
+// NotInDM is thrown when something which can only be used in a DM is used in a guild.
+var NotInDM = errors.New("this can only be used in a DM")
+
+// MissingMemberPermissions is thrown when the member does not have the permissions required.
+var MissingMemberPermissions = errors.New("member is missing permissions")
+
+// MissingAppPermissions is thrown when the app does not have the permissions required.
+var MissingAppPermissions = errors.New("app is missing permissions")
+
+// AppPermissionsUnavailable is thrown when app permissions are required but the guard has no way to get them.
+var AppPermissionsUnavailable = errors.New("app permissions are required but AppPermissionsFunc is not set")
+
+// MissingAllowedRole is thrown when the member does not have any of the roles allowed.
+var MissingAllowedRole = errors.New("member does not have an allowed role")
+
+// NotOwner is thrown when something which can only be used by the owners is used by someone else.
+var NotOwner = errors.New("this can only be used by the owners")
+
+// ConflictingGuard is thrown when a guard requires a DM and also requires a guild (by setting GuildOnly,
+// MemberPermissions, or Roles), since it could never pass.
+var ConflictingGuard = errors.New("guard cannot require both a DM and a guild")
+
+// GuardError is thrown when a guard fails. The underlying error is one of the errors above, so errors.Is can be used to
+// check what went wrong.
+type GuardError struct {
+	// Missing is the permissions which are missing if the member or app is missing permissions.
+	Missing permissions.PermissionBit
+
+	// Err is the guard error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *GuardError) Error() string {
+	if e.Missing == 0 {
+		return e.Err.Error()
+	}
+	var names []string
+	for x := uint64(e.Missing); x != 0; x &= x - 1 {
+		names = append(names, permissions.PermissionBit(1<<bits.TrailingZeros64(x)).String())
+	}
+	return fmt.Sprintf("%v: %s", e.Err, strings.Join(names, ", "))
+}
+
+// Unwrap is used to get the guard error.
+func (e *GuardError) Unwrap() error {
+	return e.Err
+}
+
+// Guard is used to define the requirements which are checked by the router before a command, component, or modal is
+// handled. Guards are opt-in and only apply where their middleware is added. Deriving a guard from the default
+// permissions or DM setting of a command was rejected, since server admins can override these for their guild and a
+// derived guard would block the users they allowed. Where a guard is added, it is checked on every interaction, so it
+// cannot be bypassed with stale registrations or permission overrides.
+type Guard struct {
+	// GuildOnly is used to only allow this to be used in guilds.
+	GuildOnly bool
+
+	// DMOnly is used to only allow this to be used in DMs.
+	DMOnly bool
+
+	// MemberPermissions is the permissions the member must have in the channel. Administrators have all permissions.
+	// If this is set, this can only be used in guilds.
+	MemberPermissions permissions.PermissionBit
+
+	// AppPermissions is the permissions the app must have in the channel. The Postcord interaction object does not
+	// include the permissions of the app, so AppPermissionsFunc must be set to get them.
+	AppPermissions permissions.PermissionBit
+
+	// AppPermissionsFunc is used to get the permissions of the app in the channel the interaction was in.
+	AppPermissionsFunc func(ctx InteractionCtx) (permissions.PermissionBit, error)
+
+	// Roles is the roles which are allowed. If this is not empty, the member must have one of the roles and this can
+	// only be used in guilds.
+	Roles []objects.Snowflake
+
+	// Owners is the IDs of the users who own the app. If this is not empty, only these users can use this.
+	Owners []objects.Snowflake
+}
+
+// Gets the ID of the user who made the interaction.
+func interactionUserID(interaction *objects.Interaction) objects.Snowflake {
+	if interaction.Member != nil && interaction.Member.User != nil {
+		return interaction.Member.User.ID
+	}
+	if interaction.User != nil {
+		return interaction.User.ID
+	}
+	return 0
+}
+
+// Checks if the guard can only pass in a guild.
+func (g Guard) needsGuild() bool {
+	return g.GuildOnly || g.MemberPermissions != 0 || len(g.Roles) != 0
+}
+
+// Validates that the guard could pass.
+func (g Guard) validate() error {
+	if g.DMOnly && g.needsGuild() {
+		return &GuardError{Err: ConflictingGuard}
+	}
+	return nil
+}
+
+// Check is used to check the guard against the context. If a requirement is not met, a *GuardError is returned.
+func (g Guard) Check(ctx InteractionCtx) error {
+	if err := g.validate(); err != nil {
+		return err
+	}
+	interaction := ctx.GetInteraction()
+	inGuild := interaction.GuildID != 0
+	if g.needsGuild() && !inGuild {
+		return &GuardError{Err: NotInGuild}
+	}
+	if g.DMOnly && inGuild {
+		return &GuardError{Err: NotInDM}
+	}
+
+	// Check the owners.
+	if len(g.Owners) != 0 {
+		owner := false
+		userID := interactionUserID(interaction)
+		for _, v := range g.Owners {
+			if v == userID {
+				owner = true
+				break
+			}
+		}
+		if !owner {
+			return &GuardError{Err: NotOwner}
+		}
+	}
+
+	// Check the member permissions.
+	if g.MemberPermissions != 0 {
+		var perms permissions.PermissionBit
+		if interaction.Member != nil {
+			x, _ := strconv.ParseUint(interaction.Member.Permissions, 10, 64)
+			perms = permissions.PermissionBit(x)
+		}
+		if !perms.HasOrAdmin(g.MemberPermissions) {
+			return &GuardError{Missing: g.MemberPermissions &^ perms, Err: MissingMemberPermissions}
+		}
+	}
+
+	// Check the roles.
+	if len(g.Roles) != 0 {
+		allowed := false
+		if interaction.Member != nil {
+		roleLoop:
+			for _, v := range interaction.Member.Roles {
+				for _, role := range g.Roles {
+					if v == role {
+						allowed = true
+						break roleLoop
+					}
+				}
+			}
+		}
+		if !allowed {
+			return &GuardError{Err: MissingAllowedRole}
+		}
+	}
+
+	// Check the app permissions.
+	if g.AppPermissions != 0 {
+		if g.AppPermissionsFunc == nil {
+			return &GuardError{Err: AppPermissionsUnavailable}
+		}
+		perms, err := g.AppPermissionsFunc(ctx)
+		if err != nil {
+			return err
+		}
+		if !perms.HasOrAdmin(g.AppPermissions) {
+			return &GuardError{Missing: g.AppPermissions &^ perms, Err: MissingAppPermissions}
+		}
+	}
+	return nil
+}
+
+// Middleware is used to get middleware which checks the guard. This can be used with the component and modal routers
+// and their routes. This panics if the guard could never pass.
+func (g Guard) Middleware() InteractionMiddlewareFunc {
+	if err := g.validate(); err != nil {
+		panic(err)
+	}
+	return func(ctx InteractionMiddlewareCtx) error {
+		if err := g.Check(ctx.InteractionCtx); err != nil {
+			return err
+		}
+		return ctx.Next()
+	}
+}
+
+// CommandMiddleware is used to get middleware which checks the guard for commands and command groups. This panics if the
+// guard could never pass.
+func (g Guard) CommandMiddleware() MiddlewareFunc {
+	return CommandMiddleware(g.Middleware())
+}
@@ -0,0 +1,185 @@
+package router
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Postcord/objects"
+	"github.com/Postcord/objects/permissions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardError_Error(t *testing.T) {
+	assert.EqualError(t, &GuardError{Err: NotOwner}, "this can only be used by the owners")
+	assert.EqualError(t, &GuardError{Missing: permissions.BanMembers | permissions.ManageGuild, Err: MissingMemberPermissions},
+		"member is missing permissions: BanMembers, ManageGuild")
+}
+
+func guardTestMember(perms string, roles ...objects.Snowflake) *objects.GuildMember {
+	return &objects.GuildMember{
+		User:        &objects.User{DiscordBaseObject: objects.DiscordBaseObject{ID: 1}},
+		Permissions: perms,
+		Roles:       roles,
+	}
+}
+
+func TestGuard_Check(t *testing.T) {
+	guildInteraction := &objects.Interaction{GuildID: 1, Member: guardTestMember("34", 2)} // KickMembers | ManageGuild
+	adminInteraction := &objects.Interaction{GuildID: 1, Member: guardTestMember("8")}
+	dmInteraction := &objects.Interaction{User: &objects.User{DiscordBaseObject: objects.DiscordBaseObject{ID: 1}}}
+	appPerms := func(perms permissions.PermissionBit, err error) func(InteractionCtx) (permissions.PermissionBit, error) {
+		return func(InteractionCtx) (permissions.PermissionBit, error) { return perms, err }
+	}
+
+	tests := []struct {
+		name string
+
+		guard       Guard
+		interaction *objects.Interaction
+
+		expectsErr     error
+		expectsMissing permissions.PermissionBit
+	}{
+		{
+			name:        "empty guard",
+			interaction: dmInteraction,
+		},
+		{
+			name:        "guild only in guild",
+			guard:       Guard{GuildOnly: true},
+			interaction: guildInteraction,
+		},
+		{
+			name:        "guild only in dm",
+			guard:       Guard{GuildOnly: true},
+			interaction: dmInteraction,
+			expectsErr:  NotInGuild,
+		},
+		{
+			name:        "dm only in dm",
+			guard:       Guard{DMOnly: true},
+			interaction: dmInteraction,
+		},
+		{
+			name:        "dm only in guild",
+			guard:       Guard{DMOnly: true},
+			interaction: guildInteraction,
+			expectsErr:  NotInDM,
+		},
+		{
+			name:        "member has permissions",
+			guard:       Guard{MemberPermissions: permissions.KickMembers | permissions.ManageGuild},
+			interaction: guildInteraction,
+		},
+		{
+			name:           "member missing permissions",
+			guard:          Guard{MemberPermissions: permissions.KickMembers | permissions.BanMembers},
+			interaction:    guildInteraction,
+			expectsErr:     MissingMemberPermissions,
+			expectsMissing: permissions.BanMembers,
+		},
+		{
+			name:        "member is admin",
+			guard:       Guard{MemberPermissions: permissions.BanMembers},
+			interaction: adminInteraction,
+		},
+		{
+			name:        "member permissions in dm",
+			guard:       Guard{MemberPermissions: permissions.BanMembers},
+			interaction: dmInteraction,
+			expectsErr:  NotInGuild,
+		},
+		{
+			name:        "member has role",
+			guard:       Guard{Roles: []objects.Snowflake{3, 2}},
+			interaction: guildInteraction,
+		},
+		{
+			name:        "member missing role",
+			guard:       Guard{Roles: []objects.Snowflake{3}},
+			interaction: guildInteraction,
+			expectsErr:  MissingAllowedRole,
+		},
+		{
+			name:        "owner",
+			guard:       Guard{Owners: []objects.Snowflake{1}},
+			interaction: dmInteraction,
+		},
+		{
+			name:        "not owner",
+			guard:       Guard{Owners: []objects.Snowflake{2}},
+			interaction: guildInteraction,
+			expectsErr:  NotOwner,
+		},
+		{
+			name:        "app has permissions",
+			guard:       Guard{AppPermissions: permissions.SendMessages, AppPermissionsFunc: appPerms(permissions.SendMessages, nil)},
+			interaction: guildInteraction,
+		},
+		{
+			name:           "app missing permissions",
+			guard:          Guard{AppPermissions: permissions.SendMessages | permissions.EmbedLinks, AppPermissionsFunc: appPerms(permissions.SendMessages, nil)},
+			interaction:    guildInteraction,
+			expectsErr:     MissingAppPermissions,
+			expectsMissing: permissions.EmbedLinks,
+		},
+		{
+			name:        "app permissions unavailable",
+			guard:       Guard{AppPermissions: permissions.SendMessages},
+			interaction: guildInteraction,
+			expectsErr:  AppPermissionsUnavailable,
+		},
+		{
+			name:        "guild only and dm only",
+			guard:       Guard{GuildOnly: true, DMOnly: true},
+			interaction: dmInteraction,
+			expectsErr:  ConflictingGuard,
+		},
+		{
+			name:        "roles and dm only",
+			guard:       Guard{Roles: []objects.Snowflake{2}, DMOnly: true},
+			interaction: guildInteraction,
+			expectsErr:  ConflictingGuard,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.guard.Check(&ComponentRouterCtx{Interaction: tt.interaction})
+			if tt.expectsErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var guardErr *GuardError
+			require.True(t, errors.As(err, &guardErr))
+			assert.ErrorIs(t, err, tt.expectsErr)
+			assert.Equal(t, tt.expectsMissing, guardErr.Missing)
+		})
+	}
+
+	t.Run("app permissions error", func(t *testing.T) {
+		g := Guard{AppPermissions: permissions.SendMessages, AppPermissionsFunc: appPerms(0, errors.New("rest error"))}
+		assert.EqualError(t, g.Check(&ComponentRouterCtx{Interaction: guildInteraction}), "rest error")
+	})
+}
+
+func TestGuard_Middleware(t *testing.T) {
+	g := Guard{GuildOnly: true}
+	called := false
+	handler := func() error {
+		called = true
+		return nil
+	}
+
+	err := runInteractionMiddleware(&ModalRouterCtx{Interaction: &objects.Interaction{}}, []InteractionMiddlewareFunc{g.Middleware()}, handler)
+	assert.ErrorIs(t, err, NotInGuild)
+	assert.False(t, called)
+
+	err = runInteractionMiddleware(&ModalRouterCtx{Interaction: &objects.Interaction{GuildID: 1}}, []InteractionMiddlewareFunc{g.Middleware()}, handler)
+	assert.NoError(t, err)
+	assert.True(t, called)
+
+	conflicting := Guard{GuildOnly: true, DMOnly: true}
+	assert.PanicsWithError(t, ConflictingGuard.Error(), func() { conflicting.Middleware() })
+	assert.PanicsWithError(t, ConflictingGuard.Error(), func() { conflicting.CommandMiddleware() })
+}
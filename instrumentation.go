@@ -0,0 +1,159 @@
+package router
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Postcord/objects"
+	"github.com/Postcord/rest"
+)
+
+// DispatchInfo is used to define information about an interaction which is being dispatched to a route.
+type DispatchInfo struct {
+	// Interaction is the interaction which is being handled.
+	Interaction *objects.Interaction
+
+	// Route is the route which is being handled. See ErrorInfo for more information.
+	Route string
+
+	// Kind is the kind of interaction which is being handled.
+	Kind InteractionKind
+}
+
+// DispatchResult is used to define the result of dispatching an interaction.
+type DispatchResult struct {
+	// Duration is how long the route took to respond.
+	Duration time.Duration
+
+	// Err is the error which was passed to the error handler. This is nil if there was no error.
+	Err error
+
+	// ResponseType is the type of the response. This is 0 if there was no response.
+	ResponseType objects.ResponseType
+
+	// Response is the response which was returned. This can be nil.
+	Response *objects.InteractionResponse
+}
+
+// RESTCallInfo is used to define information about a REST call made through a context.
+type RESTCallInfo struct {
+	// DispatchInfo is the information about the interaction which made the call.
+	DispatchInfo
+
+	// Method is the name of the rest.RESTClient method which was called.
+	Method string
+}
+
+// RESTCallResult is used to define the result of a REST call.
+type RESTCallResult struct {
+	// Duration is how long the call took.
+	Duration time.Duration
+
+	// Err is the error returned by the call.
+	Err error
+}
+
+// Instrumentation is used to define hooks which are called when interactions are dispatched and when REST calls are
+// made through a context. This can be used for metrics and tracing. The context returned by the start hooks is the one
+// used for the dispatch or call, so spans can be attached to it, and is passed to the end hooks.
+type Instrumentation interface {
+	// DispatchStart is called when an interaction is about to be dispatched to a route.
+	DispatchStart(ctx context.Context, info *DispatchInfo) context.Context
+
+	// DispatchEnd is called when the route has responded.
+	DispatchEnd(ctx context.Context, info *DispatchInfo, result *DispatchResult)
+
+	// RESTCallStart is called before a REST call is made.
+	RESTCallStart(ctx context.Context, info *RESTCallInfo) context.Context
+
+	// RESTCallEnd is called when the REST call has returned.
+	RESTCallEnd(ctx context.Context, info *RESTCallInfo, result *RESTCallResult)
+}
+
+// Defines the function used to get the current time. This is used for testing.
+var instrumentationNow = time.Now
+
+// Used to wrap a REST client so each call is instrumented.
+type restInstrumented struct {
+	rest rest.RESTClient
+	inst Instrumentation
+	info DispatchInfo
+}
+
+// Used to track a REST call which has started.
+type restInstrumentedCall struct {
+	ctx   context.Context
+	inst  Instrumentation
+	info  *RESTCallInfo
+	start time.Time
+}
+
+// Starts instrumenting a REST call.
+func (r restInstrumented) start(ctx context.Context, method string) (context.Context, restInstrumentedCall) {
+	info := &RESTCallInfo{DispatchInfo: r.info, Method: method}
+	ctx = r.inst.RESTCallStart(ctx, info)
+	return ctx, restInstrumentedCall{ctx: ctx, inst: r.inst, info: info, start: instrumentationNow()}
+}
+
+// Ends instrumenting the REST call.
+func (c restInstrumentedCall) end(err error) {
+	c.inst.RESTCallEnd(c.ctx, c.info, &RESTCallResult{
+		Duration: instrumentationNow().Sub(c.start),
+		Err:      err,
+	})
+}
+
+// Used to track the errors handled during a dispatch.
+type dispatchErrors struct {
+	mu  sync.Mutex
+	err error
+}
+
+// Sets the error if one was not already set. This is safe to call from UpdateLater.
+func (d *dispatchErrors) set(err error) {
+	d.mu.Lock()
+	if d.err == nil {
+		d.err = err
+	}
+	d.mu.Unlock()
+}
+
+// Gets the first error which was handled.
+func (d *dispatchErrors) get() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.err
+}
+
+// Starts instrumenting the dispatch of the route in the error scope. The context and REST client to use for the
+// dispatch are returned along with a function which should be called with the response when it is done.
+func (l loaderPassthrough) startDispatch(
+	reqCtx context.Context, r rest.RESTClient, scope *errorScope,
+) (context.Context, rest.RESTClient, func(*objects.InteractionResponse)) {
+	if l.instrumentation == nil {
+		return reqCtx, r, func(*objects.InteractionResponse) {}
+	}
+	info := &DispatchInfo{
+		Interaction: scope.info.Interaction,
+		Route:       scope.info.Route,
+		Kind:        scope.info.Kind,
+	}
+	if reqCtx == nil {
+		reqCtx = context.Background()
+	}
+	reqCtx = l.instrumentation.DispatchStart(reqCtx, info)
+	start := instrumentationNow()
+	r = restInstrumented{rest: r, inst: l.instrumentation, info: *info}
+	return reqCtx, r, func(resp *objects.InteractionResponse) {
+		result := &DispatchResult{
+			Duration: instrumentationNow().Sub(start),
+			Err:      scope.errs.get(),
+			Response: resp,
+		}
+		if resp != nil {
+			result.ResponseType = resp.Type
+		}
+		l.instrumentation.DispatchEnd(reqCtx, info, result)
+	}
+}
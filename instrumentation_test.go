@@ -0,0 +1,260 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Postcord/objects"
+	"github.com/Postcord/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ rest.RESTClient = restInstrumented{}
+
+type instrumentationCtxKey struct{}
+
+type instrumentationEvent struct {
+	hook   string
+	ctx    context.Context
+	info   any
+	result any
+}
+
+type recordingInstrumentation struct {
+	mu     sync.Mutex
+	events []instrumentationEvent
+}
+
+func (r *recordingInstrumentation) add(e instrumentationEvent) {
+	r.mu.Lock()
+	r.events = append(r.events, e)
+	r.mu.Unlock()
+}
+
+func (r *recordingInstrumentation) get() []instrumentationEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]instrumentationEvent(nil), r.events...)
+}
+
+func (r *recordingInstrumentation) DispatchStart(ctx context.Context, info *DispatchInfo) context.Context {
+	r.add(instrumentationEvent{hook: "DispatchStart", ctx: ctx, info: info})
+	return context.WithValue(ctx, instrumentationCtxKey{}, "dispatch")
+}
+
+func (r *recordingInstrumentation) DispatchEnd(ctx context.Context, info *DispatchInfo, result *DispatchResult) {
+	r.add(instrumentationEvent{hook: "DispatchEnd", ctx: ctx, info: info, result: result})
+}
+
+func (r *recordingInstrumentation) RESTCallStart(ctx context.Context, info *RESTCallInfo) context.Context {
+	r.add(instrumentationEvent{hook: "RESTCallStart", ctx: ctx, info: info})
+	return context.WithValue(ctx, instrumentationCtxKey{}, "rest")
+}
+
+func (r *recordingInstrumentation) RESTCallEnd(ctx context.Context, info *RESTCallInfo, result *RESTCallResult) {
+	r.add(instrumentationEvent{hook: "RESTCallEnd", ctx: ctx, info: info, result: result})
+}
+
+// Makes each call to instrumentationNow a second after the last.
+func mockInstrumentationNow(t *testing.T) {
+	t.Helper()
+	now := time.Unix(0, 0)
+	instrumentationNow = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	t.Cleanup(func() { instrumentationNow = time.Now })
+}
+
+type contextCheckingRestClient struct {
+	rest.RESTClient
+
+	ctx context.Context
+}
+
+func (c *contextCheckingRestClient) GetUser(ctx context.Context, _ objects.SnowflakeObject) (*objects.User, error) {
+	c.ctx = ctx
+	return nil, errEditFailed
+}
+
+func TestInstrumentation(t *testing.T) {
+	mockInstrumentationNow(t)
+	errResp := &objects.InteractionResponse{Type: objects.ResponseChannelMessageWithSource}
+
+	commandRouter := &CommandRouter{}
+	commandRouter.NewCommandBuilder("a").
+		StringOption("b", "b", false, StringAutoCompleteFuncBuilder(func(*CommandRouterCtx) ([]StringChoice, error) {
+			return nil, NotOwner
+		})).
+		Handler(func(ctx *CommandRouterCtx) error {
+			assert.Equal(t, "dispatch", ctx.Context.Value(instrumentationCtxKey{}))
+			ctx.SetContent("hello")
+			return nil
+		}).
+		MustBuild()
+	componentRouter := &ComponentRouter{}
+	componentRouter.RegisterButton("/a/:id", func(ctx *ComponentRouterCtx) error {
+		_, err := ctx.RESTClient.GetUser(ctx.Context, objects.Snowflake(1))
+		return err
+	})
+	modalRouter := &ModalRouter{}
+	modalRouter.AddModal(&ModalContent{
+		Path: "/a",
+		Function: func(*ModalRouterCtx) error {
+			panic("wumpus fled the scene")
+		},
+	})
+
+	tests := []struct {
+		name string
+
+		handle      func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse
+		interaction *objects.Interaction
+		rest        bool
+
+		expectsRoute        string
+		expectsKind         InteractionKind
+		expectsErr          error
+		expectsResponseType objects.ResponseType
+	}{
+		{
+			name: "command",
+			handle: func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse {
+				h, _ := commandRouter.build(loader)
+				return h(context.Background(), interaction)
+			},
+			interaction:         mockInteraction(&objects.ApplicationCommandInteractionData{Name: "a"}),
+			expectsRoute:        "a",
+			expectsKind:         InteractionKindCommand,
+			expectsResponseType: objects.ResponseChannelMessageWithSource,
+		},
+		{
+			name: "autocomplete",
+			handle: func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse {
+				_, h := commandRouter.build(loader)
+				return h(context.Background(), interaction)
+			},
+			interaction: mockInteraction(&objects.ApplicationCommandInteractionData{
+				Name: "a",
+				Options: []*objects.ApplicationCommandInteractionDataOption{
+					{Name: "b", Type: objects.TypeString, Value: "x", Focused: true},
+				},
+			}),
+			expectsRoute: "a",
+			expectsKind:  InteractionKindAutocomplete,
+			expectsErr:   NotOwner,
+		},
+		{
+			name: "component",
+			handle: func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse {
+				return componentRouter.build(nil, loader)(context.Background(), interaction)
+			},
+			interaction: mockInteraction(&objects.ApplicationComponentInteractionData{
+				CustomID:      "/a/1",
+				ComponentType: objects.ComponentTypeButton,
+			}),
+			rest:                true,
+			expectsRoute:        "/a/:id",
+			expectsKind:         InteractionKindComponent,
+			expectsErr:          errEditFailed,
+			expectsResponseType: objects.ResponseChannelMessageWithSource,
+		},
+		{
+			name: "modal",
+			handle: func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse {
+				return modalRouter.build(loader)(context.Background(), interaction)
+			},
+			interaction:         mockInteraction(&objects.ApplicationModalInteractionData{CustomID: "/a"}),
+			expectsRoute:        "/a",
+			expectsKind:         InteractionKindModal,
+			expectsErr:          &PanicError{},
+			expectsResponseType: objects.ResponseChannelMessageWithSource,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inst := &recordingInstrumentation{}
+			restClient := &contextCheckingRestClient{}
+			resp := tt.handle(loaderPassthrough{
+				rest:            restClient,
+				instrumentation: inst,
+				contextErrHandler: func(error, *ErrorInfo) *objects.InteractionResponse {
+					return errResp
+				},
+			}, tt.interaction)
+
+			events := inst.get()
+			info := &DispatchInfo{Interaction: tt.interaction, Route: tt.expectsRoute, Kind: tt.expectsKind}
+			if tt.rest {
+				require.Len(t, events, 4)
+				restInfo := &RESTCallInfo{DispatchInfo: *info, Method: "GetUser"}
+				assert.Equal(t, "RESTCallStart", events[1].hook)
+				assert.Equal(t, restInfo, events[1].info)
+				assert.Equal(t, "dispatch", events[1].ctx.Value(instrumentationCtxKey{}))
+				assert.Equal(t, "rest", restClient.ctx.Value(instrumentationCtxKey{}))
+				assert.Equal(t, "RESTCallEnd", events[2].hook)
+				assert.Equal(t, restInfo, events[2].info)
+				assert.Equal(t, &RESTCallResult{Duration: time.Second, Err: errEditFailed}, events[2].result)
+			} else {
+				require.Len(t, events, 2)
+			}
+
+			start, end := events[0], events[len(events)-1]
+			assert.Equal(t, "DispatchStart", start.hook)
+			assert.Equal(t, info, start.info)
+			assert.Equal(t, "DispatchEnd", end.hook)
+			assert.Equal(t, info, end.info)
+			assert.Equal(t, "dispatch", end.ctx.Value(instrumentationCtxKey{}))
+			result := end.result.(*DispatchResult)
+			assert.Positive(t, result.Duration)
+			assert.Equal(t, tt.expectsResponseType, result.ResponseType)
+			assert.Same(t, resp, result.Response)
+			switch x := tt.expectsErr.(type) {
+			case nil:
+				assert.NoError(t, result.Err)
+			case *PanicError:
+				assert.ErrorAs(t, result.Err, &x)
+			default:
+				assert.ErrorIs(t, result.Err, x)
+			}
+		})
+	}
+}
+
+func TestInstrumentation_notFound(t *testing.T) {
+	inst := &recordingInstrumentation{}
+	(&ComponentRouter{}).build(nil, loaderPassthrough{instrumentation: inst})(
+		context.Background(), mockInteraction(&objects.ApplicationComponentInteractionData{CustomID: "/a"}))
+	assert.Empty(t, inst.get())
+}
+
+func Test_loaderPassthrough_startDispatch(t *testing.T) {
+	t.Run("no instrumentation", func(t *testing.T) {
+		ctx := context.Background()
+		reqCtx, r, done := loaderPassthrough{}.startDispatch(ctx, dummyRestClient, &errorScope{})
+		assert.Equal(t, ctx, reqCtx)
+		assert.Same(t, dummyRestClient, r)
+		done(nil)
+	})
+
+	t.Run("first error", func(t *testing.T) {
+		inst := &recordingInstrumentation{}
+		scope := newErrorScope(loaderPassthrough{
+			errHandler: func(error) *objects.InteractionResponse { return nil },
+		}, &objects.Interaction{}, InteractionKindCommand)
+		scope.info.Route = "a"
+		_, _, done := loaderPassthrough{instrumentation: inst}.startDispatch(nil, dummyRestClient, scope)
+		scope.handle(NotOwner)
+		scope.handle(errors.New("second"))
+		done(nil)
+		events := inst.get()
+		require.Len(t, events, 2)
+		assert.NotNil(t, events[0].ctx)
+		assert.Equal(t, NotOwner, events[1].result.(*DispatchResult).Err)
+		assert.Equal(t, objects.ResponseType(0), events[1].result.(*DispatchResult).ResponseType)
+	})
+}
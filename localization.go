@@ -0,0 +1,186 @@
+package router
+
+import "github.com/Postcord/objects"
+
+// Localizations maps a Discord locale (such as "fr" or "en-US") to a localized string.
+type Localizations map[string]string
+
+// OptionLocalizations is used to define the localizations for a command option.
+type OptionLocalizations struct {
+	// Name defines the localizations for the option name.
+	Name Localizations `json:"name,omitempty"`
+
+	// Description defines the localizations for the option description.
+	Description Localizations `json:"description,omitempty"`
+
+	// Choices maps the name of a static choice to the localizations for that name.
+	Choices map[string]Localizations `json:"choices,omitempty"`
+}
+
+// Gets the localizations for the option specified, creating them if they do not exist.
+func (c *Command) getOptionLocalizations(option string) *OptionLocalizations {
+	if c.OptionLocalizations == nil {
+		c.OptionLocalizations = map[string]*OptionLocalizations{}
+	}
+	l := c.OptionLocalizations[option]
+	if l == nil {
+		l = &OptionLocalizations{}
+		c.OptionLocalizations[option] = l
+	}
+	return l
+}
+
+// Used to localize a static choice.
+func (c *Command) localizeChoice(option, choice string, localizations Localizations) {
+	if len(localizations) == 0 {
+		return
+	}
+	l := c.getOptionLocalizations(option)
+	if l.Choices == nil {
+		l.Choices = map[string]Localizations{}
+	}
+	l.Choices[choice] = localizations
+}
+
+// LocalizationCatalog is used to bulk load localizations. It maps a locale to a map of keys to localized strings, which
+// means the strings for each locale can be loaded from their own JSON file. Keys are made up of the path to the command
+// or group joined with "." followed by the field being localized:
+//   - "<path>.name" and "<path>.description" for commands and groups.
+//   - "<path>.options.<option>.name" and "<path>.options.<option>.description" for options.
+//   - "<path>.options.<option>.choices.<choice name>" for static choices.
+type LocalizationCatalog map[string]map[string]string
+
+// Merges any strings with the key specified into the localizations given. If there are no localizations, nil is returned.
+func (l LocalizationCatalog) merge(existing Localizations, key string) Localizations {
+	for locale, strings := range l {
+		if s, ok := strings[key]; ok {
+			if existing == nil {
+				existing = Localizations{}
+			}
+			existing[locale] = s
+		}
+	}
+	return existing
+}
+
+// Applies the catalog to a command or group with the path specified.
+func (l LocalizationCatalog) apply(path string, cmdOrCat any) {
+	switch x := cmdOrCat.(type) {
+	case *CommandGroup:
+		x.NameLocalizations = l.merge(x.NameLocalizations, path+".name")
+		x.DescriptionLocalizations = l.merge(x.DescriptionLocalizations, path+".description")
+		for k, v := range x.Subcommands {
+			l.apply(path+"."+k, v)
+		}
+	case *Command:
+		x.NameLocalizations = l.merge(x.NameLocalizations, path+".name")
+		x.DescriptionLocalizations = l.merge(x.DescriptionLocalizations, path+".description")
+		for _, option := range x.Options {
+			optionPath := path + ".options." + option.Name
+			var existing OptionLocalizations
+			if ptr := x.OptionLocalizations[option.Name]; ptr != nil {
+				existing = *ptr
+			}
+			names := l.merge(existing.Name, optionPath+".name")
+			descriptions := l.merge(existing.Description, optionPath+".description")
+			if names != nil || descriptions != nil {
+				ol := x.getOptionLocalizations(option.Name)
+				ol.Name, ol.Description = names, descriptions
+			}
+			for _, choice := range option.Choices {
+				if choiceNames := l.merge(existing.Choices[choice.Name], optionPath+".choices."+choice.Name); choiceNames != nil {
+					x.localizeChoice(option.Name, choice.Name, choiceNames)
+				}
+			}
+		}
+	}
+}
+
+// LoadLocalizations is used to attach the localizations in the catalog to every command, group, option, and static
+// choice in the router. Strings for a locale which already has a localization are overwritten.
+func (c *CommandRouter) LoadLocalizations(catalog LocalizationCatalog) {
+	for k, v := range c.roots.Subcommands {
+		catalog.apply(k, v)
+	}
+}
+
+// DiscordCommandOptionChoice is the Discord representation of a choice including the fields which are not supported by
+// objects.ApplicationCommandOptionChoice.
+type DiscordCommandOptionChoice struct {
+	objects.ApplicationCommandOptionChoice
+
+	// NameLocalizations defines the localizations for the choice name.
+	NameLocalizations Localizations `json:"name_localizations,omitempty"`
+}
+
+// DiscordCommandOption is the Discord representation of an option including the fields which are not supported by
+// objects.ApplicationCommandOption. Choices and Options shadow the fields of the embedded option.
+type DiscordCommandOption struct {
+	objects.ApplicationCommandOption
+
+	// NameLocalizations defines the localizations for the option name.
+	NameLocalizations Localizations `json:"name_localizations,omitempty"`
+
+	// DescriptionLocalizations defines the localizations for the option description.
+	DescriptionLocalizations Localizations `json:"description_localizations,omitempty"`
+
+	// Choices defines the static choices for the option.
+	Choices []DiscordCommandOptionChoice `json:"choices,omitempty"`
+
+	// MinLength defines the minimum length of a string option.
+	MinLength *int `json:"min_length,omitempty"`
+
+	// MaxLength defines the maximum length of a string option.
+	MaxLength *int `json:"max_length,omitempty"`
+
+	// Options defines the options nested inside this sub-command or sub-command group.
+	Options []DiscordCommandOption `json:"options,omitempty"`
+}
+
+// Object is used to convert the option into the type used by Postcord. Any unsupported fields are dropped.
+func (o DiscordCommandOption) Object() objects.ApplicationCommandOption {
+	x := o.ApplicationCommandOption
+	x.Choices = nil
+	if o.Choices != nil {
+		x.Choices = make([]objects.ApplicationCommandOptionChoice, len(o.Choices))
+		for i, v := range o.Choices {
+			x.Choices[i] = v.ApplicationCommandOptionChoice
+		}
+	}
+	x.Options = objectifyOptions(o.Options)
+	return x
+}
+
+// Converts a slice of options into the type used by Postcord.
+func objectifyOptions(options []DiscordCommandOption) []objects.ApplicationCommandOption {
+	if options == nil {
+		return nil
+	}
+	x := make([]objects.ApplicationCommandOption, len(options))
+	for i, v := range options {
+		x[i] = v.Object()
+	}
+	return x
+}
+
+// DiscordCommand is the Discord representation of a command including the fields which are not supported by
+// objects.ApplicationCommand. Options shadows the field of the embedded command.
+type DiscordCommand struct {
+	objects.ApplicationCommand
+
+	// NameLocalizations defines the localizations for the command name.
+	NameLocalizations Localizations `json:"name_localizations,omitempty"`
+
+	// DescriptionLocalizations defines the localizations for the command description.
+	DescriptionLocalizations Localizations `json:"description_localizations,omitempty"`
+
+	// Options defines the options for the command.
+	Options []DiscordCommandOption `json:"options"`
+}
+
+// Object is used to convert the command into the type used by Postcord. Any unsupported fields are dropped.
+func (c *DiscordCommand) Object() *objects.ApplicationCommand {
+	x := c.ApplicationCommand
+	x.Options = objectifyOptions(c.Options)
+	return &x
+}
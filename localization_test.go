@@ -0,0 +1,139 @@
+package router
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func localizedTestRouter() *CommandRouter {
+	r := &CommandRouter{}
+	r.NewCommandBuilder("hello").Description("Say hello").
+		NameLocalizations(Localizations{"fr": "bonjour"}).
+		DescriptionLocalizations(Localizations{"fr": "Dire bonjour"}).
+		StringOption("language", "The language", true, StringStaticChoicesBuilder([]StringChoice{
+			{Name: "English", Value: "en", NameLocalizations: Localizations{"fr": "Anglais"}},
+			{Name: "French", Value: "fr"},
+		})).
+		OptionLocalizations("language", Localizations{"fr": "langue"}, Localizations{"fr": "La langue"}).
+		MustBuild()
+	useInDMs := true
+	g := r.MustNewCommandGroup("group", "A group", &CommandGroupOptions{
+		UseInDMs:          &useInDMs,
+		NameLocalizations: Localizations{"fr": "groupe"},
+	})
+	g.NewCommandBuilder("sub").Description("A sub-command").
+		NameLocalizations(Localizations{"fr": "sous"}).
+		BoolOption("flag", "A flag", false).
+		MustBuild()
+	r.NewCommandBuilder("message").MessageCommand().NameLocalizations(Localizations{"fr": "message"}).MustBuild()
+	return r
+}
+
+func findDiscordCommand(cmds []*DiscordCommand, name string) *DiscordCommand {
+	for _, v := range cmds {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+func TestCommandRouter_FormulateExtendedDiscordCommands(t *testing.T) {
+	cmds := localizedTestRouter().FormulateExtendedDiscordCommands()
+	require.Len(t, cmds, 3)
+
+	// Check the root command.
+	hello := findDiscordCommand(cmds, "hello")
+	require.NotNil(t, hello)
+	assert.Equal(t, Localizations{"fr": "bonjour"}, hello.NameLocalizations)
+	assert.Equal(t, Localizations{"fr": "Dire bonjour"}, hello.DescriptionLocalizations)
+	require.Len(t, hello.Options, 1)
+	assert.Equal(t, Localizations{"fr": "langue"}, hello.Options[0].NameLocalizations)
+	assert.Equal(t, Localizations{"fr": "La langue"}, hello.Options[0].DescriptionLocalizations)
+	require.Len(t, hello.Options[0].Choices, 2)
+	assert.Equal(t, Localizations{"fr": "Anglais"}, hello.Options[0].Choices[0].NameLocalizations)
+	assert.Nil(t, hello.Options[0].Choices[1].NameLocalizations)
+
+	// Check the group.
+	group := findDiscordCommand(cmds, "group")
+	require.NotNil(t, group)
+	assert.Equal(t, Localizations{"fr": "groupe"}, group.NameLocalizations)
+	require.Len(t, group.Options, 1)
+	assert.Equal(t, Localizations{"fr": "sous"}, group.Options[0].NameLocalizations)
+	require.Len(t, group.Options[0].Options, 1)
+	assert.Equal(t, "flag", group.Options[0].Options[0].Name)
+
+	// Check the message command.
+	message := findDiscordCommand(cmds, "message")
+	require.NotNil(t, message)
+	assert.Equal(t, Localizations{"fr": "message"}, message.NameLocalizations)
+
+	// Check the JSON is in the format Discord expects.
+	b, err := json.Marshal(hello)
+	require.NoError(t, err)
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(b, &m))
+	assert.Equal(t, map[string]any{"fr": "bonjour"}, m["name_localizations"])
+	option := m["options"].([]any)[0].(map[string]any)
+	assert.Equal(t, map[string]any{"fr": "langue"}, option["name_localizations"])
+	choice := option["choices"].([]any)[0].(map[string]any)
+	assert.Equal(t, map[string]any{"fr": "Anglais"}, choice["name_localizations"])
+	assert.Equal(t, "en", choice["value"])
+}
+
+func TestDiscordCommand_Object(t *testing.T) {
+	cmd := findDiscordCommand(localizedTestRouter().FormulateExtendedDiscordCommands(), "hello")
+	require.NotNil(t, cmd)
+	obj := cmd.Object()
+	assert.Equal(t, "hello", obj.Name)
+	require.Len(t, obj.Options, 1)
+	assert.Equal(t, []objects.ApplicationCommandOptionChoice{
+		{Name: "English", Value: "en"},
+		{Name: "French", Value: "fr"},
+	}, obj.Options[0].Choices)
+	assert.Nil(t, obj.Options[0].Options)
+}
+
+func TestCommandRouter_LoadLocalizations(t *testing.T) {
+	r := localizedTestRouter()
+	r.LoadLocalizations(LocalizationCatalog{
+		"de": {
+			"hello.name":                            "hallo",
+			"hello.description":                     "Hallo sagen",
+			"hello.options.language.name":           "sprache",
+			"hello.options.language.choices.French": "Französisch",
+			"group.description":                     "Eine Gruppe",
+			"group.sub.options.flag.description":    "Eine Flagge",
+			"message.name":                          "nachricht",
+			"unknown.name":                          "unbekannt",
+		},
+		"fr": {
+			"hello.name": "salut",
+		},
+	})
+	cmds := r.FormulateExtendedDiscordCommands()
+
+	hello := findDiscordCommand(cmds, "hello")
+	require.NotNil(t, hello)
+	assert.Equal(t, Localizations{"fr": "salut", "de": "hallo"}, hello.NameLocalizations)
+	assert.Equal(t, Localizations{"fr": "Dire bonjour", "de": "Hallo sagen"}, hello.DescriptionLocalizations)
+	assert.Equal(t, Localizations{"fr": "langue", "de": "sprache"}, hello.Options[0].NameLocalizations)
+	assert.Equal(t, Localizations{"fr": "La langue"}, hello.Options[0].DescriptionLocalizations)
+	assert.Equal(t, Localizations{"fr": "Anglais"}, hello.Options[0].Choices[0].NameLocalizations)
+	assert.Equal(t, Localizations{"de": "Französisch"}, hello.Options[0].Choices[1].NameLocalizations)
+
+	group := findDiscordCommand(cmds, "group")
+	require.NotNil(t, group)
+	assert.Equal(t, Localizations{"fr": "groupe"}, group.NameLocalizations)
+	assert.Equal(t, Localizations{"de": "Eine Gruppe"}, group.DescriptionLocalizations)
+	assert.Equal(t, Localizations{"de": "Eine Flagge"}, group.Options[0].Options[0].DescriptionLocalizations)
+	assert.Nil(t, group.Options[0].Options[0].NameLocalizations)
+
+	message := findDiscordCommand(cmds, "message")
+	require.NotNil(t, message)
+	assert.Equal(t, Localizations{"fr": "message", "de": "nachricht"}, message.NameLocalizations)
+}
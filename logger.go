@@ -0,0 +1,91 @@
+package router
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Postcord/objects"
+)
+
+// Logger is used to define a structured logger. The arguments after the message are key-value pairs. The methods are
+// the same as *slog.Logger, so one can be used directly.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// Defines the logger used when one is not set. This only logs warnings and errors using the standard logger.
+type defaultLogger struct{}
+
+// Writes the log line.
+func (defaultLogger) write(level, msg string, args []any) {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteByte(' ')
+	b.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			fmt.Fprintf(&b, " %v", args[i])
+			break
+		}
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+	log.Print(b.String())
+}
+
+// Debug implements the Logger interface.
+func (defaultLogger) Debug(string, ...any) {}
+
+// Info implements the Logger interface.
+func (defaultLogger) Info(string, ...any) {}
+
+// Warn implements the Logger interface.
+func (l defaultLogger) Warn(msg string, args ...any) {
+	l.write("WARN", msg, args)
+}
+
+// Error implements the Logger interface.
+func (l defaultLogger) Error(msg string, args ...any) {
+	l.write("ERROR", msg, args)
+}
+
+// Gets the attributes to log for an interaction.
+func logAttrs(kind InteractionKind, route string, interaction *objects.Interaction) []any {
+	attrs := []any{"kind", kind.String()}
+	if route != "" {
+		attrs = append(attrs, "route", route)
+	}
+	if interaction != nil {
+		attrs = append(attrs,
+			"interaction_id", interaction.ID,
+			"guild_id", interaction.GuildID,
+			"user_id", interactionUserID(interaction))
+	}
+	return attrs
+}
+
+// Defines the logger used when the router is not built by the loader. This discards everything.
+type nopLogger struct{}
+
+// Gets the logger specified, or a logger which discards everything if it is nil.
+func loggerOrNop(l Logger) Logger {
+	if l == nil {
+		return nopLogger{}
+	}
+	return l
+}
+
+// Debug implements the Logger interface.
+func (nopLogger) Debug(string, ...any) {}
+
+// Info implements the Logger interface.
+func (nopLogger) Info(string, ...any) {}
+
+// Warn implements the Logger interface.
+func (nopLogger) Warn(string, ...any) {}
+
+// Error implements the Logger interface.
+func (nopLogger) Error(string, ...any) {}
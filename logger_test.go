@@ -0,0 +1,234 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Postcord/objects"
+	"github.com/Postcord/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type logEntry struct {
+	level string
+	msg   string
+	args  []any
+}
+
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordingLogger) add(level, msg string, args []any) {
+	l.mu.Lock()
+	l.entries = append(l.entries, logEntry{level, msg, args})
+	l.mu.Unlock()
+}
+
+func (l *recordingLogger) get() []logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]logEntry(nil), l.entries...)
+}
+
+func (l *recordingLogger) Debug(msg string, args ...any) { l.add("debug", msg, args) }
+
+func (l *recordingLogger) Info(msg string, args ...any) { l.add("info", msg, args) }
+
+func (l *recordingLogger) Warn(msg string, args ...any) { l.add("warn", msg, args) }
+
+func (l *recordingLogger) Error(msg string, args ...any) { l.add("error", msg, args) }
+
+func Test_defaultLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log.SetOutput(buf)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	}()
+
+	l := defaultLogger{}
+	l.Debug("debug", "a", 1)
+	l.Info("info", "a", 1)
+	assert.Empty(t, buf.String())
+	l.Warn("hello", "a", 1, "b", "c")
+	l.Error("world", "a", 1, "odd")
+	assert.Equal(t, "WARN hello a=1 b=c\nERROR world a=1 odd\n", buf.String())
+}
+
+func Test_logAttrs(t *testing.T) {
+	interaction := &objects.Interaction{
+		DiscordBaseObject: objects.DiscordBaseObject{ID: 1},
+		GuildID:           2,
+		Member:            &objects.GuildMember{User: &objects.User{DiscordBaseObject: objects.DiscordBaseObject{ID: 3}}},
+	}
+	assert.Equal(t, []any{
+		"kind", "command", "route", "a b",
+		"interaction_id", objects.Snowflake(1), "guild_id", objects.Snowflake(2), "user_id", objects.Snowflake(3),
+	}, logAttrs(InteractionKindCommand, "a b", interaction))
+	assert.Equal(t, []any{"kind", "modal"}, logAttrs(InteractionKindModal, "", nil))
+}
+
+func Test_loggerOrNop(t *testing.T) {
+	assert.Equal(t, nopLogger{}, loggerOrNop(nil))
+	l := &recordingLogger{}
+	assert.Same(t, l, loggerOrNop(l))
+}
+
+func TestRouterLogging(t *testing.T) {
+	commandRouter := &CommandRouter{}
+	commandRouter.NewCommandBuilder("a").Handler(func(*CommandRouterCtx) error { return nil }).MustBuild()
+	componentRouter := &ComponentRouter{}
+	componentRouter.RegisterButton("/a", func(*ComponentRouterCtx) error { return nil })
+	modalRouter := &ModalRouter{}
+
+	tests := []struct {
+		name string
+
+		handle  func(loader loaderPassthrough) *objects.InteractionResponse
+		expects logEntry
+	}{
+		{
+			name: "command dispatched",
+			handle: func(loader loaderPassthrough) *objects.InteractionResponse {
+				h, _ := commandRouter.build(loader)
+				return h(context.Background(), mockInteraction(&objects.ApplicationCommandInteractionData{Name: "a"}))
+			},
+			expects: logEntry{"debug", "dispatching interaction", []any{
+				"kind", "command", "route", "a",
+				"interaction_id", objects.Snowflake(1234), "guild_id", objects.Snowflake(1234), "user_id", objects.Snowflake(123),
+			}},
+		},
+		{
+			name: "command not found",
+			handle: func(loader loaderPassthrough) *objects.InteractionResponse {
+				h, _ := commandRouter.build(loader)
+				return h(context.Background(), mockInteraction(&objects.ApplicationCommandInteractionData{Name: "b"}))
+			},
+			expects: logEntry{"warn", "command not found", []any{
+				"kind", "command",
+				"interaction_id", objects.Snowflake(1234), "guild_id", objects.Snowflake(1234), "user_id", objects.Snowflake(123),
+				"name", "b",
+			}},
+		},
+		{
+			name: "autocomplete command not found",
+			handle: func(loader loaderPassthrough) *objects.InteractionResponse {
+				_, h := commandRouter.build(loader)
+				return h(context.Background(), mockInteraction(&objects.ApplicationCommandInteractionData{Name: "b"}))
+			},
+			expects: logEntry{"warn", "command not found", []any{
+				"kind", "autocomplete",
+				"interaction_id", objects.Snowflake(1234), "guild_id", objects.Snowflake(1234), "user_id", objects.Snowflake(123),
+				"name", "b",
+			}},
+		},
+		{
+			name: "component dispatched",
+			handle: func(loader loaderPassthrough) *objects.InteractionResponse {
+				return componentRouter.build(nil, loader)(context.Background(), mockInteraction(&objects.ApplicationComponentInteractionData{
+					CustomID:      "/a",
+					ComponentType: objects.ComponentTypeButton,
+				}))
+			},
+			expects: logEntry{"debug", "dispatching interaction", []any{
+				"kind", "component", "route", "/a",
+				"interaction_id", objects.Snowflake(1234), "guild_id", objects.Snowflake(1234), "user_id", objects.Snowflake(123),
+			}},
+		},
+		{
+			name: "component not found",
+			handle: func(loader loaderPassthrough) *objects.InteractionResponse {
+				return componentRouter.build(nil, loader)(context.Background(), mockInteraction(&objects.ApplicationComponentInteractionData{
+					CustomID: "/b",
+				}))
+			},
+			expects: logEntry{"warn", "component route not found", []any{
+				"kind", "component",
+				"interaction_id", objects.Snowflake(1234), "guild_id", objects.Snowflake(1234), "user_id", objects.Snowflake(123),
+				"custom_id", "/b",
+			}},
+		},
+		{
+			name: "modal not found",
+			handle: func(loader loaderPassthrough) *objects.InteractionResponse {
+				return modalRouter.build(loader)(context.Background(), mockInteraction(&objects.ApplicationModalInteractionData{
+					CustomID: "/b",
+				}))
+			},
+			expects: logEntry{"warn", "modal route not found", []any{
+				"kind", "modal",
+				"interaction_id", objects.Snowflake(1234), "guild_id", objects.Snowflake(1234), "user_id", objects.Snowflake(123),
+				"custom_id", "/b",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &recordingLogger{}
+			tt.handle(loaderPassthrough{
+				rest:              dummyRestClient,
+				logger:            l,
+				contextErrHandler: func(error, *ErrorInfo) *objects.InteractionResponse { return nil },
+			})
+			assert.Equal(t, []logEntry{tt.expects}, l.get())
+		})
+	}
+}
+
+func Test_loaderPassthrough_writeFrame(t *testing.T) {
+	// Make a file where the folder should be so the folder cannot be created.
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(path, nil, 0666))
+
+	l := &recordingLogger{}
+	loaderPassthrough{logger: l}.writeFrame(&frame{}, path, "a")
+	require.Eventually(t, func() bool { return len(l.get()) == 1 }, time.Second, time.Millisecond)
+	entry := l.get()[0]
+	assert.Equal(t, "error", entry.level)
+	assert.Equal(t, "failed to write test frame", entry.msg)
+	assert.Equal(t, []any{"path", filepath.Join(path, "a")}, entry.args[:2])
+	assert.Error(t, entry.args[3].(error))
+}
+
+type failingEditRestClient struct {
+	rest.RESTClient
+}
+
+var errEditFailed = errors.New("edit failed")
+
+func (failingEditRestClient) EditOriginalInteractionResponse(context.Context, objects.SnowflakeObject, string, *rest.EditWebhookMessageParams) (*objects.Message, error) {
+	return nil, errEditFailed
+}
+
+func TestUpdateLater_logsErrors(t *testing.T) {
+	l := &recordingLogger{}
+	interaction := &objects.Interaction{}
+	ctx := &ModalRouterCtx{
+		route:       "/a",
+		logger:      l,
+		Interaction: interaction,
+		RESTClient:  failingEditRestClient{},
+	}
+	ctx.UpdateLater(func(ctx *ModalRouterCtx) error {
+		ctx.SetContent("hello")
+		return nil
+	})
+	require.Eventually(t, func() bool { return len(l.get()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, logEntry{"error", "failed to update the response", []any{
+		"kind", "modal", "route", "/a",
+		"interaction_id", objects.Snowflake(0), "guild_id", objects.Snowflake(0), "user_id", objects.Snowflake(0),
+		"error", errEditFailed,
+	}}, l.get()[0])
+}
@@ -2,7 +2,12 @@ package router
 
 import (
 	"container/list"
+	"context"
 	"errors"
+	"strings"
+
+	"github.com/Postcord/objects"
+	"github.com/Postcord/rest"
 )
 
 // MiddlewareCtx is used to define the additional context that is shared between middleware.
@@ -29,3 +34,166 @@ func (m MiddlewareCtx) Next() error {
 
 // MiddlewareFunc is used to define a middleware function.
 type MiddlewareFunc func(ctx MiddlewareCtx) error
+
+// InteractionCtx is implemented by the contexts of all of the routers. This allows middleware to be shared between
+// commands, auto-complete, components, and modals.
+type InteractionCtx interface {
+	// GetInteraction is used to get the interaction which started this.
+	GetInteraction() *objects.Interaction
+
+	// GetContext is used to get the context.Context passed from the HTTP handler.
+	GetContext() context.Context
+
+	// GetRESTClient is used to get the REST client.
+	GetRESTClient() rest.RESTClient
+}
+
+// GetInteraction implements the InteractionCtx interface.
+func (c *CommandRouterCtx) GetInteraction() *objects.Interaction { return c.Interaction }
+
+// GetContext implements the InteractionCtx interface.
+func (c *CommandRouterCtx) GetContext() context.Context { return c.Context }
+
+// GetRESTClient implements the InteractionCtx interface.
+func (c *CommandRouterCtx) GetRESTClient() rest.RESTClient { return c.RESTClient }
+
+// GetInteraction implements the InteractionCtx interface.
+func (c *ComponentRouterCtx) GetInteraction() *objects.Interaction { return c.Interaction }
+
+// GetContext implements the InteractionCtx interface.
+func (c *ComponentRouterCtx) GetContext() context.Context { return c.Context }
+
+// GetRESTClient implements the InteractionCtx interface.
+func (c *ComponentRouterCtx) GetRESTClient() rest.RESTClient { return c.RESTClient }
+
+// GetInteraction implements the InteractionCtx interface.
+func (c *ModalRouterCtx) GetInteraction() *objects.Interaction { return c.Interaction }
+
+// GetContext implements the InteractionCtx interface.
+func (c *ModalRouterCtx) GetContext() context.Context { return c.Context }
+
+// GetRESTClient implements the InteractionCtx interface.
+func (c *ModalRouterCtx) GetRESTClient() rest.RESTClient { return c.RESTClient }
+
+// InteractionMiddlewareCtx is used to define the context for middleware which can be used with any router. The
+// underlying context can be type asserted to get the router specific context, and the interaction type can be used to
+// tell commands and auto-complete apart.
+type InteractionMiddlewareCtx struct {
+	// Defines the context of the router.
+	InteractionCtx
+
+	// Defines the function to call the next item in the chain.
+	next func() error
+}
+
+// Next is used to call the next function in the middleware chain.
+func (m InteractionMiddlewareCtx) Next() error {
+	return m.next()
+}
+
+// InteractionMiddlewareFunc is used to define a middleware function which can be used with any router. To use one with
+// the command router, wrap it with CommandMiddleware.
+type InteractionMiddlewareFunc func(ctx InteractionMiddlewareCtx) error
+
+// CommandMiddleware is used to wrap an InteractionMiddlewareFunc so it can be used with the command router and groups.
+func CommandMiddleware(f InteractionMiddlewareFunc) MiddlewareFunc {
+	return func(ctx MiddlewareCtx) error {
+		return f(InteractionMiddlewareCtx{InteractionCtx: ctx.CommandRouterCtx, next: ctx.Next})
+	}
+}
+
+// Runs the middleware chain followed by the handler.
+func runInteractionMiddleware(ctx InteractionCtx, middleware []InteractionMiddlewareFunc, handler func() error) error {
+	i := 0
+	var next func() error
+	next = func() error {
+		switch {
+		case i < len(middleware):
+			f := middleware[i]
+			i++
+			return f(InteractionMiddlewareCtx{InteractionCtx: ctx, next: next})
+		case i == len(middleware):
+			i++
+			return handler()
+		default:
+			return MiddlewareChainExhausted
+		}
+	}
+	return next()
+}
+
+// Runs the command middleware chain followed by the handler.
+func runCommandMiddleware(ctx *CommandRouterCtx, middlewareList *list.List, handler func(*CommandRouterCtx) error) error {
+	if middlewareList.Len() == 0 {
+		return handler(ctx)
+	}
+
+	// Wrap the end function in a middleware function and push it.
+	var middlewareWrapper MiddlewareFunc = func(ctx MiddlewareCtx) error {
+		return handler(ctx.CommandRouterCtx)
+	}
+	middlewareList.PushBack(middlewareWrapper)
+
+	// Call the middleware chain.
+	return MiddlewareCtx{CommandRouterCtx: ctx, middlewareList: middlewareList}.Next()
+}
+
+// Defines middleware which is used for routes starting with the prefix.
+type prefixMiddleware struct {
+	prefix string
+	f      InteractionMiddlewareFunc
+}
+
+// Gets the middleware for the route. The router middleware is first, then the prefix middleware in the order it was
+// added, and then the middleware for the route.
+func routeMiddleware(route string, global []InteractionMiddlewareFunc, prefixes []prefixMiddleware, local []InteractionMiddlewareFunc) []InteractionMiddlewareFunc {
+	var middleware []InteractionMiddlewareFunc
+	middleware = append(middleware, global...)
+	for _, v := range prefixes {
+		if strings.HasPrefix(route, v.prefix) {
+			middleware = append(middleware, v.f)
+		}
+	}
+	return append(middleware, local...)
+}
+
+// AroundMiddlewareCtx is used to define the context for middleware which wraps the response. The underlying context
+// can be type asserted to get the router specific context.
+type AroundMiddlewareCtx struct {
+	// Defines the context of the router.
+	InteractionCtx
+
+	// Defines the function to call the next item in the chain.
+	next func() (*objects.InteractionResponse, error)
+}
+
+// Next is used to call the rest of the chain. This returns the response which was built, or the error returned by the
+// handler or middleware before it is passed to the error handler.
+func (m AroundMiddlewareCtx) Next() (*objects.InteractionResponse, error) {
+	return m.next()
+}
+
+// AroundMiddlewareFunc is used to define middleware which wraps the response. This is called before any other
+// middleware. The response and error returned replace the ones from Next, so the middleware can rewrite the response,
+// or turn an error into a response by returning a nil error. If an error is returned, it is passed to the error handler.
+type AroundMiddlewareFunc func(ctx AroundMiddlewareCtx) (*objects.InteractionResponse, error)
+
+// Runs the around middleware chain followed by the handler.
+func runAroundMiddleware(ctx InteractionCtx, middleware []AroundMiddlewareFunc, handler func() (*objects.InteractionResponse, error)) (*objects.InteractionResponse, error) {
+	i := 0
+	var next func() (*objects.InteractionResponse, error)
+	next = func() (*objects.InteractionResponse, error) {
+		switch {
+		case i < len(middleware):
+			f := middleware[i]
+			i++
+			return f(AroundMiddlewareCtx{InteractionCtx: ctx, next: next})
+		case i == len(middleware):
+			i++
+			return handler()
+		default:
+			return nil, MiddlewareChainExhausted
+		}
+	}
+	return next()
+}
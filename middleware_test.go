@@ -3,8 +3,11 @@ package router
 import (
 	"container/list"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/Postcord/objects"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -66,3 +69,205 @@ func TestMiddlewareCtx_Next(t *testing.T) {
 		})
 	}
 }
+
+// Appends to the content of the response before calling the next function.
+func appendContentMiddleware(s string) InteractionMiddlewareFunc {
+	return func(ctx InteractionMiddlewareCtx) error {
+		ctx.InteractionCtx.(ResponseDataBuilder).ResponseData().Content += s
+		return ctx.Next()
+	}
+}
+
+func Test_runInteractionMiddleware(t *testing.T) {
+	tests := []struct {
+		name string
+
+		middleware []InteractionMiddlewareFunc
+		handlerErr error
+
+		expectedContent string
+		expectedErr     string
+	}{
+		{
+			name:            "no middleware",
+			expectedContent: "handler",
+		},
+		{
+			name:            "middleware order",
+			middleware:      []InteractionMiddlewareFunc{appendContentMiddleware("a"), appendContentMiddleware("b")},
+			expectedContent: "abhandler",
+		},
+		{
+			name: "middleware error",
+			middleware: []InteractionMiddlewareFunc{
+				appendContentMiddleware("a"),
+				func(ctx InteractionMiddlewareCtx) error {
+					return errors.New("blocked")
+				},
+			},
+			expectedContent: "a",
+			expectedErr:     "blocked",
+		},
+		{
+			name:            "handler error",
+			middleware:      []InteractionMiddlewareFunc{appendContentMiddleware("a")},
+			handlerErr:      errors.New("handler failed"),
+			expectedContent: "ahandler",
+			expectedErr:     "handler failed",
+		},
+		{
+			name: "middleware exhausted",
+			middleware: []InteractionMiddlewareFunc{
+				func(ctx InteractionMiddlewareCtx) error {
+					if err := ctx.Next(); err != nil {
+						return err
+					}
+					return ctx.Next()
+				},
+			},
+			expectedContent: "handler",
+			expectedErr:     "the middleware chain has been exhausted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &ComponentRouterCtx{}
+			err := runInteractionMiddleware(ctx, tt.middleware, func() error {
+				ctx.ResponseData().Content += "handler"
+				return tt.handlerErr
+			})
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+			assert.Equal(t, tt.expectedContent, ctx.ResponseData().Content)
+		})
+	}
+}
+
+func TestCommandMiddleware(t *testing.T) {
+	ctx := &CommandRouterCtx{}
+	l := list.New()
+	l.PushBack(CommandMiddleware(appendContentMiddleware("a")))
+	l.PushBack(MiddlewareFunc(func(ctx MiddlewareCtx) error {
+		ctx.SetContent(ctx.ResponseData().Content + "b")
+		return nil
+	}))
+	assert.NoError(t, MiddlewareCtx{CommandRouterCtx: ctx, middlewareList: l}.Next())
+	assert.Equal(t, "ab", ctx.ResponseData().Content)
+}
+
+func Test_routeMiddleware(t *testing.T) {
+	var calls []string
+	mw := func(s string) InteractionMiddlewareFunc {
+		return func(ctx InteractionMiddlewareCtx) error {
+			calls = append(calls, s)
+			return nil
+		}
+	}
+	middleware := routeMiddleware("/a/b",
+		[]InteractionMiddlewareFunc{mw("global")},
+		[]prefixMiddleware{{"/a/", mw("a")}, {"/b/", mw("b")}, {"/a/b", mw("ab")}},
+		[]InteractionMiddlewareFunc{mw("local")})
+	for _, f := range middleware {
+		_ = f(InteractionMiddlewareCtx{})
+	}
+	assert.Equal(t, []string{"global", "a", "ab", "local"}, calls)
+}
+
+func Test_runAroundMiddleware(t *testing.T) {
+	handlerResp := &objects.InteractionResponse{Type: objects.ResponseChannelMessageWithSource}
+	replacedResp := &objects.InteractionResponse{Type: objects.ResponseDeferredChannelMessageWithSource}
+	tests := []struct {
+		name string
+
+		middleware []AroundMiddlewareFunc
+		handlerErr error
+
+		expectedResp *objects.InteractionResponse
+		expectedErr  string
+	}{
+		{
+			name:         "no middleware",
+			expectedResp: handlerResp,
+		},
+		{
+			name: "middleware sees response",
+			middleware: []AroundMiddlewareFunc{
+				func(ctx AroundMiddlewareCtx) (*objects.InteractionResponse, error) {
+					resp, err := ctx.Next()
+					if resp != handlerResp {
+						return nil, errors.New("wrong response")
+					}
+					return resp, err
+				},
+			},
+			expectedResp: handlerResp,
+		},
+		{
+			name: "middleware replaces response",
+			middleware: []AroundMiddlewareFunc{
+				func(ctx AroundMiddlewareCtx) (*objects.InteractionResponse, error) {
+					_, err := ctx.Next()
+					return replacedResp, err
+				},
+			},
+			expectedResp: replacedResp,
+		},
+		{
+			name: "middleware handles error",
+			middleware: []AroundMiddlewareFunc{
+				func(ctx AroundMiddlewareCtx) (*objects.InteractionResponse, error) {
+					if _, err := ctx.Next(); err.Error() == "handler failed" {
+						return replacedResp, nil
+					}
+					return nil, errors.New("wrong error")
+				},
+			},
+			handlerErr:   errors.New("handler failed"),
+			expectedResp: replacedResp,
+		},
+		{
+			name: "middleware order",
+			middleware: []AroundMiddlewareFunc{
+				func(ctx AroundMiddlewareCtx) (*objects.InteractionResponse, error) {
+					_, err := ctx.Next()
+					return nil, fmt.Errorf("first: %w", err)
+				},
+				func(ctx AroundMiddlewareCtx) (*objects.InteractionResponse, error) {
+					_, err := ctx.Next()
+					return nil, fmt.Errorf("second: %w", err)
+				},
+			},
+			handlerErr:  errors.New("handler failed"),
+			expectedErr: "first: second: handler failed",
+		},
+		{
+			name: "middleware exhausted",
+			middleware: []AroundMiddlewareFunc{
+				func(ctx AroundMiddlewareCtx) (*objects.InteractionResponse, error) {
+					_, _ = ctx.Next()
+					return ctx.Next()
+				},
+			},
+			expectedErr: "the middleware chain has been exhausted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := runAroundMiddleware(&ComponentRouterCtx{}, tt.middleware, func() (*objects.InteractionResponse, error) {
+				if tt.handlerErr != nil {
+					return nil, tt.handlerErr
+				}
+				return handlerResp, nil
+			})
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+			assert.Same(t, tt.expectedResp, resp)
+		})
+	}
+}
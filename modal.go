@@ -35,6 +35,12 @@ type ModalRouterCtx struct {
 	// Defines the void ID generator.
 	voidGenerator
 
+	// Defines the route which is being handled. This is used when recovering from a panic.
+	route string
+
+	// Defines the logger used for errors when updating the response later.
+	logger Logger
+
 	// Context is a context.Context passed from the HTTP handler.
 	Context context.Context
 
@@ -89,12 +95,34 @@ type ModalContent struct {
 
 	// Function is the function that will be called when the modal is executed.
 	Function func(*ModalRouterCtx) error `json:"-"`
+
+	// Middleware is used to define middleware which is only used for this modal. This is called after the router and
+	// prefix middleware.
+	Middleware []InteractionMiddlewareFunc `json:"-"`
+
+	// ErrorHandler is used to set the error handler for this modal. If this is not nil, it overrides the error handler
+	// of the router.
+	ErrorHandler ContextErrorHandler `json:"-"`
 }
 
 // ModalRouter is used to route modals.
 type ModalRouter struct {
 	routes map[string]*ModalContent
 	tree   *node
+
+	// Defines the middleware for the router and prefixes.
+	middleware       []InteractionMiddlewareFunc
+	prefixMiddleware []prefixMiddleware
+	aroundMiddleware []AroundMiddlewareFunc
+
+	// Defines the error handler for the router.
+	errorHandler ContextErrorHandler
+
+	// Defines the handler used when a custom ID does not match a modal.
+	notFoundHandler NotFoundHandler
+
+	// Defines the signer used to sign and verify custom IDs.
+	signer *CustomIDSigner
 }
 
 // ResponseDataBuilder is used to
@@ -115,6 +143,42 @@ func (f *ModalRouter) AddModal(modal *ModalContent) {
 	f.routes[modal.Path] = modal
 }
 
+// Use is used to add middleware which is used for all modals in the router.
+func (f *ModalRouter) Use(mw InteractionMiddlewareFunc) {
+	f.middleware = append(f.middleware, mw)
+}
+
+// UseAround is used to add middleware which wraps the response for all modals in the router. This is called before any
+// other middleware.
+func (f *ModalRouter) UseAround(mw AroundMiddlewareFunc) {
+	f.aroundMiddleware = append(f.aroundMiddleware, mw)
+}
+
+// SetErrorHandler is used to set the error handler for the router. This overrides the error handler of the loader,
+// and is overridden by the error handler of a modal.
+func (f *ModalRouter) SetErrorHandler(h ContextErrorHandler) {
+	f.errorHandler = h
+}
+
+// SetNotFoundHandler is used to set the handler used when a custom ID does not match a modal. This overrides the not
+// found handler of the loader. If neither is set, ModalPathNotFound is passed to the error handler.
+func (f *ModalRouter) SetNotFoundHandler(h NotFoundHandler) {
+	f.notFoundHandler = h
+}
+
+// SetSigner is used to turn on signed custom IDs. When this is set, the custom IDs of the modals sent by
+// SendModalResponse are signed for the user who opened them, and if a submitted custom ID is not signed by the signer,
+// the error from verifying it is passed to the error handler as a router error.
+func (f *ModalRouter) SetSigner(s *CustomIDSigner) {
+	f.signer = s
+}
+
+// UsePrefix is used to add middleware which is used for modals with a path starting with the prefix specified. This is
+// checked against the path which was added rather than the custom ID.
+func (f *ModalRouter) UsePrefix(prefix string, mw InteractionMiddlewareFunc) {
+	f.prefixMiddleware = append(f.prefixMiddleware, prefixMiddleware{prefix: prefix, f: mw})
+}
+
 // ModalPathNotFound is thrown when the modal path is not found.
 var ModalPathNotFound = errors.New("modal path not found")
 
@@ -123,7 +187,7 @@ func (f *ModalRouter) build(loader loaderPassthrough) interactions.HandlerFunc {
 	// Build the tree.
 	tree := node{}
 	for route, form := range f.routes {
-		tree.addRoute(route, &routeContext{
+		tree.addTypedRoute(route, &routeContext{
 			i: form,
 			r: route,
 		})
@@ -132,22 +196,49 @@ func (f *ModalRouter) build(loader loaderPassthrough) interactions.HandlerFunc {
 
 	// Return the handler.
 	return func(reqCtx context.Context, ctx *objects.Interaction) (resp *objects.InteractionResponse) {
+		// Create the error scope.
+		scope := newErrorScope(loader, ctx, InteractionKindModal)
+		scope.handler = f.errorHandler
+		routerErrHandler := wrapRouterErrors(scope.handle, &scope.info)
+
 		// Get the value from the tree.
 		var data objects.ApplicationModalInteractionData
 		if err := json.Unmarshal(ctx.Data, &data); err != nil {
-			return loader.errHandler(err)
+			return routerErrHandler(err)
+		}
+		customID, _, err := verifyCustomID(f.signer, false, data.CustomID, ctx)
+		if err != nil {
+			loader.log().Warn("invalid custom ID", append(logAttrs(InteractionKindModal, "", ctx), "custom_id", data.CustomID, "error", err)...)
+			return routerErrHandler(err)
 		}
 		params := map[string]string{}
-		val := f.tree.getValue(data.CustomID, params)
+		val, paramErr := f.tree.match(customID, params)
 		if val == nil {
-			return loader.errHandler(ModalPathNotFound)
+			loader.log().Warn("modal route not found", append(logAttrs(InteractionKindModal, "", ctx), "custom_id", data.CustomID)...)
+			if notFoundResp, ok := loader.notFound(f.notFoundHandler, &NotFoundInfo{
+				Interaction: ctx,
+				Kind:        InteractionKindModal,
+				ID:          customID,
+			}); ok {
+				return notFoundResp
+			}
+			return routerErrHandler(ModalPathNotFound)
+		}
+		scope.info.Route = val.r
+		loader.log().Debug("dispatching interaction", logAttrs(InteractionKindModal, val.r, ctx)...)
+		if h := val.i.(*ModalContent).ErrorHandler; h != nil {
+			scope.handler = h
+		}
+		if paramErr != nil {
+			loader.log().Warn("invalid route parameter", append(logAttrs(InteractionKindModal, val.r, ctx), "error", paramErr)...)
+			return routerErrHandler(paramErr)
 		}
 
 		// Create the rest tape if this is wanted.
 		r := loader.rest
 		tape := tape{}
 		var returnedErr string
-		errHandler := loader.errHandler
+		errHandler := scope.handle
 		if loader.generateFrames {
 			r = &restTape{
 				tape: &tape,
@@ -155,24 +246,27 @@ func (f *ModalRouter) build(loader loaderPassthrough) interactions.HandlerFunc {
 			}
 			errHandler = func(err error) *objects.InteractionResponse {
 				returnedErr = err.Error()
-				return loader.errHandler(err)
+				return scope.handle(err)
 			}
 		}
 
-		// Handle if the function panics.
-		defer func() {
-			if errGeneric := recover(); errGeneric != nil {
-				// Shouldn't try and return from defer.
-				loader.errHandler(ungenericError(errGeneric))
-			}
-		}()
+		// Instrument the dispatch. This is deferred first so it runs after the panic is recovered.
+		reqCtx, r, done := loader.startDispatch(reqCtx, r, scope)
+		defer func() { done(resp) }()
 
 		// Handle test frames.
 		defer func() {
 			if loader.generateFrames {
 				// Now we have all the data, we can generate the frame.
 				fr := frame{ctx, tape, returnedErr, resp}
-				go fr.write("testframes", "modals", strings.ReplaceAll(val.r, "/", "_"))
+				loader.writeFrame(&fr, "testframes", "modals", strings.ReplaceAll(routePath(val.r), "/", "_"))
+			}
+		}()
+
+		// Handle if the function panics. This is deferred after the test frames so the frame has the response.
+		defer func() {
+			if errGeneric := recover(); errGeneric != nil {
+				resp = errHandler(newPanicError(errGeneric, val.r))
 			}
 		}()
 
@@ -189,19 +283,28 @@ func (f *ModalRouter) build(loader loaderPassthrough) interactions.HandlerFunc {
 			}
 		}
 		rctx := &ModalRouterCtx{
-			errorHandler:          loader.errHandler,
+			errorHandler:          scope.handle,
 			globalAllowedMentions: loader.globalAllowedMentions,
 			Interaction:           ctx,
 			Context:               reqCtx,
 			Params:                params,
 			ModalItems:            modalItems,
 			RESTClient:            r,
+			route:                 val.r,
+			logger:                loader.log(),
 		}
-		if err := val.i.(*ModalContent).Function(rctx); err != nil {
+		scope.builder = &rctx.responseBuilder
+		modal := val.i.(*ModalContent)
+		middleware := routeMiddleware(val.r, f.middleware, f.prefixMiddleware, modal.Middleware)
+		resp, err = runAroundMiddleware(rctx, f.aroundMiddleware, func() (*objects.InteractionResponse, error) {
+			if err := runInteractionMiddleware(rctx, middleware, func() error { return modal.Function(rctx) }); err != nil {
+				return nil, err
+			}
+			return rctx.buildResponse(false, wrapRouterErrors(errHandler, &scope.info), loader.globalAllowedMentions), nil
+		})
+		if err != nil {
 			resp = errHandler(err)
-			return
 		}
-		resp = rctx.buildResponse(false, loader.errHandler, loader.globalAllowedMentions)
 		return
 	}
 }
@@ -222,14 +325,18 @@ func uint2IntPtr(x uint) *int {
 
 // SendModalResponse is used to send the modal response with the given context.
 // The passed through context is expected to be one of a valid Postcord type.
-// The router will need to be built before you can use this function.
+// The router will need to be built before you can use this function. If a parameter in the path does not match its
+// type annotation, a *RouteParamError is returned.
 func (f *ModalRouter) SendModalResponse(ctx ResponseDataBuilder, path string) error {
 	// Get the value from the tree.
 	m := map[string]string{}
-	val := f.tree.getValue(path, m)
+	val, err := f.tree.match(path, m)
 	if val == nil {
 		return ModalPathNotFound
 	}
+	if err != nil {
+		return err
+	}
 
 	// Mark the response type as a modal.
 	var interaction *objects.Interaction
@@ -252,6 +359,9 @@ func (f *ModalRouter) SendModalResponse(ctx ResponseDataBuilder, path string) er
 	// Build the response.
 	data := ctx.ResponseData()
 	data.CustomID = path
+	if f.signer != nil {
+		data.CustomID = f.signer.Sign(path, &SignOptions{User: interactionUserID(interaction)})
+	}
 	formName, formContents := formContent.Contents(&ModalGenerationCtx{
 		Interaction: interaction,
 		Path:        path,
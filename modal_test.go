@@ -3,6 +3,7 @@ package router
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/Postcord/rest"
@@ -336,6 +337,60 @@ func TestModalRouter_build(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "middleware",
+			interaction: &objects.Interaction{
+				Data: jsonify(t, objects.ApplicationModalInteractionData{
+					CustomID: "/a/b",
+				}),
+			},
+			init: func(t *testing.T, r *ModalRouter) {
+				r.Use(appendContentMiddleware("a"))
+				r.UsePrefix("/a/", appendContentMiddleware("b"))
+				r.UsePrefix("/c/", appendContentMiddleware("x"))
+				r.AddModal(&ModalContent{
+					Path: "/a/b",
+					Contents: func(_ *ModalGenerationCtx) (string, []ModalContentItem) {
+						panic("this should not be called")
+					},
+					Function: func(ctx *ModalRouterCtx) error {
+						ctx.SetContent(ctx.ResponseData().Content + "d")
+						return nil
+					},
+					Middleware: []InteractionMiddlewareFunc{appendContentMiddleware("c")},
+				})
+			},
+			expects: &objects.InteractionResponse{
+				Type: objects.ResponseChannelMessageWithSource,
+				Data: &objects.InteractionApplicationCommandCallbackData{
+					Content: "abcd",
+				},
+			},
+		},
+		{
+			name: "around middleware error",
+			interaction: &objects.Interaction{
+				Data: jsonify(t, objects.ApplicationModalInteractionData{
+					CustomID: "/a",
+				}),
+			},
+			init: func(t *testing.T, r *ModalRouter) {
+				r.UseAround(func(ctx AroundMiddlewareCtx) (*objects.InteractionResponse, error) {
+					_, err := ctx.Next()
+					return nil, fmt.Errorf("wrapped: %w", err)
+				})
+				r.AddModal(&ModalContent{
+					Path: "/a",
+					Contents: func(_ *ModalGenerationCtx) (string, []ModalContentItem) {
+						panic("this should not be called")
+					},
+					Function: func(ctx *ModalRouterCtx) error {
+						return errors.New("wumpus fled the scene")
+					},
+				})
+			},
+			expectsErr: "wrapped: wumpus fled the scene",
+		},
 		{
 			name: "modal custom param",
 			interaction: &objects.Interaction{
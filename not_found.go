@@ -0,0 +1,33 @@
+package router
+
+import "github.com/Postcord/objects"
+
+// NotFoundInfo is used to define information about an interaction which did not match a route.
+type NotFoundInfo struct {
+	// Interaction is the interaction which did not match a route.
+	Interaction *objects.Interaction
+
+	// Kind is the kind of interaction. Auto-complete does not use the not found handlers since it cannot respond with a
+	// message.
+	Kind InteractionKind
+
+	// ID is what was looked up. For commands, this is the command name including any groups separated by spaces. For
+	// components and modals, this is the custom ID, without the signature if signing is turned on.
+	ID string
+}
+
+// NotFoundHandler is used to define a handler which is called when an interaction does not match a route. This can be
+// used to tell the user that something has expired rather than Discord saying that the interaction failed.
+type NotFoundHandler = func(info *NotFoundInfo) *objects.InteractionResponse
+
+// Handles an interaction which did not match a route. The handler of the router is used, otherwise the handler of the
+// loader is used. If neither is set, false is returned.
+func (l loaderPassthrough) notFound(handler NotFoundHandler, info *NotFoundInfo) (*objects.InteractionResponse, bool) {
+	if handler == nil {
+		handler = l.notFoundHandler
+	}
+	if handler == nil {
+		return nil, false
+	}
+	return handler(info), true
+}
@@ -0,0 +1,178 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Postcord/interactions"
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loaderPassthrough_notFound(t *testing.T) {
+	respond := func(content string) NotFoundHandler {
+		return func(*NotFoundInfo) *objects.InteractionResponse {
+			return &objects.InteractionResponse{Data: &objects.InteractionApplicationCommandCallbackData{Content: content}}
+		}
+	}
+	routerHandler, loaderHandler := respond("router"), respond("loader")
+
+	tests := []struct {
+		name string
+
+		routerHandler NotFoundHandler
+		loaderHandler NotFoundHandler
+		expects       string
+		expectsOk     bool
+	}{
+		{
+			name: "no handlers",
+		},
+		{
+			name:          "loader handler",
+			loaderHandler: loaderHandler,
+			expects:       "loader",
+			expectsOk:     true,
+		},
+		{
+			name:          "router handler",
+			routerHandler: routerHandler,
+			expects:       "router",
+			expectsOk:     true,
+		},
+		{
+			name:          "router handler overrides loader",
+			routerHandler: routerHandler,
+			loaderHandler: loaderHandler,
+			expects:       "router",
+			expectsOk:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, ok := loaderPassthrough{notFoundHandler: tt.loaderHandler}.notFound(tt.routerHandler, &NotFoundInfo{})
+			assert.Equal(t, tt.expectsOk, ok)
+			if tt.expectsOk {
+				assert.Equal(t, tt.expects, resp.Data.Content)
+			} else {
+				assert.Nil(t, resp)
+			}
+		})
+	}
+}
+
+func TestNotFoundHandlers(t *testing.T) {
+	commandRouter := &CommandRouter{}
+	commandRouter.MustNewCommandGroup("a", "a", nil).NewCommandBuilder("b").
+		Handler(func(*CommandRouterCtx) error { return nil }).MustBuild()
+	modalRouter := &ModalRouter{}
+	modalRouter.AddModal(&ModalContent{Path: "/modal", Function: func(*ModalRouterCtx) error { return nil }})
+	modalRouter.build(loaderPassthrough{})
+
+	tests := []struct {
+		name string
+
+		handler     func(loader loaderPassthrough) interactions.HandlerFunc
+		interaction *objects.Interaction
+		expects     *NotFoundInfo
+	}{
+		{
+			name: "command",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				h, _ := commandRouter.build(loader)
+				return h
+			},
+			interaction: mockInteraction(&objects.ApplicationCommandInteractionData{Name: "b"}),
+			expects:     &NotFoundInfo{Kind: InteractionKindCommand, ID: "b"},
+		},
+		{
+			name: "sub-command",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				h, _ := commandRouter.build(loader)
+				return h
+			},
+			interaction: mockInteraction(&objects.ApplicationCommandInteractionData{
+				Name: "a",
+				Options: []*objects.ApplicationCommandInteractionDataOption{
+					{Name: "c", Type: objects.TypeSubCommand},
+				},
+			}),
+			expects: &NotFoundInfo{Kind: InteractionKindCommand, ID: "a c"},
+		},
+		{
+			name: "component",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				return (&ComponentRouter{}).build(modalRouter, loader)
+			},
+			interaction: mockInteraction(&objects.ApplicationComponentInteractionData{CustomID: "/expired"}),
+			expects:     &NotFoundInfo{Kind: InteractionKindComponent, ID: "/expired"},
+		},
+		{
+			name: "modal",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				return modalRouter.build(loader)
+			},
+			interaction: mockInteraction(&objects.ApplicationModalInteractionData{CustomID: "/expired"}),
+			expects:     &NotFoundInfo{Kind: InteractionKindModal, ID: "/expired"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notFoundResp := &objects.InteractionResponse{Type: objects.ResponseChannelMessageWithSource}
+			var errResult error
+			loader := loaderPassthrough{
+				rest: dummyRestClient,
+				contextErrHandler: func(err error, _ *ErrorInfo) *objects.InteractionResponse {
+					errResult = err
+					return nil
+				},
+			}
+
+			// Without a handler, the previous behaviour is kept.
+			assert.Nil(t, tt.handler(loader)(context.Background(), tt.interaction))
+			if tt.expects.Kind == InteractionKindModal {
+				assert.ErrorIs(t, errResult, ModalPathNotFound)
+			} else {
+				assert.NoError(t, errResult)
+			}
+
+			// With a handler, it is called with the interaction.
+			errResult = nil
+			var info *NotFoundInfo
+			loader.notFoundHandler = func(i *NotFoundInfo) *objects.InteractionResponse {
+				info = i
+				return notFoundResp
+			}
+			assert.Same(t, notFoundResp, tt.handler(loader)(context.Background(), tt.interaction))
+			assert.NoError(t, errResult)
+			tt.expects.Interaction = tt.interaction
+			assert.Equal(t, tt.expects, info)
+		})
+	}
+}
+
+func TestNotFoundHandlers_router(t *testing.T) {
+	routerResp := &objects.InteractionResponse{Type: objects.ResponseChannelMessageWithSource}
+	loader := loaderPassthrough{
+		rest: dummyRestClient,
+		notFoundHandler: func(*NotFoundInfo) *objects.InteractionResponse {
+			panic("the loader handler should not be called")
+		},
+	}
+	routerHandler := func(*NotFoundInfo) *objects.InteractionResponse { return routerResp }
+
+	commandRouter := &CommandRouter{}
+	commandRouter.SetNotFoundHandler(routerHandler)
+	cmdHandler, _ := commandRouter.build(loader)
+	assert.Same(t, routerResp, cmdHandler(context.Background(), mockInteraction(&objects.ApplicationCommandInteractionData{Name: "a"})))
+
+	componentRouter := &ComponentRouter{}
+	componentRouter.SetNotFoundHandler(routerHandler)
+	assert.Same(t, routerResp, componentRouter.build(nil, loader)(context.Background(),
+		mockInteraction(&objects.ApplicationComponentInteractionData{CustomID: "/a"})))
+
+	modalRouter := &ModalRouter{}
+	modalRouter.SetNotFoundHandler(routerHandler)
+	assert.Same(t, routerResp, modalRouter.build(loader)(context.Background(),
+		mockInteraction(&objects.ApplicationModalInteractionData{CustomID: "/a"})))
+}
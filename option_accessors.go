@@ -0,0 +1,82 @@
+package router
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// OptionGetter is implemented by the contexts which have command options. This allows the option accessors to be used
+// from command and auto-complete handlers.
+type OptionGetter interface {
+	// RawOption is used to get the raw value of an option. The boolean is false if the option is not set.
+	RawOption(name string) (any, bool)
+}
+
+// RawOption implements the OptionGetter interface.
+func (c *CommandRouterCtx) RawOption(name string) (any, bool) {
+	v, ok := c.Options[name]
+	return v, ok
+}
+
+// OptionTypeError is thrown when an option cannot be converted into the type requested.
+type OptionTypeError struct {
+	// Option is the name of the option.
+	Option string
+
+	// Expected is the type which was requested.
+	Expected reflect.Type
+
+	// Actual is the type of the option value.
+	Actual reflect.Type
+
+	// Err is the error from converting the value. This is one of the bind errors.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *OptionTypeError) Error() string {
+	return fmt.Sprintf("option %q is a %s which cannot be used as a %s: %v", e.Option, e.Actual, e.Expected, e.Err)
+}
+
+// Unwrap is used to get the conversion error.
+func (e *OptionTypeError) Unwrap() error {
+	return e.Err
+}
+
+// Option is used to get the option specified as the type T. The boolean is false if the option is not set. T can be
+// any type supported by Bind, so for example an integer option can be got as an int64 and a user option can be got as
+// a ResolvableUser, objects.Snowflake, or *objects.User. If the value cannot be converted, an *OptionTypeError is
+// returned rather than panicking.
+func Option[T any](ctx OptionGetter, name string) (T, bool, error) {
+	var zero T
+	raw, ok := ctx.RawOption(name)
+	if !ok {
+		return zero, false, nil
+	}
+	if x, ok := raw.(T); ok {
+		return x, true, nil
+	}
+	v := reflect.New(reflect.TypeOf((*T)(nil)).Elem())
+	if err := bindValue(v.Elem(), raw); err != nil {
+		return zero, true, &OptionTypeError{
+			Option:   name,
+			Expected: v.Elem().Type(),
+			Actual:   reflect.TypeOf(raw),
+			Err:      err,
+		}
+	}
+	return v.Elem().Interface().(T), true, nil
+}
+
+// OptionOr is used to get the option specified as the type T, or the fallback if it is not set. See Option for the
+// types which are supported.
+func OptionOr[T any](ctx OptionGetter, name string, fallback T) (T, error) {
+	x, ok, err := Option[T](ctx, name)
+	if err != nil {
+		return fallback, err
+	}
+	if !ok {
+		return fallback, nil
+	}
+	return x, nil
+}
@@ -0,0 +1,74 @@
+package router
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOption(t *testing.T) {
+	user := (ResolvableUser)(resolvableUser{resolvable[objects.User]{id: "1", data: bindTestData}})
+	ctx := &CommandRouterCtx{Options: map[string]any{
+		"str":  "hello",
+		"int":  5,
+		"user": user,
+	}}
+
+	s, ok, err := Option[string](ctx, "str")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "hello", s)
+
+	i, ok, err := Option[int64](ctx, "int")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), i)
+
+	r, ok, err := Option[ResolvableUser](ctx, "user")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, user, r)
+
+	id, _, err := Option[objects.Snowflake](ctx, "user")
+	assert.NoError(t, err)
+	assert.Equal(t, objects.Snowflake(1), id)
+
+	u, _, err := Option[*objects.User](ctx, "user")
+	assert.NoError(t, err)
+	assert.Equal(t, "jeff", u.Username)
+
+	s, ok, err = Option[string](ctx, "unset")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, "", s)
+
+	_, ok, err = Option[bool](ctx, "str")
+	assert.True(t, ok)
+	var typeErr *OptionTypeError
+	require.True(t, errors.As(err, &typeErr))
+	assert.Equal(t, "str", typeErr.Option)
+	assert.Equal(t, reflect.TypeOf(false), typeErr.Expected)
+	assert.Equal(t, reflect.TypeOf(""), typeErr.Actual)
+	assert.ErrorIs(t, err, IncompatibleBindField)
+	assert.EqualError(t, err, `option "str" is a string which cannot be used as a bool: option cannot be bound to the field type`)
+}
+
+func TestOptionOr(t *testing.T) {
+	ctx := &CommandRouterCtx{Options: map[string]any{"int": 5}}
+
+	i, err := OptionOr(ctx, "int", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, i)
+
+	i, err = OptionOr(ctx, "unset", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, i)
+
+	s, err := OptionOr(ctx, "int", "fallback")
+	assert.Error(t, err)
+	assert.Equal(t, "fallback", s)
+}
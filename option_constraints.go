@@ -0,0 +1,215 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/Postcord/objects"
+)
+
+// OptionConstraints is used to define the constraints on the value of an option. These are sent to Discord so the
+// client can enforce them, and they are also checked by the router when the command is invoked.
+type OptionConstraints struct {
+	// MinValue is the minimum value of an int or double option.
+	MinValue *float64 `json:"min_value,omitempty"`
+
+	// MaxValue is the maximum value of an int or double option.
+	MaxValue *float64 `json:"max_value,omitempty"`
+
+	// MinLength is the minimum length of a string option. This must be between 0 and 6000.
+	MinLength *int `json:"min_length,omitempty"`
+
+	// MaxLength is the maximum length of a string option. This must be between 1 and 6000.
+	MaxLength *int `json:"max_length,omitempty"`
+
+	// ChannelTypes is the channel types that a channel option can be. If this is empty, all channel types are allowed.
+	ChannelTypes []objects.ChannelType `json:"channel_types,omitempty"`
+}
+
+// OptionConfig is used to configure the constraints of an option. Use the Option* functions to create one.
+type OptionConfig = func(*OptionConstraints)
+
+// OptionMinValue is used to set the minimum value of an int or double option.
+func OptionMinValue(min float64) OptionConfig {
+	return func(c *OptionConstraints) {
+		c.MinValue = &min
+	}
+}
+
+// OptionMaxValue is used to set the maximum value of an int or double option.
+func OptionMaxValue(max float64) OptionConfig {
+	return func(c *OptionConstraints) {
+		c.MaxValue = &max
+	}
+}
+
+// OptionMinLength is used to set the minimum length of a string option.
+func OptionMinLength(min int) OptionConfig {
+	return func(c *OptionConstraints) {
+		c.MinLength = &min
+	}
+}
+
+// OptionMaxLength is used to set the maximum length of a string option.
+func OptionMaxLength(max int) OptionConfig {
+	return func(c *OptionConstraints) {
+		c.MaxLength = &max
+	}
+}
+
+// OptionChannelTypes is used to set the channel types that a channel option can be.
+func OptionChannelTypes(types ...objects.ChannelType) OptionConfig {
+	return func(c *OptionConstraints) {
+		c.ChannelTypes = types
+	}
+}
+
+// Formats a constraint value into the number type used by Postcord.
+func constraintNumber(f *float64) json.Number {
+	if f == nil {
+		return ""
+	}
+	return json.Number(strconv.FormatFloat(*f, 'f', -1, 64))
+}
+
+// Applies the configs to the option. The constraints Postcord can represent are set on the option, and the rest are
+// stored in the command.
+func (c *Command) configureOption(option *objects.ApplicationCommandOption, configs []OptionConfig) {
+	if len(configs) == 0 {
+		return
+	}
+	constraints := &OptionConstraints{}
+	for _, f := range configs {
+		f(constraints)
+	}
+	option.MinValue = constraintNumber(constraints.MinValue)
+	option.MaxValue = constraintNumber(constraints.MaxValue)
+	option.ChannelTypes = constraints.ChannelTypes
+	if c.OptionConstraints == nil {
+		c.OptionConstraints = map[string]*OptionConstraints{}
+	}
+	c.OptionConstraints[option.Name] = constraints
+}
+
+// OptionValueOutOfRange is thrown when the value of an int or double option is outside of the range set on the command.
+var OptionValueOutOfRange = errors.New("option value is out of range")
+
+// OptionLengthOutOfRange is thrown when the length of a string option is outside of the range set on the command.
+var OptionLengthOutOfRange = errors.New("option length is out of range")
+
+// OptionChannelTypeNotAllowed is thrown when a channel option is a channel type that is not allowed by the command.
+var OptionChannelTypeNotAllowed = errors.New("option channel type is not allowed")
+
+// OptionConstraintError is thrown when the value of an option does not meet a constraint set on the command. It
+// unwraps to OptionValueOutOfRange, OptionLengthOutOfRange, or OptionChannelTypeNotAllowed.
+type OptionConstraintError struct {
+	// Option is the name of the option.
+	Option string
+
+	// Constraint is the JSON name of the constraint which was not met, such as "max_length".
+	Constraint string
+
+	// Bound is the value of the constraint. This is a float64 for values, an int for lengths, and a
+	// []objects.ChannelType for channel types.
+	Bound any
+
+	// Value is the value which was checked. This is the number for values, the length for strings, and the
+	// objects.ChannelType for channels.
+	Value any
+
+	// Err is the error which this unwraps to.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *OptionConstraintError) Error() string {
+	return fmt.Sprintf("option %q has the value %v which does not meet the %s constraint of %v: %s",
+		e.Option, e.Value, e.Constraint, e.Bound, e.Err)
+}
+
+// Unwrap is used to get the sentinel error.
+func (e *OptionConstraintError) Unwrap() error {
+	return e.Err
+}
+
+// Checks the mapped value of the option against the constraints. Channels which were not resolved are not checked.
+func (o *OptionConstraints) check(option string, value any) error {
+	switch x := value.(type) {
+	case int:
+		return o.checkValue(option, float64(x), x)
+	case float64:
+		return o.checkValue(option, x, x)
+	case string:
+		l := utf8.RuneCountInString(x)
+		if o.MinLength != nil && l < *o.MinLength {
+			return &OptionConstraintError{Option: option, Constraint: "min_length", Bound: *o.MinLength, Value: l, Err: OptionLengthOutOfRange}
+		}
+		if o.MaxLength != nil && l > *o.MaxLength {
+			return &OptionConstraintError{Option: option, Constraint: "max_length", Bound: *o.MaxLength, Value: l, Err: OptionLengthOutOfRange}
+		}
+	case ResolvableChannel:
+		if len(o.ChannelTypes) == 0 {
+			return nil
+		}
+		channel := x.Resolve()
+		if channel == nil {
+			return nil
+		}
+		for _, v := range o.ChannelTypes {
+			if channel.Type == v {
+				return nil
+			}
+		}
+		return &OptionConstraintError{
+			Option:     option,
+			Constraint: "channel_types",
+			Bound:      o.ChannelTypes,
+			Value:      channel.Type,
+			Err:        OptionChannelTypeNotAllowed,
+		}
+	}
+	return nil
+}
+
+// Checks the value is within the range. The original value is put in the error.
+func (o *OptionConstraints) checkValue(option string, f float64, value any) error {
+	if o.MinValue != nil && f < *o.MinValue {
+		return &OptionConstraintError{Option: option, Constraint: "min_value", Bound: *o.MinValue, Value: value, Err: OptionValueOutOfRange}
+	}
+	if o.MaxValue != nil && f > *o.MaxValue {
+		return &OptionConstraintError{Option: option, Constraint: "max_value", Bound: *o.MaxValue, Value: value, Err: OptionValueOutOfRange}
+	}
+	return nil
+}
+
+// Validates the constraints against the option type.
+func (o *OptionConstraints) validate(optionType objects.ApplicationCommandOptionType) error {
+	numeric := optionType == objects.TypeInteger || optionType == objects.TypeNumber
+	if (o.MinValue != nil || o.MaxValue != nil) && !numeric {
+		return InvalidOptionConstraint
+	}
+	if o.MinValue != nil && o.MaxValue != nil && *o.MinValue > *o.MaxValue {
+		return InvalidOptionConstraint
+	}
+	if o.MinLength != nil || o.MaxLength != nil {
+		if optionType != objects.TypeString {
+			return InvalidOptionConstraint
+		}
+		if o.MinLength != nil && (*o.MinLength < 0 || *o.MinLength > 6000) {
+			return InvalidOptionConstraint
+		}
+		if o.MaxLength != nil && (*o.MaxLength < 1 || *o.MaxLength > 6000) {
+			return InvalidOptionConstraint
+		}
+		if o.MinLength != nil && o.MaxLength != nil && *o.MinLength > *o.MaxLength {
+			return InvalidOptionConstraint
+		}
+	}
+	if len(o.ChannelTypes) != 0 && optionType != objects.TypeChannel {
+		return InvalidOptionConstraint
+	}
+	return nil
+}
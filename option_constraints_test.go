@@ -0,0 +1,220 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func constrainedTestRouter() *CommandRouter {
+	r := &CommandRouter{}
+	r.NewCommandBuilder("constrained").Description("constrained options").
+		IntOption("int", "an int", true, nil, OptionMinValue(1), OptionMaxValue(10)).
+		DoubleOption("double", "a double", true, nil, OptionMinValue(0.5)).
+		StringOption("string", "a string", true, nil, OptionMinLength(2), OptionMaxLength(4)).
+		ChannelOption("channel", "a channel", true, OptionChannelTypes(objects.ChannelTypeGuildText)).
+		MustBuild()
+	return r
+}
+
+func TestCommandRouter_FormulateDiscordCommands_constraints(t *testing.T) {
+	cmds := constrainedTestRouter().FormulateDiscordCommands()
+	require.Len(t, cmds, 1)
+	options := cmds[0].Options
+	require.Len(t, options, 4)
+	assert.Equal(t, json.Number("1"), options[0].MinValue)
+	assert.Equal(t, json.Number("10"), options[0].MaxValue)
+	assert.Equal(t, json.Number("0.5"), options[1].MinValue)
+	assert.Equal(t, json.Number(""), options[1].MaxValue)
+	assert.Equal(t, []objects.ChannelType{objects.ChannelTypeGuildText}, options[3].ChannelTypes)
+
+	// Postcord has no fields for lengths, so they are only in the extended commands.
+	extended := constrainedTestRouter().FormulateExtendedDiscordCommands()
+	require.Len(t, extended, 1)
+	b, err := json.Marshal(extended[0].Options[2])
+	require.NoError(t, err)
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(b, &m))
+	assert.Equal(t, float64(2), m["min_length"])
+	assert.Equal(t, float64(4), m["max_length"])
+}
+
+func TestOptionConstraints_validate(t *testing.T) {
+	tests := []struct {
+		name string
+
+		build func(r *CommandRouter) error
+
+		expectsErr error
+	}{
+		{
+			name: "valid",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("a").IntOption("a", "a", true, nil, OptionMinValue(1), OptionMaxValue(1)).Build()
+				return err
+			},
+		},
+		{
+			name: "value on string",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("a").StringOption("a", "a", true, nil, OptionMinValue(1)).Build()
+				return err
+			},
+			expectsErr: InvalidOptionConstraint,
+		},
+		{
+			name: "min value greater than max value",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("a").IntOption("a", "a", true, nil, OptionMinValue(2), OptionMaxValue(1)).Build()
+				return err
+			},
+			expectsErr: InvalidOptionConstraint,
+		},
+		{
+			name: "length on int",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("a").IntOption("a", "a", true, nil, OptionMaxLength(1)).Build()
+				return err
+			},
+			expectsErr: InvalidOptionConstraint,
+		},
+		{
+			name: "max length too long",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("a").StringOption("a", "a", true, nil, OptionMaxLength(6001)).Build()
+				return err
+			},
+			expectsErr: InvalidOptionConstraint,
+		},
+		{
+			name: "min length greater than max length",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("a").StringOption("a", "a", true, nil, OptionMinLength(5), OptionMaxLength(4)).Build()
+				return err
+			},
+			expectsErr: InvalidOptionConstraint,
+		},
+		{
+			name: "channel types on string",
+			build: func(r *CommandRouter) error {
+				_, err := r.NewCommandBuilder("a").
+					StringOption("a", "a", true, nil, OptionChannelTypes(objects.ChannelTypeDM)).Build()
+				return err
+			},
+			expectsErr: InvalidOptionConstraint,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.build(&CommandRouter{})
+			if tt.expectsErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expectsErr)
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, "a", validationErr.Option)
+		})
+	}
+}
+
+func TestCommand_mapOptions_constraints(t *testing.T) {
+	data := &objects.ApplicationCommandInteractionData{
+		Resolved: objects.ApplicationCommandInteractionDataResolved{
+			Channels: map[objects.Snowflake]objects.Channel{
+				1: {DiscordBaseObject: objects.DiscordBaseObject{ID: 1}, Type: objects.ChannelTypeGuildText},
+				2: {DiscordBaseObject: objects.DiscordBaseObject{ID: 2}, Type: objects.ChannelTypeGuildVoice},
+			},
+		},
+	}
+	tests := []struct {
+		name string
+
+		autocomplete bool
+		option       *objects.ApplicationCommandInteractionDataOption
+
+		expectsErr *OptionConstraintError
+	}{
+		{
+			name:   "int in range",
+			option: &objects.ApplicationCommandInteractionDataOption{Type: objects.TypeInteger, Name: "int", Value: float64(10)},
+		},
+		{
+			name:       "int out of range",
+			option:     &objects.ApplicationCommandInteractionDataOption{Type: objects.TypeInteger, Name: "int", Value: float64(11)},
+			expectsErr: &OptionConstraintError{Option: "int", Constraint: "max_value", Bound: float64(10), Value: 11, Err: OptionValueOutOfRange},
+		},
+		{
+			name:       "double out of range",
+			option:     &objects.ApplicationCommandInteractionDataOption{Type: objects.TypeNumber, Name: "double", Value: 0.25},
+			expectsErr: &OptionConstraintError{Option: "double", Constraint: "min_value", Bound: 0.5, Value: 0.25, Err: OptionValueOutOfRange},
+		},
+		{
+			name:   "string in range",
+			option: &objects.ApplicationCommandInteractionDataOption{Type: objects.TypeString, Name: "string", Value: "héé"},
+		},
+		{
+			name:       "string too long",
+			option:     &objects.ApplicationCommandInteractionDataOption{Type: objects.TypeString, Name: "string", Value: "hello"},
+			expectsErr: &OptionConstraintError{Option: "string", Constraint: "max_length", Bound: 4, Value: 5, Err: OptionLengthOutOfRange},
+		},
+		{
+			name:       "string too short",
+			option:     &objects.ApplicationCommandInteractionDataOption{Type: objects.TypeString, Name: "string", Value: "é"},
+			expectsErr: &OptionConstraintError{Option: "string", Constraint: "min_length", Bound: 2, Value: 1, Err: OptionLengthOutOfRange},
+		},
+		{
+			name:         "auto-complete is not checked",
+			autocomplete: true,
+			option:       &objects.ApplicationCommandInteractionDataOption{Type: objects.TypeString, Name: "string", Value: "h"},
+		},
+		{
+			name:   "allowed channel type",
+			option: &objects.ApplicationCommandInteractionDataOption{Type: objects.TypeChannel, Name: "channel", Value: "1"},
+		},
+		{
+			name:   "disallowed channel type",
+			option: &objects.ApplicationCommandInteractionDataOption{Type: objects.TypeChannel, Name: "channel", Value: "2"},
+			expectsErr: &OptionConstraintError{
+				Option:     "channel",
+				Constraint: "channel_types",
+				Bound:      []objects.ChannelType{objects.ChannelTypeGuildText},
+				Value:      objects.ChannelTypeGuildVoice,
+				Err:        OptionChannelTypeNotAllowed,
+			},
+		},
+		{
+			name:   "unresolved channel",
+			option: &objects.ApplicationCommandInteractionDataOption{Type: objects.TypeChannel, Name: "channel", Value: "3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := constrainedTestRouter().roots.Subcommands["constrained"].(*Command)
+			var errResult error
+			resp, _ := cmd.mapOptions(tt.autocomplete, data, []*objects.ApplicationCommandInteractionDataOption{tt.option}, func(err error) *objects.InteractionResponse {
+				errResult = err
+				return &objects.InteractionResponse{Type: 69}
+			})
+			if tt.expectsErr == nil {
+				assert.NoError(t, errResult)
+				assert.Nil(t, resp)
+			} else {
+				assert.Equal(t, tt.expectsErr, errResult)
+				assert.ErrorIs(t, errResult, tt.expectsErr.Err)
+				assert.NotNil(t, resp)
+			}
+		})
+	}
+}
+
+func TestOptionConstraintError(t *testing.T) {
+	err := &OptionConstraintError{Option: "a", Constraint: "max_length", Bound: 4, Value: 5, Err: OptionLengthOutOfRange}
+	assert.Equal(t, `option "a" has the value 5 which does not meet the max_length constraint of 4: option length is out of range`, err.Error())
+	assert.ErrorIs(t, err, OptionLengthOutOfRange)
+}
@@ -0,0 +1,34 @@
+package router
+
+import "runtime/debug"
+
+// PanicError is passed to the error handler when a handler panics. The error message is the same as the value passed
+// to panic, and the stack trace and route can be used for logging.
+type PanicError struct {
+	// Value is the value which was passed to panic.
+	Value any
+
+	// Stack is the stack trace of the goroutine when it panicked.
+	Stack []byte
+
+	// Route is what was being handled when the panic happened. For commands, this is the command name including any
+	// groups separated by spaces. For components and modals, this is the route which was registered.
+	Route string
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return ungenericError(e.Value).Error()
+}
+
+// Unwrap is used to get the error which was passed to panic. If the value was not an error, this is nil.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Creates the panic error. This should be called in the deferred function which recovered the panic so that the stack
+// trace includes where the panic happened.
+func newPanicError(errGeneric any, route string) *PanicError {
+	return &PanicError{Value: errGeneric, Stack: debug.Stack(), Route: route}
+}
@@ -0,0 +1,243 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Postcord/interactions"
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPanicError(t *testing.T) {
+	cause := errors.New("abc")
+	assert.EqualError(t, &PanicError{Value: cause}, "abc")
+	assert.Same(t, cause, errors.Unwrap(&PanicError{Value: cause}))
+	assert.EqualError(t, &PanicError{Value: 1}, "1")
+	assert.Nil(t, errors.Unwrap(&PanicError{Value: 1}))
+
+	err := newPanicError("abc", "/a")
+	assert.Equal(t, "/a", err.Route)
+	assert.Contains(t, string(err.Stack), "TestPanicError")
+}
+
+func TestPanicRecovery(t *testing.T) {
+	errResp := &objects.InteractionResponse{Type: 69}
+
+	tests := []struct {
+		name string
+
+		handler     func(loader loaderPassthrough) interactions.HandlerFunc
+		interaction *objects.Interaction
+
+		expectsRoute string
+		expectsResp  *objects.InteractionResponse
+	}{
+		{
+			name: "command",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				r := &CommandRouter{}
+				r.MustNewCommandGroup("a", "", nil).NewCommandBuilder("b").
+					Handler(func(*CommandRouterCtx) error { panic("wumpus fled the scene") }).
+					MustBuild()
+				h, _ := r.build(loader)
+				return h
+			},
+			interaction: mockInteraction(&objects.ApplicationCommandInteractionData{
+				Name: "a",
+				Type: objects.CommandTypeChatInput,
+				Options: []*objects.ApplicationCommandInteractionDataOption{
+					{Name: "b", Type: objects.TypeSubCommand},
+				},
+			}),
+			expectsRoute: "a b",
+			expectsResp:  errResp,
+		},
+		{
+			name: "auto-complete",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				r := &CommandRouter{}
+				r.NewCommandBuilder("a").
+					StringOption("b", "b", true, nil).
+					Autocomplete("b", func(*AutocompleteCtx) error { panic("wumpus fled the scene") }).
+					Handler(func(*CommandRouterCtx) error { return nil }).
+					MustBuild()
+				_, h := r.build(loader)
+				return h
+			},
+			interaction: mockInteraction(&objects.ApplicationCommandInteractionData{
+				Name: "a",
+				Type: objects.CommandTypeChatInput,
+				Options: []*objects.ApplicationCommandInteractionDataOption{
+					{Name: "b", Type: objects.TypeString, Value: "x", Focused: true},
+				},
+			}),
+			expectsRoute: "a",
+		},
+		{
+			name: "button",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				r := &ComponentRouter{}
+				r.RegisterButton("/a/:id", func(*ComponentRouterCtx) error { panic("wumpus fled the scene") })
+				return r.build(nil, loader)
+			},
+			interaction: mockInteraction(objects.ApplicationComponentInteractionData{
+				CustomID:      "/a/1",
+				ComponentType: objects.ComponentTypeButton,
+			}),
+			expectsRoute: "/a/:id",
+			expectsResp:  errResp,
+		},
+		{
+			name: "select menu",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				r := &ComponentRouter{}
+				r.RegisterSelectMenu("/a", func(*ComponentRouterCtx, []string) error { panic("wumpus fled the scene") })
+				return r.build(nil, loader)
+			},
+			interaction: mockInteraction(objects.ApplicationComponentInteractionData{
+				CustomID:      "/a",
+				ComponentType: objects.ComponentTypeSelectMenu,
+			}),
+			expectsRoute: "/a",
+			expectsResp:  errResp,
+		},
+		{
+			name: "modal",
+			handler: func(loader loaderPassthrough) interactions.HandlerFunc {
+				r := &ModalRouter{}
+				r.AddModal(&ModalContent{
+					Path:     "/a",
+					Contents: func(*ModalGenerationCtx) (string, []ModalContentItem) { return "", nil },
+					Function: func(*ModalRouterCtx) error { panic("wumpus fled the scene") },
+				})
+				return r.build(loader)
+			},
+			interaction: mockInteraction(objects.ApplicationModalInteractionData{
+				CustomID: "/a",
+			}),
+			expectsRoute: "/a",
+			expectsResp:  errResp,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errResult error
+			handler := tt.handler(loaderPassthrough{
+				rest: dummyRestClient,
+				errHandler: func(err error) *objects.InteractionResponse {
+					errResult = err
+					return errResp
+				},
+			})
+			resp := handler(context.Background(), tt.interaction)
+			assert.Equal(t, tt.expectsResp, resp)
+			var panicErr *PanicError
+			require.True(t, errors.As(errResult, &panicErr))
+			assert.EqualError(t, panicErr, "wumpus fled the scene")
+			assert.Equal(t, tt.expectsRoute, panicErr.Route)
+			assert.NotEmpty(t, panicErr.Stack)
+		})
+	}
+}
+
+func TestCommandRouterCtx_UpdateLater_panic(t *testing.T) {
+	errs := make(chan error, 1)
+	ctx := &CommandRouterCtx{
+		errorHandler: func(err error) *objects.InteractionResponse {
+			errs <- err
+			return nil
+		},
+		route:       "a",
+		Interaction: &objects.Interaction{},
+	}
+	ctx.UpdateLater(func(*CommandRouterCtx) error {
+		panic("wumpus fled the scene")
+	})
+	var panicErr *PanicError
+	require.True(t, errors.As(<-errs, &panicErr))
+	assert.Equal(t, "wumpus fled the scene", panicErr.Value)
+	assert.Equal(t, "a", panicErr.Route)
+}
+
+func TestUpdateLater_copiesFields(t *testing.T) {
+	// Sets every field other than the response builder to a non-zero value, and checks they are all copied.
+	check := func(t *testing.T, ctx any, updateLater func(done chan<- any)) {
+		t.Helper()
+		v := reflect.ValueOf(ctx).Elem()
+		for i := 1; i < v.NumField(); i++ {
+			f := v.Field(i)
+			reflect.NewAt(f.Type(), f.Addr().UnsafePointer()).Elem().Set(nonZeroValue(f.Type()))
+		}
+		done := make(chan any, 1)
+		updateLater(done)
+		cpy := reflect.ValueOf(<-done).Elem()
+		for i := 1; i < v.NumField(); i++ {
+			assert.False(t, cpy.Field(i).IsZero(), "field %s was not copied", v.Type().Field(i).Name)
+		}
+	}
+
+	t.Run("component", func(t *testing.T) {
+		ctx := &ComponentRouterCtx{}
+		check(t, ctx, func(done chan<- any) {
+			ctx.UpdateLater(func(cpy *ComponentRouterCtx) error {
+				done <- cpy
+				return errors.New("stop")
+			})
+		})
+	})
+	t.Run("command", func(t *testing.T) {
+		ctx := &CommandRouterCtx{}
+		check(t, ctx, func(done chan<- any) {
+			ctx.UpdateLater(func(cpy *CommandRouterCtx) error {
+				done <- cpy
+				return errors.New("stop")
+			})
+		})
+	})
+	t.Run("modal", func(t *testing.T) {
+		ctx := &ModalRouterCtx{}
+		check(t, ctx, func(done chan<- any) {
+			ctx.UpdateLater(func(cpy *ModalRouterCtx) error {
+				done <- cpy
+				return errors.New("stop")
+			})
+		})
+	})
+}
+
+// Gets a non-zero value of the type specified.
+func nonZeroValue(t reflect.Type) reflect.Value {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return reflect.New(t.Elem())
+	case reflect.Map:
+		return reflect.MakeMap(t)
+	case reflect.Slice:
+		return reflect.MakeSlice(t, 1, 1)
+	case reflect.Func:
+		return reflect.MakeFunc(t, func([]reflect.Value) []reflect.Value {
+			out := make([]reflect.Value, t.NumOut())
+			for i := range out {
+				out[i] = reflect.Zero(t.Out(i))
+			}
+			return out
+		})
+	case reflect.Interface:
+		// Only used for the context, logger, and REST client.
+		if t == reflect.TypeOf((*context.Context)(nil)).Elem() {
+			return reflect.ValueOf(context.Background())
+		}
+		if t == reflect.TypeOf((*Logger)(nil)).Elem() {
+			return reflect.ValueOf(nopLogger{})
+		}
+		return reflect.ValueOf(dummyRestClient)
+	case reflect.String:
+		return reflect.ValueOf("a").Convert(t)
+	default:
+		return reflect.ValueOf(1).Convert(t)
+	}
+}
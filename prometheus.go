@@ -0,0 +1,193 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultPrometheusBuckets is used to define the histogram buckets used when they are not set. These are in seconds.
+var DefaultPrometheusBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Defines a histogram for one set of labels.
+type promHistogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// Adds the value to the histogram.
+func (h *promHistogram) observe(buckets []float64, v float64) {
+	for i, le := range buckets {
+		if v <= le {
+			h.counts[i]++
+		}
+	}
+	h.sum += v
+	h.count++
+}
+
+// PrometheusExporter is used to collect metrics from the router and render them in the Prometheus text format. This
+// implements Instrumentation so it can be passed to the loader, and http.Handler so it can be served. The zero value is
+// ready to use.
+type PrometheusExporter struct {
+	// Namespace is the prefix of the metric names. Defaults to "postcord_router".
+	Namespace string
+
+	// Buckets is the upper bounds of the histogram buckets in seconds. Defaults to DefaultPrometheusBuckets. This must
+	// not be changed once metrics have been collected.
+	Buckets []float64
+
+	mu sync.Mutex
+
+	// Defines the counters and histograms. These are keyed by the rendered labels.
+	dispatches       map[string]uint64
+	dispatchDuration map[string]*promHistogram
+	restCalls        map[string]uint64
+	restDuration     map[string]*promHistogram
+}
+
+// Used to escape a label value.
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Renders the key-value pairs as labels.
+func promLabels(kv ...string) string {
+	var b strings.Builder
+	for i := 0; i < len(kv); i += 2 {
+		if i != 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(kv[i])
+		b.WriteString(`="`)
+		b.WriteString(promLabelEscaper.Replace(kv[i+1]))
+		b.WriteByte('"')
+	}
+	return b.String()
+}
+
+// Gets the status label for the error.
+func promStatus(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	return "error"
+}
+
+// Gets the buckets to use.
+func (p *PrometheusExporter) buckets() []float64 {
+	if p.Buckets == nil {
+		return DefaultPrometheusBuckets
+	}
+	return p.Buckets
+}
+
+// Adds the value to the histogram with the labels specified. The lock must be held.
+func (p *PrometheusExporter) observe(m *map[string]*promHistogram, labels string, v float64) {
+	if *m == nil {
+		*m = map[string]*promHistogram{}
+	}
+	h := (*m)[labels]
+	if h == nil {
+		h = &promHistogram{counts: make([]uint64, len(p.buckets()))}
+		(*m)[labels] = h
+	}
+	h.observe(p.buckets(), v)
+}
+
+// DispatchStart implements the Instrumentation interface.
+func (p *PrometheusExporter) DispatchStart(ctx context.Context, _ *DispatchInfo) context.Context {
+	return ctx
+}
+
+// DispatchEnd implements the Instrumentation interface.
+func (p *PrometheusExporter) DispatchEnd(_ context.Context, info *DispatchInfo, result *DispatchResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.dispatches == nil {
+		p.dispatches = map[string]uint64{}
+	}
+	kind := info.Kind.String()
+	p.dispatches[promLabels("kind", kind, "route", info.Route, "status", promStatus(result.Err))]++
+	p.observe(&p.dispatchDuration, promLabels("kind", kind, "route", info.Route), result.Duration.Seconds())
+}
+
+// RESTCallStart implements the Instrumentation interface.
+func (p *PrometheusExporter) RESTCallStart(ctx context.Context, _ *RESTCallInfo) context.Context {
+	return ctx
+}
+
+// RESTCallEnd implements the Instrumentation interface.
+func (p *PrometheusExporter) RESTCallEnd(_ context.Context, info *RESTCallInfo, result *RESTCallResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.restCalls == nil {
+		p.restCalls = map[string]uint64{}
+	}
+	p.restCalls[promLabels("method", info.Method, "status", promStatus(result.Err))]++
+	p.observe(&p.restDuration, promLabels("method", info.Method), result.Duration.Seconds())
+}
+
+// Gets the keys of the map sorted so the output is stable.
+func promSortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Writes a counter.
+func promWriteCounter(b *bytes.Buffer, name, help string, m map[string]uint64) {
+	b.WriteString("# HELP " + name + " " + help + "\n# TYPE " + name + " counter\n")
+	for _, k := range promSortedKeys(m) {
+		b.WriteString(name + "{" + k + "} " + strconv.FormatUint(m[k], 10) + "\n")
+	}
+}
+
+// Writes a histogram.
+func promWriteHistogram(b *bytes.Buffer, name, help string, buckets []float64, m map[string]*promHistogram) {
+	b.WriteString("# HELP " + name + " " + help + "\n# TYPE " + name + " histogram\n")
+	for _, k := range promSortedKeys(m) {
+		h := m[k]
+		for i, le := range buckets {
+			b.WriteString(name + "_bucket{" + k + `,le="` + strconv.FormatFloat(le, 'g', -1, 64) + `"} ` +
+				strconv.FormatUint(h.counts[i], 10) + "\n")
+		}
+		count := strconv.FormatUint(h.count, 10)
+		b.WriteString(name + "_bucket{" + k + `,le="+Inf"} ` + count + "\n")
+		b.WriteString(name + "_sum{" + k + "} " + strconv.FormatFloat(h.sum, 'g', -1, 64) + "\n")
+		b.WriteString(name + "_count{" + k + "} " + count + "\n")
+	}
+}
+
+// Renders the metrics in the Prometheus text format.
+func (p *PrometheusExporter) render() []byte {
+	namespace := p.Namespace
+	if namespace == "" {
+		namespace = "postcord_router"
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	b := &bytes.Buffer{}
+	promWriteCounter(b, namespace+"_dispatches_total",
+		"The number of interactions dispatched to a route.", p.dispatches)
+	promWriteHistogram(b, namespace+"_dispatch_duration_seconds",
+		"How long routes took to respond.", p.buckets(), p.dispatchDuration)
+	promWriteCounter(b, namespace+"_rest_calls_total",
+		"The number of REST calls made through a context.", p.restCalls)
+	promWriteHistogram(b, namespace+"_rest_call_duration_seconds",
+		"How long REST calls made through a context took.", p.buckets(), p.restDuration)
+	return b.Bytes()
+}
+
+// ServeHTTP implements the http.Handler interface. This responds with the metrics in the Prometheus text format.
+func (p *PrometheusExporter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write(p.render())
+}
@@ -0,0 +1,101 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ Instrumentation = (*PrometheusExporter)(nil)
+	_ http.Handler    = (*PrometheusExporter)(nil)
+)
+
+func Test_promLabels(t *testing.T) {
+	assert.Equal(t, `a="b",c="\\ \" \n"`, promLabels("a", "b", "c", "\\ \" \n"))
+}
+
+func TestPrometheusExporter(t *testing.T) {
+	tests := []struct {
+		name string
+
+		exporter *PrometheusExporter
+		record   func(t *testing.T, p *PrometheusExporter)
+		expects  string
+	}{
+		{
+			name:     "empty",
+			exporter: &PrometheusExporter{},
+			expects: `# HELP postcord_router_dispatches_total The number of interactions dispatched to a route.
+# TYPE postcord_router_dispatches_total counter
+# HELP postcord_router_dispatch_duration_seconds How long routes took to respond.
+# TYPE postcord_router_dispatch_duration_seconds histogram
+# HELP postcord_router_rest_calls_total The number of REST calls made through a context.
+# TYPE postcord_router_rest_calls_total counter
+# HELP postcord_router_rest_call_duration_seconds How long REST calls made through a context took.
+# TYPE postcord_router_rest_call_duration_seconds histogram
+`,
+		},
+		{
+			name:     "metrics",
+			exporter: &PrometheusExporter{Namespace: "bot", Buckets: []float64{0.1, 1}},
+			record: func(t *testing.T, p *PrometheusExporter) {
+				ctx := context.Background()
+				command := &DispatchInfo{Route: "a", Kind: InteractionKindCommand}
+				assert.Equal(t, ctx, p.DispatchStart(ctx, command))
+				p.DispatchEnd(ctx, command, &DispatchResult{Duration: 50 * time.Millisecond})
+				p.DispatchEnd(ctx, command, &DispatchResult{Duration: 500 * time.Millisecond})
+				p.DispatchEnd(ctx, command, &DispatchResult{Duration: 2 * time.Second, Err: NotOwner})
+				p.DispatchEnd(ctx, &DispatchInfo{Route: "/b", Kind: InteractionKindComponent},
+					&DispatchResult{Duration: 10 * time.Millisecond})
+				restCall := &RESTCallInfo{DispatchInfo: *command, Method: "GetUser"}
+				assert.Equal(t, ctx, p.RESTCallStart(ctx, restCall))
+				p.RESTCallEnd(ctx, restCall, &RESTCallResult{Duration: 500 * time.Millisecond, Err: errEditFailed})
+			},
+			expects: `# HELP bot_dispatches_total The number of interactions dispatched to a route.
+# TYPE bot_dispatches_total counter
+bot_dispatches_total{kind="command",route="a",status="error"} 1
+bot_dispatches_total{kind="command",route="a",status="ok"} 2
+bot_dispatches_total{kind="component",route="/b",status="ok"} 1
+# HELP bot_dispatch_duration_seconds How long routes took to respond.
+# TYPE bot_dispatch_duration_seconds histogram
+bot_dispatch_duration_seconds_bucket{kind="command",route="a",le="0.1"} 1
+bot_dispatch_duration_seconds_bucket{kind="command",route="a",le="1"} 2
+bot_dispatch_duration_seconds_bucket{kind="command",route="a",le="+Inf"} 3
+bot_dispatch_duration_seconds_sum{kind="command",route="a"} 2.55
+bot_dispatch_duration_seconds_count{kind="command",route="a"} 3
+bot_dispatch_duration_seconds_bucket{kind="component",route="/b",le="0.1"} 1
+bot_dispatch_duration_seconds_bucket{kind="component",route="/b",le="1"} 1
+bot_dispatch_duration_seconds_bucket{kind="component",route="/b",le="+Inf"} 1
+bot_dispatch_duration_seconds_sum{kind="component",route="/b"} 0.01
+bot_dispatch_duration_seconds_count{kind="component",route="/b"} 1
+# HELP bot_rest_calls_total The number of REST calls made through a context.
+# TYPE bot_rest_calls_total counter
+bot_rest_calls_total{method="GetUser",status="error"} 1
+# HELP bot_rest_call_duration_seconds How long REST calls made through a context took.
+# TYPE bot_rest_call_duration_seconds histogram
+bot_rest_call_duration_seconds_bucket{method="GetUser",le="0.1"} 0
+bot_rest_call_duration_seconds_bucket{method="GetUser",le="1"} 1
+bot_rest_call_duration_seconds_bucket{method="GetUser",le="+Inf"} 1
+bot_rest_call_duration_seconds_sum{method="GetUser"} 0.5
+bot_rest_call_duration_seconds_count{method="GetUser"} 1
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.record != nil {
+				tt.record(t, tt.exporter)
+			}
+
+			rec := httptest.NewRecorder()
+			tt.exporter.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+			assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expects, rec.Body.String())
+		})
+	}
+}
@@ -79,6 +79,20 @@ func (r *responseBuilder) buildResponse(component bool, errorHandler ErrorHandle
 	}
 }
 
+// Gets the response which has been built so far without inferring anything. This is nil if nothing was built.
+func (r *responseBuilder) partialResponse() *objects.InteractionResponse {
+	r.dataPtrLock.Lock()
+	data := r.dataPtr
+	r.dataPtrLock.Unlock()
+	if r.respType == 0 && data == nil {
+		return nil
+	}
+	return &objects.InteractionResponse{
+		Type: r.respType,
+		Data: data,
+	}
+}
+
 // Internal method to edit embeds.
 func (r *responseBuilder) editEmbed(embed *objects.Embed, appendEmbed bool) {
 	if embed == nil {
@@ -12,23 +12,39 @@ import (
 
 // UpdateLater is used to spawn the function specified in a goroutine. When the function is returned, the result is set as a message update.
 func (c *ComponentRouterCtx) UpdateLater(f func(*ComponentRouterCtx) error) *ComponentRouterCtx {
-	cpy := *c
-	cpy.responseBuilder = responseBuilder{}
+	// Copy everything but the response builder. The struct cannot be copied directly since the response builder has a lock.
+	cpy := &ComponentRouterCtx{
+		errorHandler:          c.errorHandler,
+		globalAllowedMentions: c.globalAllowedMentions,
+		modalRouter:           c.modalRouter,
+		voidGenerator:         c.voidGenerator,
+		route:                 c.route,
+		logger:                c.logger,
+		state:                 c.state,
+		Context:               c.Context,
+		Interaction:           c.Interaction,
+		Params:                c.Params,
+		RESTClient:            c.RESTClient,
+	}
 	go func() {
+		var response *objects.InteractionResponse
 		defer func() {
 			if errGeneric := recover(); errGeneric != nil {
-				cpy.errorHandler(ungenericError(errGeneric))
+				response = cpy.errorHandler(newPanicError(errGeneric, cpy.route))
+			}
+			// Need a better way to handle this context - the one on the RouterCtx will have been cancelled already
+			// and can't be used
+			err := processUpdateLaterResponse(context.Background(), cpy.RESTClient, cpy.ApplicationID, cpy.Token, response)
+			if err != nil {
+				attrs := logAttrs(InteractionKindComponent, cpy.route, cpy.Interaction)
+				loggerOrNop(cpy.logger).Error("failed to update the response", append(attrs, "error", err)...)
 			}
 		}()
-		var response *objects.InteractionResponse
-		if err := f(&cpy); err == nil {
+		if err := f(cpy); err == nil {
 			response = cpy.buildResponse(false, cpy.errorHandler, cpy.globalAllowedMentions)
 		} else {
 			response = cpy.errorHandler(err)
 		}
-		// Need a better way to handle this context - the one on the RouterCtx will have been cancelled already
-		// and can't be used
-		processUpdateLaterResponse(context.Background(), cpy.RESTClient, cpy.ApplicationID, cpy.Token, response)
 	}()
 	return c
 }
@@ -50,23 +66,39 @@ func (c *ComponentRouterCtx) WithModalPath(path string) error {
 
 // UpdateLater is used to spawn the function specified in a goroutine. When the function is returned, the result is set as a message update.
 func (c *CommandRouterCtx) UpdateLater(f func(*CommandRouterCtx) error) *CommandRouterCtx {
-	cpy := *c
-	cpy.responseBuilder = responseBuilder{}
+	// Copy everything but the response builder. The struct cannot be copied directly since the response builder has a lock.
+	cpy := &CommandRouterCtx{
+		errorHandler:          c.errorHandler,
+		modalRouter:           c.modalRouter,
+		globalAllowedMentions: c.globalAllowedMentions,
+		voidGenerator:         c.voidGenerator,
+		route:                 c.route,
+		logger:                c.logger,
+		Interaction:           c.Interaction,
+		Context:               c.Context,
+		Command:               c.Command,
+		Options:               c.Options,
+		RESTClient:            c.RESTClient,
+	}
 	go func() {
+		var response *objects.InteractionResponse
 		defer func() {
 			if errGeneric := recover(); errGeneric != nil {
-				cpy.errorHandler(ungenericError(errGeneric))
+				response = cpy.errorHandler(newPanicError(errGeneric, cpy.route))
+			}
+			// Need a better way to handle this context - the one on the RouterCtx will have been cancelled already
+			// and can't be used
+			err := processUpdateLaterResponse(context.Background(), cpy.RESTClient, cpy.ApplicationID, cpy.Token, response)
+			if err != nil {
+				attrs := logAttrs(InteractionKindCommand, cpy.route, cpy.Interaction)
+				loggerOrNop(cpy.logger).Error("failed to update the response", append(attrs, "error", err)...)
 			}
 		}()
-		var response *objects.InteractionResponse
-		if err := f(&cpy); err == nil {
+		if err := f(cpy); err == nil {
 			response = cpy.buildResponse(false, cpy.errorHandler, cpy.globalAllowedMentions)
 		} else {
 			response = cpy.errorHandler(err)
 		}
-		// Need a better way to handle this context - the one on the RouterCtx will have been cancelled already
-		// and can't be used
-		processUpdateLaterResponse(context.Background(), cpy.RESTClient, cpy.ApplicationID, cpy.Token, response)
 	}()
 	return c
 }
@@ -88,23 +120,38 @@ func (c *CommandRouterCtx) WithModalPath(path string) error {
 
 // UpdateLater is used to spawn the function specified in a goroutine. When the function is returned, the result is set as a message update.
 func (c *ModalRouterCtx) UpdateLater(f func(*ModalRouterCtx) error) *ModalRouterCtx {
-	cpy := *c
-	cpy.responseBuilder = responseBuilder{}
+	// Copy everything but the response builder. The struct cannot be copied directly since the response builder has a lock.
+	cpy := &ModalRouterCtx{
+		errorHandler:          c.errorHandler,
+		globalAllowedMentions: c.globalAllowedMentions,
+		voidGenerator:         c.voidGenerator,
+		route:                 c.route,
+		logger:                c.logger,
+		Context:               c.Context,
+		Interaction:           c.Interaction,
+		Params:                c.Params,
+		ModalItems:            c.ModalItems,
+		RESTClient:            c.RESTClient,
+	}
 	go func() {
+		var response *objects.InteractionResponse
 		defer func() {
 			if errGeneric := recover(); errGeneric != nil {
-				cpy.errorHandler(ungenericError(errGeneric))
+				response = cpy.errorHandler(newPanicError(errGeneric, cpy.route))
+			}
+			// Need a better way to handle this context - the one on the RouterCtx will have been cancelled already
+			// and can't be used
+			err := processUpdateLaterResponse(context.Background(), cpy.RESTClient, cpy.ApplicationID, cpy.Token, response)
+			if err != nil {
+				attrs := logAttrs(InteractionKindModal, cpy.route, cpy.Interaction)
+				loggerOrNop(cpy.logger).Error("failed to update the response", append(attrs, "error", err)...)
 			}
 		}()
-		var response *objects.InteractionResponse
-		if err := f(&cpy); err == nil {
+		if err := f(cpy); err == nil {
 			response = cpy.buildResponse(false, cpy.errorHandler, cpy.globalAllowedMentions)
 		} else {
 			response = cpy.errorHandler(err)
 		}
-		// Need a better way to handle this context - the one on the RouterCtx will have been cancelled already
-		// and can't be used
-		processUpdateLaterResponse(context.Background(), cpy.RESTClient, cpy.ApplicationID, cpy.Token, response)
 	}()
 	return c
 }
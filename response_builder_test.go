@@ -3,6 +3,11 @@ package router
 import (
 	"bytes"
 	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,6 +16,48 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// Makes sure the generated UpdateLater functions copy every field other than the response builder. If this fails, the
+// field list in generate_response_builder.go needs updating.
+func Test_UpdateLater_fields(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "response_builder_gen.go", nil, 0)
+	require.NoError(t, err)
+
+	// Get the fields set in the copy made by each UpdateLater function.
+	copied := map[string][]string{}
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != "UpdateLater" {
+			continue
+		}
+		typeName := fn.Recv.List[0].Type.(*ast.StarExpr).X.(*ast.Ident).Name
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			lit, ok := n.(*ast.CompositeLit)
+			if !ok {
+				return true
+			}
+			if ident, ok := lit.Type.(*ast.Ident); ok && ident.Name == typeName {
+				for _, elt := range lit.Elts {
+					copied[typeName] = append(copied[typeName], elt.(*ast.KeyValueExpr).Key.(*ast.Ident).Name)
+				}
+			}
+			return true
+		})
+	}
+
+	for _, v := range []any{ComponentRouterCtx{}, CommandRouterCtx{}, ModalRouterCtx{}} {
+		typ := reflect.TypeOf(v)
+		t.Run(typ.Name(), func(t *testing.T) {
+			var fields []string
+			for i := 0; i < typ.NumField(); i++ {
+				if name := typ.Field(i).Name; !strings.HasPrefix(name, "publicResponseBuilder") {
+					fields = append(fields, name)
+				}
+			}
+			assert.ElementsMatch(t, fields, copied[typ.Name()])
+		})
+	}
+}
+
 func Test_responseBuilder_ResponseData(t *testing.T) {
 	tests := []struct {
 		name string
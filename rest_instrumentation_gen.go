@@ -0,0 +1,1021 @@
+// Code generated by generate_rest_instrumentation.go; DO NOT EDIT.
+
+package router
+
+//go:generate go run generate_rest_instrumentation.go
+
+import (
+	"context"
+	"image"
+
+	"github.com/Postcord/objects"
+	"github.com/Postcord/rest"
+)
+
+func (r restInstrumented) AddGuildCommand(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d *objects.ApplicationCommand) (*objects.ApplicationCommand, error) {
+	a, call := r.start(a, "AddGuildCommand")
+	e, f := r.rest.AddGuildCommand(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) AddGuildMember(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d *rest.AddGuildMemberParams) (*objects.GuildMember, error) {
+	a, call := r.start(a, "AddGuildMember")
+	e, f := r.rest.AddGuildMember(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) AddGuildMemberRole(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d objects.SnowflakeObject, e string) error {
+	a, call := r.start(a, "AddGuildMemberRole")
+	x := r.rest.AddGuildMemberRole(a, b, c, d, e)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) AddPinnedMessage(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) error {
+	a, call := r.start(a, "AddPinnedMessage")
+	x := r.rest.AddPinnedMessage(a, b, c)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) AddThreadMember(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) error {
+	a, call := r.start(a, "AddThreadMember")
+	x := r.rest.AddThreadMember(a, b, c)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) BatchEditApplicationCommandPermissions(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d []*objects.GuildApplicationCommandPermissions) ([]*objects.GuildApplicationCommandPermissions, error) {
+	a, call := r.start(a, "BatchEditApplicationCommandPermissions")
+	e, f := r.rest.BatchEditApplicationCommandPermissions(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) BeginGuildPrune(a context.Context, b objects.SnowflakeObject, c *rest.BeginGuildPruneParams) (int, error) {
+	a, call := r.start(a, "BeginGuildPrune")
+	d, e := r.rest.BeginGuildPrune(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) BulkDeleteMessages(a context.Context, b objects.SnowflakeObject, c *rest.DeleteMessagesParams) error {
+	a, call := r.start(a, "BulkDeleteMessages")
+	x := r.rest.BulkDeleteMessages(a, b, c)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) BulkOverwriteGlobalCommands(a context.Context, b objects.SnowflakeObject, c []*objects.ApplicationCommand) ([]*objects.ApplicationCommand, error) {
+	a, call := r.start(a, "BulkOverwriteGlobalCommands")
+	d, e := r.rest.BulkOverwriteGlobalCommands(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) BulkOverwriteGuildCommands(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d []*objects.ApplicationCommand) ([]*objects.ApplicationCommand, error) {
+	a, call := r.start(a, "BulkOverwriteGuildCommands")
+	e, f := r.rest.BulkOverwriteGuildCommands(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) CreateBan(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d *rest.CreateGuildBanParams) error {
+	a, call := r.start(a, "CreateBan")
+	x := r.rest.CreateBan(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) CreateChannelInvite(a context.Context, b objects.SnowflakeObject, c *rest.CreateInviteParams) (*objects.Invite, error) {
+	a, call := r.start(a, "CreateChannelInvite")
+	d, e := r.rest.CreateChannelInvite(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) CreateCommand(a context.Context, b objects.SnowflakeObject, c *objects.ApplicationCommand) (*objects.ApplicationCommand, error) {
+	a, call := r.start(a, "CreateCommand")
+	d, e := r.rest.CreateCommand(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) CreateDM(a context.Context, b *rest.CreateDMParams) (*objects.Channel, error) {
+	a, call := r.start(a, "CreateDM")
+	c, d := r.rest.CreateDM(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) CreateFollowupMessage(a context.Context, b objects.SnowflakeObject, c string, d *rest.CreateFollowupMessageParams) (*objects.Message, error) {
+	a, call := r.start(a, "CreateFollowupMessage")
+	e, f := r.rest.CreateFollowupMessage(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) CreateGroupDM(a context.Context, b *rest.CreateGroupDMParams) (*objects.Channel, error) {
+	a, call := r.start(a, "CreateGroupDM")
+	c, d := r.rest.CreateGroupDM(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) CreateGuild(a context.Context, b *rest.CreateGuildParams) (*objects.Guild, error) {
+	a, call := r.start(a, "CreateGuild")
+	c, d := r.rest.CreateGuild(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) CreateGuildChannel(a context.Context, b objects.SnowflakeObject, c *rest.ChannelCreateParams) (*objects.Channel, error) {
+	a, call := r.start(a, "CreateGuildChannel")
+	d, e := r.rest.CreateGuildChannel(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) CreateGuildFromTemplate(a context.Context, b string, c string) (*objects.Guild, error) {
+	a, call := r.start(a, "CreateGuildFromTemplate")
+	d, e := r.rest.CreateGuildFromTemplate(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) CreateGuildRole(a context.Context, b objects.SnowflakeObject, c *rest.CreateGuildRoleParams) (*objects.Role, error) {
+	a, call := r.start(a, "CreateGuildRole")
+	d, e := r.rest.CreateGuildRole(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) CreateGuildScheduledEvent(a context.Context, b objects.SnowflakeObject, c *rest.CreateGuildScheduledEventParams) (*objects.GuildScheduledEvent, error) {
+	a, call := r.start(a, "CreateGuildScheduledEvent")
+	d, e := r.rest.CreateGuildScheduledEvent(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) CreateGuildSticker(a context.Context, b objects.SnowflakeObject, c *rest.CreateGuildStickerParams) (*objects.Sticker, error) {
+	a, call := r.start(a, "CreateGuildSticker")
+	d, e := r.rest.CreateGuildSticker(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) CreateGuildTemplate(a context.Context, b objects.SnowflakeObject, c *rest.CreateGuildTemplateParams) (*objects.Template, error) {
+	a, call := r.start(a, "CreateGuildTemplate")
+	d, e := r.rest.CreateGuildTemplate(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) CreateInteractionResponse(a context.Context, b objects.SnowflakeObject, c string, d *objects.InteractionResponse) error {
+	a, call := r.start(a, "CreateInteractionResponse")
+	x := r.rest.CreateInteractionResponse(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) CreateMessage(a context.Context, b objects.SnowflakeObject, c *rest.CreateMessageParams) (*objects.Message, error) {
+	a, call := r.start(a, "CreateMessage")
+	d, e := r.rest.CreateMessage(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) CreateReaction(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d interface{}) error {
+	a, call := r.start(a, "CreateReaction")
+	x := r.rest.CreateReaction(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) CreateWebhook(a context.Context, b objects.SnowflakeObject, c *rest.CreateWebhookParams) (*objects.Webhook, error) {
+	a, call := r.start(a, "CreateWebhook")
+	d, e := r.rest.CreateWebhook(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) CrossPostMessage(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) (*objects.Message, error) {
+	a, call := r.start(a, "CrossPostMessage")
+	d, e := r.rest.CrossPostMessage(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) DeleteAllReactions(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) error {
+	a, call := r.start(a, "DeleteAllReactions")
+	x := r.rest.DeleteAllReactions(a, b, c)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteChannel(a context.Context, b objects.SnowflakeObject, c string) (*objects.Channel, error) {
+	a, call := r.start(a, "DeleteChannel")
+	d, e := r.rest.DeleteChannel(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) DeleteChannelPermission(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d string) error {
+	a, call := r.start(a, "DeleteChannelPermission")
+	x := r.rest.DeleteChannelPermission(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteCommand(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) error {
+	a, call := r.start(a, "DeleteCommand")
+	x := r.rest.DeleteCommand(a, b, c)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteEmojiReactions(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d interface{}) error {
+	a, call := r.start(a, "DeleteEmojiReactions")
+	x := r.rest.DeleteEmojiReactions(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteFollowupMessage(a context.Context, b objects.SnowflakeObject, c string, d objects.SnowflakeObject) error {
+	a, call := r.start(a, "DeleteFollowupMessage")
+	x := r.rest.DeleteFollowupMessage(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteGuild(a context.Context, b objects.SnowflakeObject) error {
+	a, call := r.start(a, "DeleteGuild")
+	x := r.rest.DeleteGuild(a, b)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteGuildCommand(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d objects.SnowflakeObject) error {
+	a, call := r.start(a, "DeleteGuildCommand")
+	x := r.rest.DeleteGuildCommand(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteGuildIntegration(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d string) error {
+	a, call := r.start(a, "DeleteGuildIntegration")
+	x := r.rest.DeleteGuildIntegration(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteGuildRole(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d string) error {
+	a, call := r.start(a, "DeleteGuildRole")
+	x := r.rest.DeleteGuildRole(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteGuildScheduledEvent(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) error {
+	a, call := r.start(a, "DeleteGuildScheduledEvent")
+	x := r.rest.DeleteGuildScheduledEvent(a, b, c)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteGuildSticker(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d ...string) error {
+	a, call := r.start(a, "DeleteGuildSticker")
+	x := r.rest.DeleteGuildSticker(a, b, c, d...)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteGuildTemplate(a context.Context, b objects.SnowflakeObject, c string, d string) (*objects.Template, error) {
+	a, call := r.start(a, "DeleteGuildTemplate")
+	e, f := r.rest.DeleteGuildTemplate(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) DeleteInvite(a context.Context, b string, c string) (*objects.Invite, error) {
+	a, call := r.start(a, "DeleteInvite")
+	d, e := r.rest.DeleteInvite(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) DeleteMessage(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) error {
+	a, call := r.start(a, "DeleteMessage")
+	x := r.rest.DeleteMessage(a, b, c)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteOriginalInteractionResponse(a context.Context, b objects.SnowflakeObject, c string) error {
+	a, call := r.start(a, "DeleteOriginalInteractionResponse")
+	x := r.rest.DeleteOriginalInteractionResponse(a, b, c)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteOwnReaction(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d interface{}) error {
+	a, call := r.start(a, "DeleteOwnReaction")
+	x := r.rest.DeleteOwnReaction(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeletePinnedMessage(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) error {
+	a, call := r.start(a, "DeletePinnedMessage")
+	x := r.rest.DeletePinnedMessage(a, b, c)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteUserReaction(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d objects.SnowflakeObject, e interface{}) error {
+	a, call := r.start(a, "DeleteUserReaction")
+	x := r.rest.DeleteUserReaction(a, b, c, d, e)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteWebhook(a context.Context, b objects.SnowflakeObject) error {
+	a, call := r.start(a, "DeleteWebhook")
+	x := r.rest.DeleteWebhook(a, b)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteWebhookMessage(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d string) error {
+	a, call := r.start(a, "DeleteWebhookMessage")
+	x := r.rest.DeleteWebhookMessage(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) DeleteWebhookWithToken(a context.Context, b objects.SnowflakeObject, c string) error {
+	a, call := r.start(a, "DeleteWebhookWithToken")
+	x := r.rest.DeleteWebhookWithToken(a, b, c)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) EditApplicationCommandPermissions(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d objects.SnowflakeObject, e []*objects.ApplicationCommandPermissions) (*objects.GuildApplicationCommandPermissions, error) {
+	a, call := r.start(a, "EditApplicationCommandPermissions")
+	f, g := r.rest.EditApplicationCommandPermissions(a, b, c, d, e)
+	call.end(g)
+	return f, g
+}
+
+func (r restInstrumented) EditChannelPermissions(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d *rest.EditChannelParams) error {
+	a, call := r.start(a, "EditChannelPermissions")
+	x := r.rest.EditChannelPermissions(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) EditFollowupMessage(a context.Context, b objects.SnowflakeObject, c string, d objects.SnowflakeObject, e *rest.EditWebhookMessageParams) (*objects.Message, error) {
+	a, call := r.start(a, "EditFollowupMessage")
+	f, g := r.rest.EditFollowupMessage(a, b, c, d, e)
+	call.end(g)
+	return f, g
+}
+
+func (r restInstrumented) EditMessage(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d *rest.EditMessageParams) (*objects.Message, error) {
+	a, call := r.start(a, "EditMessage")
+	e, f := r.rest.EditMessage(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) EditOriginalInteractionResponse(a context.Context, b objects.SnowflakeObject, c string, d *rest.EditWebhookMessageParams) (*objects.Message, error) {
+	a, call := r.start(a, "EditOriginalInteractionResponse")
+	e, f := r.rest.EditOriginalInteractionResponse(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) EditWebhookMessage(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d string, e *rest.EditWebhookMessageParams) (*objects.Message, error) {
+	a, call := r.start(a, "EditWebhookMessage")
+	f, g := r.rest.EditWebhookMessage(a, b, c, d, e)
+	call.end(g)
+	return f, g
+}
+
+func (r restInstrumented) ExecuteWebhook(a context.Context, b objects.SnowflakeObject, c string, d *rest.ExecuteWebhookParams) (*objects.Message, error) {
+	a, call := r.start(a, "ExecuteWebhook")
+	e, f := r.rest.ExecuteWebhook(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) FollowNewsChannel(a context.Context, b objects.SnowflakeObject) (*objects.FollowedChannel, error) {
+	a, call := r.start(a, "FollowNewsChannel")
+	c, d := r.rest.FollowNewsChannel(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) Gateway(a context.Context) (*objects.Gateway, error) {
+	a, call := r.start(a, "Gateway")
+	b, c := r.rest.Gateway(a)
+	call.end(c)
+	return b, c
+}
+
+func (r restInstrumented) GatewayBot(a context.Context) (*objects.Gateway, error) {
+	a, call := r.start(a, "GatewayBot")
+	b, c := r.rest.GatewayBot(a)
+	call.end(c)
+	return b, c
+}
+
+func (r restInstrumented) GetApplicationCommandPermissions(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d objects.SnowflakeObject) (*objects.GuildApplicationCommandPermissions, error) {
+	a, call := r.start(a, "GetApplicationCommandPermissions")
+	e, f := r.rest.GetApplicationCommandPermissions(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) GetAuditLogs(a context.Context, b objects.SnowflakeObject, c *rest.GetAuditLogParams) (*objects.AuditLog, error) {
+	a, call := r.start(a, "GetAuditLogs")
+	d, e := r.rest.GetAuditLogs(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) GetChannel(a context.Context, b objects.SnowflakeObject) (*objects.Channel, error) {
+	a, call := r.start(a, "GetChannel")
+	c, d := r.rest.GetChannel(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetChannelInvites(a context.Context, b objects.SnowflakeObject) ([]*objects.Invite, error) {
+	a, call := r.start(a, "GetChannelInvites")
+	c, d := r.rest.GetChannelInvites(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetChannelMessage(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) (*objects.Message, error) {
+	a, call := r.start(a, "GetChannelMessage")
+	d, e := r.rest.GetChannelMessage(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) GetChannelMessages(a context.Context, b objects.SnowflakeObject, c *rest.GetChannelMessagesParams) ([]*objects.Message, error) {
+	a, call := r.start(a, "GetChannelMessages")
+	d, e := r.rest.GetChannelMessages(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) GetChannelWebhooks(a context.Context, b objects.SnowflakeObject) ([]*objects.Webhook, error) {
+	a, call := r.start(a, "GetChannelWebhooks")
+	c, d := r.rest.GetChannelWebhooks(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetCommand(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) (*objects.ApplicationCommand, error) {
+	a, call := r.start(a, "GetCommand")
+	d, e := r.rest.GetCommand(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) GetCommands(a context.Context, b objects.SnowflakeObject) ([]*objects.ApplicationCommand, error) {
+	a, call := r.start(a, "GetCommands")
+	c, d := r.rest.GetCommands(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetCurrentUser(a context.Context) (*objects.User, error) {
+	a, call := r.start(a, "GetCurrentUser")
+	b, c := r.rest.GetCurrentUser(a)
+	call.end(c)
+	return b, c
+}
+
+func (r restInstrumented) GetCurrentUserGuildMember(a context.Context, b objects.SnowflakeObject) (*objects.GuildMember, error) {
+	a, call := r.start(a, "GetCurrentUserGuildMember")
+	c, d := r.rest.GetCurrentUserGuildMember(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetCurrentUserGuilds(a context.Context, b *rest.CurrentUserGuildsParams) ([]*objects.Guild, error) {
+	a, call := r.start(a, "GetCurrentUserGuilds")
+	c, d := r.rest.GetCurrentUserGuilds(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetFollowupMessage(a context.Context, b objects.SnowflakeObject, c string, d objects.SnowflakeObject) (*objects.Message, error) {
+	a, call := r.start(a, "GetFollowupMessage")
+	e, f := r.rest.GetFollowupMessage(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) GetGuild(a context.Context, b objects.SnowflakeObject) (*objects.Guild, error) {
+	a, call := r.start(a, "GetGuild")
+	c, d := r.rest.GetGuild(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetGuildApplicationCommandPermissions(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) ([]*objects.GuildApplicationCommandPermissions, error) {
+	a, call := r.start(a, "GetGuildApplicationCommandPermissions")
+	d, e := r.rest.GetGuildApplicationCommandPermissions(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) GetGuildBan(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) (*objects.Ban, error) {
+	a, call := r.start(a, "GetGuildBan")
+	d, e := r.rest.GetGuildBan(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) GetGuildBans(a context.Context, b objects.SnowflakeObject) ([]*objects.Ban, error) {
+	a, call := r.start(a, "GetGuildBans")
+	c, d := r.rest.GetGuildBans(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetGuildChannels(a context.Context, b objects.SnowflakeObject) ([]*objects.Channel, error) {
+	a, call := r.start(a, "GetGuildChannels")
+	c, d := r.rest.GetGuildChannels(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetGuildCommand(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d objects.SnowflakeObject) (*objects.ApplicationCommand, error) {
+	a, call := r.start(a, "GetGuildCommand")
+	e, f := r.rest.GetGuildCommand(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) GetGuildCommands(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) ([]*objects.ApplicationCommand, error) {
+	a, call := r.start(a, "GetGuildCommands")
+	d, e := r.rest.GetGuildCommands(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) GetGuildIntegrations(a context.Context, b objects.SnowflakeObject) ([]*objects.Integration, error) {
+	a, call := r.start(a, "GetGuildIntegrations")
+	c, d := r.rest.GetGuildIntegrations(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetGuildInvites(a context.Context, b objects.SnowflakeObject) ([]*objects.Invite, error) {
+	a, call := r.start(a, "GetGuildInvites")
+	c, d := r.rest.GetGuildInvites(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetGuildMember(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) (*objects.GuildMember, error) {
+	a, call := r.start(a, "GetGuildMember")
+	d, e := r.rest.GetGuildMember(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) GetGuildPreview(a context.Context, b objects.SnowflakeObject) (*objects.GuildPreview, error) {
+	a, call := r.start(a, "GetGuildPreview")
+	c, d := r.rest.GetGuildPreview(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetGuildPruneCount(a context.Context, b objects.SnowflakeObject, c *rest.GetGuildPruneCountParams) (int, error) {
+	a, call := r.start(a, "GetGuildPruneCount")
+	d, e := r.rest.GetGuildPruneCount(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) GetGuildRoles(a context.Context, b objects.SnowflakeObject) ([]*objects.Role, error) {
+	a, call := r.start(a, "GetGuildRoles")
+	c, d := r.rest.GetGuildRoles(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetGuildScheduledEvent(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d ...*rest.GetGuildScheduledEventParams) (*objects.GuildScheduledEvent, error) {
+	a, call := r.start(a, "GetGuildScheduledEvent")
+	e, f := r.rest.GetGuildScheduledEvent(a, b, c, d...)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) GetGuildScheduledEventUsers(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d ...*rest.GetGuildScheduledEventUsersParams) ([]*objects.GuildScheduledEventUser, error) {
+	a, call := r.start(a, "GetGuildScheduledEventUsers")
+	e, f := r.rest.GetGuildScheduledEventUsers(a, b, c, d...)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) GetGuildSticker(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) (*objects.Sticker, error) {
+	a, call := r.start(a, "GetGuildSticker")
+	d, e := r.rest.GetGuildSticker(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) GetGuildTemplates(a context.Context, b objects.SnowflakeObject) ([]*objects.Template, error) {
+	a, call := r.start(a, "GetGuildTemplates")
+	c, d := r.rest.GetGuildTemplates(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetGuildVanityURL(a context.Context, b objects.SnowflakeObject) (*objects.Invite, error) {
+	a, call := r.start(a, "GetGuildVanityURL")
+	c, d := r.rest.GetGuildVanityURL(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetGuildVoiceRegions(a context.Context, b objects.SnowflakeObject) ([]*objects.VoiceRegion, error) {
+	a, call := r.start(a, "GetGuildVoiceRegions")
+	c, d := r.rest.GetGuildVoiceRegions(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetGuildWebhooks(a context.Context, b objects.SnowflakeObject) ([]*objects.Webhook, error) {
+	a, call := r.start(a, "GetGuildWebhooks")
+	c, d := r.rest.GetGuildWebhooks(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetGuildWelcomeScreen(a context.Context, b objects.SnowflakeObject) (*objects.MembershipScreening, error) {
+	a, call := r.start(a, "GetGuildWelcomeScreen")
+	c, d := r.rest.GetGuildWelcomeScreen(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetGuildWidget(a context.Context, b objects.SnowflakeObject) (*objects.GuildWidgetJSON, error) {
+	a, call := r.start(a, "GetGuildWidget")
+	c, d := r.rest.GetGuildWidget(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetGuildWidgetImage(a context.Context, b objects.SnowflakeObject, c *rest.GuildWidgetImageParams) (image.Image, error) {
+	a, call := r.start(a, "GetGuildWidgetImage")
+	d, e := r.rest.GetGuildWidgetImage(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) GetGuildWidgetSettings(a context.Context, b objects.SnowflakeObject) (*objects.GuildWidget, error) {
+	a, call := r.start(a, "GetGuildWidgetSettings")
+	c, d := r.rest.GetGuildWidgetSettings(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetInvite(a context.Context, b string, c *rest.GetInviteParams) (*objects.Invite, error) {
+	a, call := r.start(a, "GetInvite")
+	d, e := r.rest.GetInvite(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) GetOriginalInteractionResponse(a context.Context, b objects.SnowflakeObject, c string) (*objects.Message, error) {
+	a, call := r.start(a, "GetOriginalInteractionResponse")
+	d, e := r.rest.GetOriginalInteractionResponse(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) GetPinnedMessages(a context.Context, b objects.SnowflakeObject) ([]*objects.Message, error) {
+	a, call := r.start(a, "GetPinnedMessages")
+	c, d := r.rest.GetPinnedMessages(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetReactions(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d interface{}, e *rest.GetReactionsParams) ([]*objects.User, error) {
+	a, call := r.start(a, "GetReactions")
+	f, g := r.rest.GetReactions(a, b, c, d, e)
+	call.end(g)
+	return f, g
+}
+
+func (r restInstrumented) GetSticker(a context.Context, b objects.SnowflakeObject) (*objects.Sticker, error) {
+	a, call := r.start(a, "GetSticker")
+	c, d := r.rest.GetSticker(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetTemplate(a context.Context, b string) (*objects.Template, error) {
+	a, call := r.start(a, "GetTemplate")
+	c, d := r.rest.GetTemplate(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetUser(a context.Context, b objects.SnowflakeObject) (*objects.User, error) {
+	a, call := r.start(a, "GetUser")
+	c, d := r.rest.GetUser(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetUserConnections(a context.Context) ([]*objects.Connection, error) {
+	a, call := r.start(a, "GetUserConnections")
+	b, c := r.rest.GetUserConnections(a)
+	call.end(c)
+	return b, c
+}
+
+func (r restInstrumented) GetVoiceRegions(a context.Context) ([]*objects.VoiceRegion, error) {
+	a, call := r.start(a, "GetVoiceRegions")
+	b, c := r.rest.GetVoiceRegions(a)
+	call.end(c)
+	return b, c
+}
+
+func (r restInstrumented) GetWebhook(a context.Context, b objects.SnowflakeObject) (*objects.Webhook, error) {
+	a, call := r.start(a, "GetWebhook")
+	c, d := r.rest.GetWebhook(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) GetWebhookWithToken(a context.Context, b objects.SnowflakeObject, c string) (*objects.Webhook, error) {
+	a, call := r.start(a, "GetWebhookWithToken")
+	d, e := r.rest.GetWebhookWithToken(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) JoinThread(a context.Context, b objects.SnowflakeObject) error {
+	a, call := r.start(a, "JoinThread")
+	x := r.rest.JoinThread(a, b)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) LeaveGuild(a context.Context, b objects.SnowflakeObject) error {
+	a, call := r.start(a, "LeaveGuild")
+	x := r.rest.LeaveGuild(a, b)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) LeaveThread(a context.Context, b objects.SnowflakeObject) error {
+	a, call := r.start(a, "LeaveThread")
+	x := r.rest.LeaveThread(a, b)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) ListActiveThreads(a context.Context, b objects.SnowflakeObject) ([]*rest.ListThreadsResponse, error) {
+	a, call := r.start(a, "ListActiveThreads")
+	c, d := r.rest.ListActiveThreads(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) ListGuildMembers(a context.Context, b objects.SnowflakeObject, c *rest.ListGuildMembersParams) ([]*objects.GuildMember, error) {
+	a, call := r.start(a, "ListGuildMembers")
+	d, e := r.rest.ListGuildMembers(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) ListGuildStickers(a context.Context, b objects.SnowflakeObject) ([]*objects.Sticker, error) {
+	a, call := r.start(a, "ListGuildStickers")
+	c, d := r.rest.ListGuildStickers(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) ListJoinedPrivateArchivedThreads(a context.Context, b objects.SnowflakeObject, c ...*rest.ListThreadsParams) (*rest.ListThreadsResponse, error) {
+	a, call := r.start(a, "ListJoinedPrivateArchivedThreads")
+	d, e := r.rest.ListJoinedPrivateArchivedThreads(a, b, c...)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) ListNitroStickerPacks(a context.Context) ([]*objects.StickerPack, error) {
+	a, call := r.start(a, "ListNitroStickerPacks")
+	b, c := r.rest.ListNitroStickerPacks(a)
+	call.end(c)
+	return b, c
+}
+
+func (r restInstrumented) ListPrivateArchivedThreads(a context.Context, b objects.SnowflakeObject, c ...*rest.ListThreadsParams) (*rest.ListThreadsResponse, error) {
+	a, call := r.start(a, "ListPrivateArchivedThreads")
+	d, e := r.rest.ListPrivateArchivedThreads(a, b, c...)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) ListPublicArchivedThreads(a context.Context, b objects.SnowflakeObject, c ...*rest.ListThreadsParams) (*rest.ListThreadsResponse, error) {
+	a, call := r.start(a, "ListPublicArchivedThreads")
+	d, e := r.rest.ListPublicArchivedThreads(a, b, c...)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) ListThreadMembers(a context.Context, b objects.SnowflakeObject) ([]*objects.ThreadMember, error) {
+	a, call := r.start(a, "ListThreadMembers")
+	c, d := r.rest.ListThreadMembers(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) ModifyChannel(a context.Context, b objects.SnowflakeObject, c *rest.ModifyChannelParams) (*objects.Channel, error) {
+	a, call := r.start(a, "ModifyChannel")
+	d, e := r.rest.ModifyChannel(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) ModifyCurrentUser(a context.Context, b *rest.ModifyCurrentUserParams) (*objects.User, error) {
+	a, call := r.start(a, "ModifyCurrentUser")
+	c, d := r.rest.ModifyCurrentUser(a, b)
+	call.end(d)
+	return c, d
+}
+
+func (r restInstrumented) ModifyCurrentUserNick(a context.Context, b objects.SnowflakeObject, c *rest.ModifyCurrentUserNickParams) (*rest.ModifyCurrentUserNickParams, error) {
+	a, call := r.start(a, "ModifyCurrentUserNick")
+	d, e := r.rest.ModifyCurrentUserNick(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) ModifyGuild(a context.Context, b objects.SnowflakeObject, c *rest.ModifyGuildParams) (*objects.Guild, error) {
+	a, call := r.start(a, "ModifyGuild")
+	d, e := r.rest.ModifyGuild(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) ModifyGuildChannelPositions(a context.Context, b objects.SnowflakeObject, c []*rest.ModifyChannelPositionParams, d string) error {
+	a, call := r.start(a, "ModifyGuildChannelPositions")
+	x := r.rest.ModifyGuildChannelPositions(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) ModifyGuildMember(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d *rest.ModifyGuildMemberParams) (*objects.GuildMember, error) {
+	a, call := r.start(a, "ModifyGuildMember")
+	e, f := r.rest.ModifyGuildMember(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) ModifyGuildRole(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d *rest.ModifyGuildRoleParams) (*objects.Role, error) {
+	a, call := r.start(a, "ModifyGuildRole")
+	e, f := r.rest.ModifyGuildRole(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) ModifyGuildRolePositions(a context.Context, b objects.SnowflakeObject, c []*rest.ModifyGuildRolePositionsParams) ([]*objects.Role, error) {
+	a, call := r.start(a, "ModifyGuildRolePositions")
+	d, e := r.rest.ModifyGuildRolePositions(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) ModifyGuildScheduledEvent(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d *rest.ModifyGuildScheduledEventParams) (*objects.GuildScheduledEvent, error) {
+	a, call := r.start(a, "ModifyGuildScheduledEvent")
+	e, f := r.rest.ModifyGuildScheduledEvent(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) ModifyGuildSticker(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d *rest.BaseStickerParams) (*objects.Sticker, error) {
+	a, call := r.start(a, "ModifyGuildSticker")
+	e, f := r.rest.ModifyGuildSticker(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) ModifyGuildTemplate(a context.Context, b objects.SnowflakeObject, c string, d *rest.ModifyGuildTemplateParams) (*objects.Template, error) {
+	a, call := r.start(a, "ModifyGuildTemplate")
+	e, f := r.rest.ModifyGuildTemplate(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) ModifyGuildWelcomeScreen(a context.Context, b objects.SnowflakeObject, c *rest.ModifyGuildMembershipScreeningParams) (*objects.MembershipScreening, error) {
+	a, call := r.start(a, "ModifyGuildWelcomeScreen")
+	d, e := r.rest.ModifyGuildWelcomeScreen(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) ModifyGuildWidget(a context.Context, b objects.SnowflakeObject, c *rest.GuildWidgetParams) (*objects.GuildWidget, error) {
+	a, call := r.start(a, "ModifyGuildWidget")
+	d, e := r.rest.ModifyGuildWidget(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) ModifyWebhook(a context.Context, b objects.SnowflakeObject, c *rest.ModifyWebhookParams) (*objects.Webhook, error) {
+	a, call := r.start(a, "ModifyWebhook")
+	d, e := r.rest.ModifyWebhook(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) ModifyWebhookWithToken(a context.Context, b objects.SnowflakeObject, c string, d *rest.ModifyWebhookWithTokenParams) (*objects.Webhook, error) {
+	a, call := r.start(a, "ModifyWebhookWithToken")
+	e, f := r.rest.ModifyWebhookWithToken(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) RemoveGuildBan(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d string) error {
+	a, call := r.start(a, "RemoveGuildBan")
+	x := r.rest.RemoveGuildBan(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) RemoveGuildMember(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d string) error {
+	a, call := r.start(a, "RemoveGuildMember")
+	x := r.rest.RemoveGuildMember(a, b, c, d)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) RemoveGuildMemberRole(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d objects.SnowflakeObject, e string) error {
+	a, call := r.start(a, "RemoveGuildMemberRole")
+	x := r.rest.RemoveGuildMemberRole(a, b, c, d, e)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) RemoveThreadMember(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject) error {
+	a, call := r.start(a, "RemoveThreadMember")
+	x := r.rest.RemoveThreadMember(a, b, c)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) StartThread(a context.Context, b objects.SnowflakeObject, c *rest.StartThreadParams) (*objects.Channel, error) {
+	a, call := r.start(a, "StartThread")
+	d, e := r.rest.StartThread(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) StartThreadWithMessage(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d *rest.StartThreadParams) (*objects.Channel, error) {
+	a, call := r.start(a, "StartThreadWithMessage")
+	e, f := r.rest.StartThreadWithMessage(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) StartTyping(a context.Context, b objects.SnowflakeObject) error {
+	a, call := r.start(a, "StartTyping")
+	x := r.rest.StartTyping(a, b)
+	call.end(x)
+	return x
+}
+
+func (r restInstrumented) SyncGuildTemplate(a context.Context, b objects.SnowflakeObject, c string) (*objects.Template, error) {
+	a, call := r.start(a, "SyncGuildTemplate")
+	d, e := r.rest.SyncGuildTemplate(a, b, c)
+	call.end(e)
+	return d, e
+}
+
+func (r restInstrumented) UpdateCommand(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d *objects.ApplicationCommand) (*objects.ApplicationCommand, error) {
+	a, call := r.start(a, "UpdateCommand")
+	e, f := r.rest.UpdateCommand(a, b, c, d)
+	call.end(f)
+	return e, f
+}
+
+func (r restInstrumented) UpdateGuildCommand(a context.Context, b objects.SnowflakeObject, c objects.SnowflakeObject, d objects.SnowflakeObject, e *objects.ApplicationCommand) (*objects.ApplicationCommand, error) {
+	a, call := r.start(a, "UpdateGuildCommand")
+	f, g := r.rest.UpdateGuildCommand(a, b, c, d, e)
+	call.end(g)
+	return f, g
+}
@@ -0,0 +1,228 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Postcord/objects"
+)
+
+// InvalidRouteParam is returned when a parameter in a custom ID does not match the type it was annotated with in the
+// route. Since custom IDs can be manipulated by users, this is passed to the error handler as a router error.
+var InvalidRouteParam = errors.New("route parameter is invalid")
+
+// RouteParamError is used to define the parameter which failed to validate. This unwraps to InvalidRouteParam.
+type RouteParamError struct {
+	// Param is the name of the parameter.
+	Param string
+
+	// Value is the value which was in the custom ID.
+	Value string
+
+	// Type is the type annotation of the parameter, for example "int" or "enum:a|b".
+	Type string
+}
+
+// Error implements the error interface.
+func (e *RouteParamError) Error() string {
+	return fmt.Sprintf("route parameter %q has the value %q which is not a valid %s", e.Param, e.Value, e.Type)
+}
+
+// Unwrap is used to get InvalidRouteParam.
+func (e *RouteParamError) Unwrap() error {
+	return InvalidRouteParam
+}
+
+// Defines a parser for a route parameter value. The boolean is false if the value is invalid.
+type routeParamParser = func(value string) (any, bool)
+
+// Creates the parser for the type annotation.
+func newRouteParamParser(typ, arg string) (routeParamParser, error) {
+	if arg != "" && typ != "enum" && typ != "regex" {
+		return nil, fmt.Errorf("the %s type does not take an argument", typ)
+	}
+	switch typ {
+	case "int":
+		return func(value string) (any, bool) {
+			x, err := strconv.ParseInt(value, 10, 64)
+			return x, err == nil
+		}, nil
+	case "uint":
+		return func(value string) (any, bool) {
+			x, err := strconv.ParseUint(value, 10, 64)
+			return x, err == nil
+		}, nil
+	case "snowflake":
+		return func(value string) (any, bool) {
+			x, err := strconv.ParseUint(value, 10, 64)
+			return objects.Snowflake(x), err == nil
+		}, nil
+	case "enum":
+		if arg == "" {
+			return nil, errors.New("the enum type requires values")
+		}
+		values := strings.Split(arg, "|")
+		return func(value string) (any, bool) {
+			for _, v := range values {
+				if v == value {
+					return value, true
+				}
+			}
+			return value, false
+		}, nil
+	case "regex":
+		re, err := regexp.Compile("^(?:" + arg + ")$")
+		if err != nil {
+			return nil, err
+		}
+		return func(value string) (any, bool) {
+			return value, re.MatchString(value)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown type %q", typ)
+	}
+}
+
+// Defines a parameter in a route which has a type annotation.
+type routeParam struct {
+	name  string
+	typ   string
+	parse routeParamParser
+}
+
+// Parses the type annotations out of a route, returning the path which is used in the tree. A type annotation is put
+// after the parameter name in angle brackets, for example "/set/:number<uint>" or "/mode/:mode<enum:a|b>". This panics
+// if an annotation is invalid since it is a programming error.
+func parseRoutePattern(route string) (string, []routeParam) {
+	var b strings.Builder
+	var params []routeParam
+	for i := 0; i < len(route); i++ {
+		c := route[i]
+		b.WriteByte(c)
+		if c != ':' && c != '*' {
+			continue
+		}
+
+		// Get the parameter name.
+		end := i + 1
+		for end < len(route) && route[end] != '/' && route[end] != '<' {
+			end++
+		}
+		name := route[i+1 : end]
+		b.WriteString(name)
+		i = end - 1
+		if end == len(route) || route[end] != '<' {
+			continue
+		}
+
+		// Find the closing bracket. This is counted so regex named groups can be used.
+		depth, closing := 0, -1
+		for j := end; j < len(route) && closing == -1; j++ {
+			switch route[j] {
+			case '<':
+				depth++
+			case '>':
+				depth--
+				if depth == 0 {
+					closing = j
+				}
+			}
+		}
+		if closing == -1 {
+			panic("unterminated type annotation for '" + name + "' in path '" + route + "'")
+		}
+		if closing+1 < len(route) && route[closing+1] != '/' {
+			panic("type annotation for '" + name + "' must end the path segment in path '" + route + "'")
+		}
+		typ := route[end+1 : closing]
+		kind, arg, _ := strings.Cut(typ, ":")
+		parse, err := newRouteParamParser(kind, arg)
+		if err != nil {
+			panic("invalid type annotation for '" + name + "' in path '" + route + "': " + err.Error())
+		}
+		params = append(params, routeParam{name: name, typ: typ, parse: parse})
+		i = closing
+	}
+	return b.String(), params
+}
+
+// Gets the path of the route without any type annotations. This is used for the test frame folders.
+func routePath(route string) string {
+	path, _ := parseRoutePattern(route)
+	return path
+}
+
+// Adds a route which can contain type annotations to the tree. The annotations are stored in the handle so they can be
+// validated when the route is matched.
+func (n *node) addTypedRoute(route string, handle *routeContext) {
+	path, params := parseRoutePattern(route)
+	handle.params = params
+	n.addRoute(path, handle)
+}
+
+// Gets the handle for the path and validates the parameters against their type annotations. If the path does not
+// match a route, the handle is nil. If a parameter is invalid, a *RouteParamError is returned with the handle.
+func (n *node) match(path string, params map[string]string) (*routeContext, error) {
+	handle := n.getValue(path, params)
+	if handle == nil {
+		return nil, nil
+	}
+	for _, p := range handle.params {
+		value := params[p.name]
+		if _, ok := p.parse(value); !ok {
+			return handle, &RouteParamError{Param: p.name, Value: value, Type: p.typ}
+		}
+	}
+	return handle, nil
+}
+
+// Parses the parameter as the type specified.
+func parseParam[T any](params map[string]string, name, typ string) (T, error) {
+	var zero T
+	value := params[name]
+	parse, _ := newRouteParamParser(typ, "")
+	x, ok := parse(value)
+	if !ok {
+		return zero, &RouteParamError{Param: name, Value: value, Type: typ}
+	}
+	return x.(T), nil
+}
+
+// ParamInt is used to get the parameter as an int64. If the parameter is not a valid integer, a *RouteParamError is
+// returned. This will not error if the parameter is annotated with the int type in the route.
+func (c *ComponentRouterCtx) ParamInt(name string) (int64, error) {
+	return parseParam[int64](c.Params, name, "int")
+}
+
+// ParamUint is used to get the parameter as a uint64. If the parameter is not a valid unsigned integer, a
+// *RouteParamError is returned. This will not error if the parameter is annotated with the uint type in the route.
+func (c *ComponentRouterCtx) ParamUint(name string) (uint64, error) {
+	return parseParam[uint64](c.Params, name, "uint")
+}
+
+// ParamSnowflake is used to get the parameter as a snowflake. If the parameter is not a valid snowflake, a
+// *RouteParamError is returned. This will not error if the parameter is annotated with the snowflake type in the route.
+func (c *ComponentRouterCtx) ParamSnowflake(name string) (objects.Snowflake, error) {
+	return parseParam[objects.Snowflake](c.Params, name, "snowflake")
+}
+
+// ParamInt is used to get the parameter as an int64. If the parameter is not a valid integer, a *RouteParamError is
+// returned. This will not error if the parameter is annotated with the int type in the route.
+func (c *ModalRouterCtx) ParamInt(name string) (int64, error) {
+	return parseParam[int64](c.Params, name, "int")
+}
+
+// ParamUint is used to get the parameter as a uint64. If the parameter is not a valid unsigned integer, a
+// *RouteParamError is returned. This will not error if the parameter is annotated with the uint type in the route.
+func (c *ModalRouterCtx) ParamUint(name string) (uint64, error) {
+	return parseParam[uint64](c.Params, name, "uint")
+}
+
+// ParamSnowflake is used to get the parameter as a snowflake. If the parameter is not a valid snowflake, a
+// *RouteParamError is returned. This will not error if the parameter is annotated with the snowflake type in the route.
+func (c *ModalRouterCtx) ParamSnowflake(name string) (objects.Snowflake, error) {
+	return parseParam[objects.Snowflake](c.Params, name, "snowflake")
+}
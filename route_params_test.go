@@ -0,0 +1,333 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteParamError(t *testing.T) {
+	err := &RouteParamError{Param: "n", Value: "abc", Type: "int"}
+	assert.Equal(t, `route parameter "n" has the value "abc" which is not a valid int`, err.Error())
+	assert.ErrorIs(t, err, InvalidRouteParam)
+}
+
+func Test_parseRoutePattern(t *testing.T) {
+	tests := []struct {
+		name string
+
+		route        string
+		expectsPath  string
+		expectsTypes map[string]string
+		panics       string
+	}{
+		{
+			name:        "no params",
+			route:       "/a/b",
+			expectsPath: "/a/b",
+		},
+		{
+			name:        "untyped params",
+			route:       "/a/:b/*c",
+			expectsPath: "/a/:b/*c",
+		},
+		{
+			name:         "typed params",
+			route:        "/a/:b<int>/:c<uint>/:d<snowflake>/:e",
+			expectsPath:  "/a/:b/:c/:d/:e",
+			expectsTypes: map[string]string{"b": "int", "c": "uint", "d": "snowflake"},
+		},
+		{
+			name:         "enum",
+			route:        "/mode/:mode<enum:a|b:c>",
+			expectsPath:  "/mode/:mode",
+			expectsTypes: map[string]string{"mode": "enum:a|b:c"},
+		},
+		{
+			name:         "regex",
+			route:        "/code/:code<regex:(?P<x>[a-z*]+)>/a",
+			expectsPath:  "/code/:code/a",
+			expectsTypes: map[string]string{"code": "regex:(?P<x>[a-z*]+)"},
+		},
+		{
+			name:         "catch all",
+			route:        "/a/*b<regex:.+>",
+			expectsPath:  "/a/*b",
+			expectsTypes: map[string]string{"b": "regex:.+"},
+		},
+		{
+			name:   "unterminated",
+			route:  "/a/:b<int",
+			panics: "unterminated type annotation for 'b' in path '/a/:b<int'",
+		},
+		{
+			name:   "not end of segment",
+			route:  "/a/:b<int>c",
+			panics: "type annotation for 'b' must end the path segment in path '/a/:b<int>c'",
+		},
+		{
+			name:   "unknown type",
+			route:  "/a/:b<float>",
+			panics: `invalid type annotation for 'b' in path '/a/:b<float>': unknown type "float"`,
+		},
+		{
+			name:   "argument on int",
+			route:  "/a/:b<int:1>",
+			panics: "invalid type annotation for 'b' in path '/a/:b<int:1>': the int type does not take an argument",
+		},
+		{
+			name:   "empty enum",
+			route:  "/a/:b<enum>",
+			panics: "invalid type annotation for 'b' in path '/a/:b<enum>': the enum type requires values",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.panics != "" {
+				assert.PanicsWithValue(t, tt.panics, func() { parseRoutePattern(tt.route) })
+				return
+			}
+			path, params := parseRoutePattern(tt.route)
+			assert.Equal(t, tt.expectsPath, path)
+			var types map[string]string
+			for _, p := range params {
+				if types == nil {
+					types = map[string]string{}
+				}
+				types[p.name] = p.typ
+			}
+			assert.Equal(t, tt.expectsTypes, types)
+		})
+	}
+}
+
+func Test_node_match(t *testing.T) {
+	root := &node{}
+	for _, route := range []string{
+		"/int/:n<int>",
+		"/uint/:n<uint>",
+		"/snowflake/:n<snowflake>",
+		"/enum/:n<enum:a|b>",
+		"/regex/:n<regex:[a-z]+>",
+		"/untyped/:n",
+	} {
+		root.addTypedRoute(route, &routeContext{r: route})
+	}
+
+	tests := []struct {
+		name string
+
+		path         string
+		expectsRoute string
+		expectsErr   *RouteParamError
+	}{
+		{name: "not found", path: "/a"},
+		{name: "valid int", path: "/int/-1", expectsRoute: "/int/:n<int>"},
+		{
+			name:         "invalid int",
+			path:         "/int/a",
+			expectsRoute: "/int/:n<int>",
+			expectsErr:   &RouteParamError{Param: "n", Value: "a", Type: "int"},
+		},
+		{name: "valid uint", path: "/uint/1", expectsRoute: "/uint/:n<uint>"},
+		{
+			name:         "invalid uint",
+			path:         "/uint/-1",
+			expectsRoute: "/uint/:n<uint>",
+			expectsErr:   &RouteParamError{Param: "n", Value: "-1", Type: "uint"},
+		},
+		{name: "valid snowflake", path: "/snowflake/1234", expectsRoute: "/snowflake/:n<snowflake>"},
+		{
+			name:         "invalid snowflake",
+			path:         "/snowflake/1.5",
+			expectsRoute: "/snowflake/:n<snowflake>",
+			expectsErr:   &RouteParamError{Param: "n", Value: "1.5", Type: "snowflake"},
+		},
+		{name: "valid enum", path: "/enum/b", expectsRoute: "/enum/:n<enum:a|b>"},
+		{
+			name:         "invalid enum",
+			path:         "/enum/c",
+			expectsRoute: "/enum/:n<enum:a|b>",
+			expectsErr:   &RouteParamError{Param: "n", Value: "c", Type: "enum:a|b"},
+		},
+		{name: "valid regex", path: "/regex/abc", expectsRoute: "/regex/:n<regex:[a-z]+>"},
+		{
+			name:         "invalid regex",
+			path:         "/regex/abc1",
+			expectsRoute: "/regex/:n<regex:[a-z]+>",
+			expectsErr:   &RouteParamError{Param: "n", Value: "abc1", Type: "regex:[a-z]+"},
+		},
+		{name: "untyped", path: "/untyped/anything", expectsRoute: "/untyped/:n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handle, err := root.match(tt.path, map[string]string{})
+			if tt.expectsRoute == "" {
+				assert.Nil(t, handle)
+			} else {
+				require.NotNil(t, handle)
+				assert.Equal(t, tt.expectsRoute, handle.r)
+			}
+			if tt.expectsErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tt.expectsErr, err)
+			}
+		})
+	}
+}
+
+func TestParamAccessors(t *testing.T) {
+	params := map[string]string{"int": "-1", "uint": "1", "snowflake": "1234", "bad": "a"}
+	contexts := map[string]interface {
+		ParamInt(string) (int64, error)
+		ParamUint(string) (uint64, error)
+		ParamSnowflake(string) (objects.Snowflake, error)
+	}{
+		"component": &ComponentRouterCtx{Params: params},
+		"modal":     &ModalRouterCtx{Params: params},
+	}
+	for name, ctx := range contexts {
+		t.Run(name, func(t *testing.T) {
+			i, err := ctx.ParamInt("int")
+			assert.NoError(t, err)
+			assert.Equal(t, int64(-1), i)
+			u, err := ctx.ParamUint("uint")
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(1), u)
+			s, err := ctx.ParamSnowflake("snowflake")
+			assert.NoError(t, err)
+			assert.Equal(t, objects.Snowflake(1234), s)
+
+			_, err = ctx.ParamInt("bad")
+			assert.Equal(t, &RouteParamError{Param: "bad", Value: "a", Type: "int"}, err)
+			_, err = ctx.ParamUint("int")
+			assert.Equal(t, &RouteParamError{Param: "int", Value: "-1", Type: "uint"}, err)
+			_, err = ctx.ParamSnowflake("missing")
+			assert.Equal(t, &RouteParamError{Param: "missing", Value: "", Type: "snowflake"}, err)
+		})
+	}
+}
+
+func TestTypedRouteParams(t *testing.T) {
+	var called bool
+	componentRouter := &ComponentRouter{}
+	componentRouter.RegisterButton("/set/:n<uint>", func(ctx *ComponentRouterCtx) error {
+		called = true
+		n, err := ctx.ParamUint("n")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), n)
+		return nil
+	})
+	modalRouter := &ModalRouter{}
+	modalRouter.AddModal(&ModalContent{
+		Path: "/modal/:n<int>",
+		Function: func(ctx *ModalRouterCtx) error {
+			called = true
+			n, err := ctx.ParamInt("n")
+			assert.NoError(t, err)
+			assert.Equal(t, int64(5), n)
+			ctx.SetContent("hello")
+			return nil
+		},
+	})
+
+	tests := []struct {
+		name string
+
+		handle       func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse
+		interaction  *objects.Interaction
+		expectsRoute string
+		expectsKind  InteractionKind
+		expectsErr   *RouteParamError
+	}{
+		{
+			name: "valid component",
+			handle: func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse {
+				return componentRouter.build(modalRouter, loader)(context.Background(), interaction)
+			},
+			interaction: mockInteraction(&objects.ApplicationComponentInteractionData{
+				CustomID:      "/set/5",
+				ComponentType: objects.ComponentTypeButton,
+			}),
+		},
+		{
+			name: "invalid component",
+			handle: func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse {
+				return componentRouter.build(modalRouter, loader)(context.Background(), interaction)
+			},
+			interaction: mockInteraction(&objects.ApplicationComponentInteractionData{
+				CustomID:      "/set/-5",
+				ComponentType: objects.ComponentTypeButton,
+			}),
+			expectsRoute: "/set/:n<uint>",
+			expectsKind:  InteractionKindComponent,
+			expectsErr:   &RouteParamError{Param: "n", Value: "-5", Type: "uint"},
+		},
+		{
+			name: "invalid component modal proxy",
+			handle: func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse {
+				return componentRouter.build(modalRouter, loader)(context.Background(), interaction)
+			},
+			interaction: mockInteraction(&objects.ApplicationComponentInteractionData{
+				CustomID:      "/modal/a",
+				ComponentType: objects.ComponentTypeButton,
+			}),
+			expectsKind: InteractionKindComponent,
+			expectsErr:  &RouteParamError{Param: "n", Value: "a", Type: "int"},
+		},
+		{
+			name: "valid modal",
+			handle: func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse {
+				return modalRouter.build(loader)(context.Background(), interaction)
+			},
+			interaction: mockInteraction(&objects.ApplicationModalInteractionData{CustomID: "/modal/5"}),
+		},
+		{
+			name: "invalid modal",
+			handle: func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse {
+				return modalRouter.build(loader)(context.Background(), interaction)
+			},
+			interaction:  mockInteraction(&objects.ApplicationModalInteractionData{CustomID: "/modal/a"}),
+			expectsRoute: "/modal/:n<int>",
+			expectsKind:  InteractionKindModal,
+			expectsErr:   &RouteParamError{Param: "n", Value: "a", Type: "int"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+			modalRouter.build(loaderPassthrough{})
+			var errResult error
+			var infoResult *ErrorInfo
+			tt.handle(loaderPassthrough{
+				rest: dummyRestClient,
+				contextErrHandler: func(err error, info *ErrorInfo) *objects.InteractionResponse {
+					errResult = err
+					infoResult = info
+					return nil
+				},
+			}, tt.interaction)
+
+			if tt.expectsErr == nil {
+				assert.True(t, called)
+				assert.NoError(t, errResult)
+				return
+			}
+			assert.False(t, called)
+			var routerErr *RouterError
+			require.ErrorAs(t, errResult, &routerErr)
+			assert.Equal(t, tt.expectsErr, routerErr.Err)
+			assert.Equal(t, tt.expectsRoute, routerErr.Route)
+			assert.Equal(t, tt.expectsKind, infoResult.Kind)
+			assert.ErrorIs(t, errResult, InvalidRouteParam)
+		})
+	}
+}
+
+func Test_routePath(t *testing.T) {
+	assert.Equal(t, "/set/:number", routePath("/set/:number<uint>"))
+}
@@ -1,7 +1,6 @@
 package router
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/Postcord/interactions"
@@ -19,6 +18,10 @@ type loaderBuilder struct {
 	commands              *CommandRouter
 	modals                *ModalRouter
 	errHandler            ErrorHandler
+	contextErrHandler     ContextErrorHandler
+	logger                Logger
+	instrumentation       Instrumentation
+	notFoundHandler       NotFoundHandler
 	app                   HandlerAccepter
 }
 
@@ -32,6 +35,11 @@ func (l *loaderBuilder) ErrorHandler(cb ErrorHandler) LoaderBuilder {
 	return l
 }
 
+func (l *loaderBuilder) ContextErrorHandler(cb ContextErrorHandler) LoaderBuilder {
+	l.contextErrHandler = cb
+	return l
+}
+
 func (l *loaderBuilder) CommandRouter(router *CommandRouter) LoaderBuilder {
 	l.commands = router
 	return l
@@ -63,12 +71,19 @@ func (l *loaderBuilder) CombinedRouter(router *CombinedRouter) LoaderBuilder {
 	return l
 }
 
-func genericErrorHandler(err error) *objects.InteractionResponse {
-	// Log the message.
-	fmt.Println("error on route:", err)
+func (l *loaderBuilder) Logger(logger Logger) LoaderBuilder {
+	l.logger = logger
+	return l
+}
 
-	// Pass off to Postcord/interaction's generic handler by setting to nil.
-	return nil
+func (l *loaderBuilder) Instrumentation(instrumentation Instrumentation) LoaderBuilder {
+	l.instrumentation = instrumentation
+	return l
+}
+
+func (l *loaderBuilder) NotFoundHandler(handler NotFoundHandler) LoaderBuilder {
+	l.notFoundHandler = handler
+	return l
 }
 
 func (l *loaderBuilder) AllowedMentions(config *objects.AllowedMentions) LoaderBuilder {
@@ -90,17 +105,37 @@ type HandlerAccepter interface {
 type loaderPassthrough struct {
 	rest                  rest.RESTClient
 	errHandler            ErrorHandler
+	contextErrHandler     ContextErrorHandler
+	logger                Logger
+	instrumentation       Instrumentation
+	notFoundHandler       NotFoundHandler
 	modalRouter           *ModalRouter
 	globalAllowedMentions *objects.AllowedMentions
 	generateFrames        bool
+
+	// Defines a hook which is called with every handled error before the nearest handler is picked.
+	errHook func(error)
+}
+
+// Gets the logger. If this was not built by the loader, nothing is logged.
+func (l loaderPassthrough) log() Logger {
+	return loggerOrNop(l.logger)
 }
 
 func (l *loaderBuilder) Build(app HandlerAccepter) LoaderBuilder {
 	l.app = app
-	cb := l.errHandler
-	if cb == nil {
-		// Defines a generic error handler if the user hasn't made their own.
-		cb = genericErrorHandler
+	logger := l.logger
+	if logger == nil {
+		logger = defaultLogger{}
+	}
+	contextCb := l.contextErrHandler
+	if contextCb == nil && l.errHandler == nil {
+		// Defines a generic error handler if the user hasn't made their own. This logs the error and passes off to
+		// Postcord/interaction's generic handler by returning nil.
+		contextCb = func(err error, info *ErrorInfo) *objects.InteractionResponse {
+			logger.Error("error on route", append(logAttrs(info.Kind, info.Route, info.Interaction), "error", err)...)
+			return nil
+		}
 	}
 
 	generateFrames := os.Getenv("POSTCORD_GENERATE_FRAMES") == "1"
@@ -108,7 +143,11 @@ func (l *loaderBuilder) Build(app HandlerAccepter) LoaderBuilder {
 	// Create the passthrough.
 	passthrough := loaderPassthrough{
 		rest:                  app.Rest(),
-		errHandler:            cb,
+		errHandler:            l.errHandler,
+		contextErrHandler:     contextCb,
+		logger:                logger,
+		instrumentation:       l.instrumentation,
+		notFoundHandler:       l.notFoundHandler,
 		modalRouter:           l.modals,
 		globalAllowedMentions: l.globalAllowedMentions,
 		generateFrames:        generateFrames,
@@ -161,9 +200,25 @@ type LoaderBuilder interface {
 	// ErrorHandler is used to add an error handler to the load process.
 	ErrorHandler(ErrorHandler) LoaderBuilder
 
+	// ContextErrorHandler is used to add an error handler which is also given information about where the error
+	// happened to the load process. If this is set, it is used instead of the ErrorHandler.
+	ContextErrorHandler(ContextErrorHandler) LoaderBuilder
+
 	// AllowedMentions allows you to set a global allowed mentions configuration.
 	AllowedMentions(*objects.AllowedMentions) LoaderBuilder
 
+	// Logger is used to set the logger used by the router. A *slog.Logger can be used here. By default, warnings and
+	// errors are logged with the standard logger.
+	Logger(Logger) LoaderBuilder
+
+	// Instrumentation is used to set the hooks which are called when interactions are dispatched and when REST calls
+	// are made through a context. A *PrometheusExporter can be used here.
+	Instrumentation(Instrumentation) LoaderBuilder
+
+	// NotFoundHandler is used to set the handler used when a command, component, or modal does not match a route. This
+	// is overridden by the not found handler of a router.
+	NotFoundHandler(NotFoundHandler) LoaderBuilder
+
 	// Build is used to execute the build.
 	Build(app HandlerAccepter) LoaderBuilder
 
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/Postcord/objects"
 	"github.com/Postcord/rest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRouterBuilder(t *testing.T) {
@@ -74,6 +76,56 @@ func TestLoaderBuilder_ErrorHandler(t *testing.T) {
 	}
 }
 
+func TestLoaderBuilder_ContextErrorHandler(t *testing.T) {
+	handler := func(error, *ErrorInfo) *objects.InteractionResponse {
+		return nil
+	}
+	l := RouterLoader().(*loaderBuilder)
+	l.ContextErrorHandler(handler)
+	assert.Equal(t, reflect.ValueOf(handler).Pointer(), reflect.ValueOf(l.contextErrHandler).Pointer())
+}
+
+func TestLoaderBuilder_Logger(t *testing.T) {
+	logger := &recordingLogger{}
+	l := RouterLoader().(*loaderBuilder)
+	l.Logger(logger)
+	assert.Same(t, logger, l.logger)
+
+	// Check the logger is used by the default error handler.
+	r := &ComponentRouter{}
+	r.RegisterButton("/a", func(*ComponentRouterCtx) error { return NotOwner })
+	app := &fakeBuildHandlerAccepter{}
+	l.ComponentRouter(r).Build(app)
+	resp := app.componentHandler(context.Background(), mockInteraction(&objects.ApplicationComponentInteractionData{
+		CustomID:      "/a",
+		ComponentType: objects.ComponentTypeButton,
+	}))
+	assert.Nil(t, resp)
+	entries := logger.get()
+	require.Len(t, entries, 2)
+	assert.Equal(t, logEntry{"error", "error on route", []any{
+		"kind", "component", "route", "/a",
+		"interaction_id", objects.Snowflake(1234), "guild_id", objects.Snowflake(1234), "user_id", objects.Snowflake(123),
+		"error", NotOwner,
+	}}, entries[1])
+}
+
+func TestLoaderBuilder_Instrumentation(t *testing.T) {
+	p := &PrometheusExporter{}
+	l := RouterLoader().(*loaderBuilder)
+	l.Instrumentation(p)
+	assert.Same(t, p, l.instrumentation)
+}
+
+func TestLoaderBuilder_NotFoundHandler(t *testing.T) {
+	handler := func(*NotFoundInfo) *objects.InteractionResponse {
+		return nil
+	}
+	l := RouterLoader().(*loaderBuilder)
+	l.NotFoundHandler(handler)
+	assert.Equal(t, reflect.ValueOf(handler).Pointer(), reflect.ValueOf(l.notFoundHandler).Pointer())
+}
+
 func TestLoaderBuilder_ComponentRouter(t *testing.T) {
 	tests := []struct {
 		name string
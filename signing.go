@@ -0,0 +1,150 @@
+package router
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Postcord/objects"
+)
+
+// InvalidCustomIDSignature is returned when a custom ID is not signed or the signature does not match any of the keys.
+var InvalidCustomIDSignature = errors.New("custom ID signature is invalid")
+
+// CustomIDExpired is returned when a signed custom ID has expired.
+var CustomIDExpired = errors.New("custom ID has expired")
+
+// CustomIDWrongUser is returned when a signed custom ID was signed for a different user than the one using it.
+var CustomIDWrongUser = errors.New("custom ID was signed for a different user")
+
+// Used to get the current time when checking the expiry. This is a variable so it can be mocked in tests.
+var signerNow = time.Now
+
+// Defines the separator between the path and the signature fields.
+const customIDSignatureSeparator = "~"
+
+// SignOptions is used to define the optional fields which are signed with the path.
+type SignOptions struct {
+	// User is the ID of the user who is allowed to use the custom ID. If this is zero, anyone can use it.
+	User objects.Snowflake
+
+	// Expires is when the custom ID stops being valid. If this is zero, it does not expire.
+	Expires time.Time
+}
+
+// CustomIDSigner is used to sign custom IDs with a truncated HMAC so that users cannot change the parameters in them.
+// The signature and the optional fields are appended to the path, so with the default length, the signed custom ID is
+// up to 33 characters longer than the path. Remember that Discord limits custom IDs to 100 characters.
+type CustomIDSigner struct {
+	// Keys are the HMAC keys. The first key is used to sign custom IDs, and all of the keys are tried when verifying.
+	// To rotate keys, add the new key to the start and remove the old key once the custom IDs signed with it are no
+	// longer in use. There must be at least one key.
+	Keys [][]byte
+
+	// Length is the number of bytes of the HMAC which are kept. If this is not positive, this defaults to 8.
+	Length int
+}
+
+// Gets the truncated HMAC of the message with the key.
+func (s *CustomIDSigner) mac(key []byte, msg string) []byte {
+	length := s.Length
+	if length <= 0 {
+		length = 8
+	}
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(msg))
+	sum := h.Sum(nil)
+	if length < len(sum) {
+		sum = sum[:length]
+	}
+	return sum
+}
+
+// Sign is used to sign the path. The options can be nil. This panics if there are no keys.
+func (s *CustomIDSigner) Sign(path string, opts *SignOptions) string {
+	if len(s.Keys) == 0 {
+		panic("postcord: custom ID signer has no keys")
+	}
+	var user, expires string
+	if opts != nil {
+		if opts.User != 0 {
+			user = strconv.FormatUint(uint64(opts.User), 36)
+		}
+		if !opts.Expires.IsZero() {
+			expires = strconv.FormatInt(opts.Expires.Unix(), 36)
+		}
+	}
+	msg := path + customIDSignatureSeparator + user + customIDSignatureSeparator + expires
+	return msg + customIDSignatureSeparator + base64.RawURLEncoding.EncodeToString(s.mac(s.Keys[0], msg))
+}
+
+// Verify is used to verify the custom ID for the user using it. If it is valid, the path is returned. If it is not,
+// InvalidCustomIDSignature, CustomIDExpired, or CustomIDWrongUser is returned.
+func (s *CustomIDSigner) Verify(customID string, user objects.Snowflake) (string, error) {
+	// Split the signature from the end so the path can contain the separator.
+	msg, encodedSig, ok := cutLast(customID, customIDSignatureSeparator)
+	if !ok {
+		return "", InvalidCustomIDSignature
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil {
+		return "", InvalidCustomIDSignature
+	}
+	valid := false
+	for _, key := range s.Keys {
+		if hmac.Equal(sig, s.mac(key, msg)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return "", InvalidCustomIDSignature
+	}
+
+	// Get the fields. These are signed, so they are in the format we wrote them in.
+	fields, expires, _ := cutLast(msg, customIDSignatureSeparator)
+	path, signedUser, ok := cutLast(fields, customIDSignatureSeparator)
+	if !ok {
+		return "", InvalidCustomIDSignature
+	}
+	if signedUser != "" && signedUser != strconv.FormatUint(uint64(user), 36) {
+		return "", CustomIDWrongUser
+	}
+	if expires != "" {
+		unix, err := strconv.ParseInt(expires, 36, 64)
+		if err != nil {
+			return "", InvalidCustomIDSignature
+		}
+		if !signerNow().Before(time.Unix(unix, 0)) {
+			return "", CustomIDExpired
+		}
+	}
+	return path, nil
+}
+
+// Cuts the string around the last instance of the separator.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i != -1 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// Verifies the custom ID with the signer if it is set. Void custom IDs are not signed since they do not run a route, and
+// state custom IDs are not signed if the router has a state store since the path is stored on the server. The boolean is
+// true if verification was skipped for one of these, in which case the custom ID must only be routed by the router
+// which made it.
+func verifyCustomID(signer *CustomIDSigner, hasStateStore bool, customID string, interaction *objects.Interaction) (string, bool, error) {
+	if signer == nil {
+		return customID, false, nil
+	}
+	if strings.HasPrefix(customID, "/_postcord/void/") || (hasStateStore && strings.HasPrefix(customID, stateCustomIDPrefix)) {
+		return customID, true, nil
+	}
+	path, err := signer.Verify(customID, interactionUserID(interaction))
+	return path, false, err
+}
@@ -0,0 +1,416 @@
+package router
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockSignerNow(t *testing.T, now time.Time) {
+	t.Helper()
+	signerNow = func() time.Time { return now }
+	t.Cleanup(func() { signerNow = time.Now })
+}
+
+func TestCustomIDSigner(t *testing.T) {
+	mockSignerNow(t, time.Unix(1000, 0))
+	oldKey, newKey := []byte("old"), []byte("new")
+
+	tests := []struct {
+		name string
+
+		signer   *CustomIDSigner
+		verifier *CustomIDSigner
+		path     string
+		opts     *SignOptions
+		user     objects.Snowflake
+		tamper   func(customID string) string
+
+		expectsErr error
+	}{
+		{
+			name:     "no options",
+			signer:   &CustomIDSigner{Keys: [][]byte{newKey}},
+			verifier: &CustomIDSigner{Keys: [][]byte{newKey}},
+			path:     "/ban/1234",
+		},
+		{
+			name:     "path with separator",
+			signer:   &CustomIDSigner{Keys: [][]byte{newKey}},
+			verifier: &CustomIDSigner{Keys: [][]byte{newKey}},
+			path:     "/a~b/~",
+		},
+		{
+			name:     "custom length",
+			signer:   &CustomIDSigner{Keys: [][]byte{newKey}, Length: 32},
+			verifier: &CustomIDSigner{Keys: [][]byte{newKey}, Length: 32},
+			path:     "/a",
+		},
+		{
+			name:     "negative length",
+			signer:   &CustomIDSigner{Keys: [][]byte{newKey}, Length: -1},
+			verifier: &CustomIDSigner{Keys: [][]byte{newKey}},
+			path:     "/a",
+		},
+		{
+			name:       "different length",
+			signer:     &CustomIDSigner{Keys: [][]byte{newKey}, Length: 4},
+			verifier:   &CustomIDSigner{Keys: [][]byte{newKey}},
+			path:       "/a",
+			expectsErr: InvalidCustomIDSignature,
+		},
+		{
+			name:     "rotated key",
+			signer:   &CustomIDSigner{Keys: [][]byte{oldKey}},
+			verifier: &CustomIDSigner{Keys: [][]byte{newKey, oldKey}},
+			path:     "/a",
+		},
+		{
+			name:       "removed key",
+			signer:     &CustomIDSigner{Keys: [][]byte{oldKey}},
+			verifier:   &CustomIDSigner{Keys: [][]byte{newKey}},
+			path:       "/a",
+			expectsErr: InvalidCustomIDSignature,
+		},
+		{
+			name:     "tampered path",
+			signer:   &CustomIDSigner{Keys: [][]byte{newKey}},
+			verifier: &CustomIDSigner{Keys: [][]byte{newKey}},
+			path:     "/ban/1234",
+			tamper: func(customID string) string {
+				return strings.Replace(customID, "1234", "4321", 1)
+			},
+			expectsErr: InvalidCustomIDSignature,
+		},
+		{
+			name:     "tampered signature",
+			signer:   &CustomIDSigner{Keys: [][]byte{newKey}},
+			verifier: &CustomIDSigner{Keys: [][]byte{newKey}},
+			path:     "/a",
+			tamper: func(customID string) string {
+				return customID[:strings.LastIndex(customID, "~")+1] + "AAAAAAAAAAA"
+			},
+			expectsErr: InvalidCustomIDSignature,
+		},
+		{
+			name:     "invalid signature encoding",
+			signer:   &CustomIDSigner{Keys: [][]byte{newKey}},
+			verifier: &CustomIDSigner{Keys: [][]byte{newKey}},
+			path:     "/a",
+			tamper: func(customID string) string {
+				return customID + "!"
+			},
+			expectsErr: InvalidCustomIDSignature,
+		},
+		{
+			name:     "unsigned",
+			verifier: &CustomIDSigner{Keys: [][]byte{newKey}},
+			tamper: func(string) string {
+				return "/ban/1234"
+			},
+			expectsErr: InvalidCustomIDSignature,
+		},
+		{
+			name:     "correct user",
+			signer:   &CustomIDSigner{Keys: [][]byte{newKey}},
+			verifier: &CustomIDSigner{Keys: [][]byte{newKey}},
+			path:     "/a",
+			opts:     &SignOptions{User: 123},
+			user:     123,
+		},
+		{
+			name:       "wrong user",
+			signer:     &CustomIDSigner{Keys: [][]byte{newKey}},
+			verifier:   &CustomIDSigner{Keys: [][]byte{newKey}},
+			path:       "/a",
+			opts:       &SignOptions{User: 123},
+			user:       456,
+			expectsErr: CustomIDWrongUser,
+		},
+		{
+			name:     "not expired",
+			signer:   &CustomIDSigner{Keys: [][]byte{newKey}},
+			verifier: &CustomIDSigner{Keys: [][]byte{newKey}},
+			path:     "/a",
+			opts:     &SignOptions{Expires: time.Unix(1001, 0)},
+		},
+		{
+			name:       "expired",
+			signer:     &CustomIDSigner{Keys: [][]byte{newKey}},
+			verifier:   &CustomIDSigner{Keys: [][]byte{newKey}},
+			path:       "/a",
+			opts:       &SignOptions{Expires: time.Unix(1000, 0)},
+			expectsErr: CustomIDExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var customID string
+			if tt.signer != nil {
+				customID = tt.signer.Sign(tt.path, tt.opts)
+				assert.True(t, strings.HasPrefix(customID, tt.path+"~"))
+			}
+			if tt.tamper != nil {
+				customID = tt.tamper(customID)
+			}
+			path, err := tt.verifier.Verify(customID, tt.user)
+			if tt.expectsErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.path, path)
+			} else {
+				assert.Equal(t, tt.expectsErr, err)
+				assert.Empty(t, path)
+			}
+		})
+	}
+}
+
+func TestCustomIDSigner_Sign(t *testing.T) {
+	s := &CustomIDSigner{Keys: [][]byte{[]byte("key")}}
+	assert.Len(t, s.Sign("", nil), 3+11)
+	assert.Equal(t, s.Sign("/a", nil), (&CustomIDSigner{Keys: s.Keys, Length: -1}).Sign("/a", nil))
+	assert.Len(t, s.Sign("", &SignOptions{User: ^objects.Snowflake(0), Expires: time.Unix(1<<31, 0)}), 33)
+	assert.PanicsWithValue(t, "postcord: custom ID signer has no keys", func() {
+		(&CustomIDSigner{}).Sign("/a", nil)
+	})
+}
+
+func Test_verifyCustomID(t *testing.T) {
+	signer := &CustomIDSigner{Keys: [][]byte{[]byte("key")}}
+	interaction := &objects.Interaction{User: &objects.User{DiscordBaseObject: objects.DiscordBaseObject{ID: 123}}}
+
+	tests := []struct {
+		name string
+
+		signer        *CustomIDSigner
+		hasStateStore bool
+		customID      string
+		expects       string
+		expectsSkip   bool
+		expectsErr    error
+	}{
+		{
+			name:     "no signer",
+			customID: "/a",
+			expects:  "/a",
+		},
+		{
+			name:        "void",
+			signer:      signer,
+			customID:    "/_postcord/void/1",
+			expects:     "/_postcord/void/1",
+			expectsSkip: true,
+		},
+		{
+			name:          "state with a state store",
+			signer:        signer,
+			hasStateStore: true,
+			customID:      "/_postcord/state/a",
+			expects:       "/_postcord/state/a",
+			expectsSkip:   true,
+		},
+		{
+			name:       "state without a state store",
+			signer:     signer,
+			customID:   "/_postcord/state/a",
+			expectsErr: InvalidCustomIDSignature,
+		},
+		{
+			name:       "unsigned",
+			signer:     signer,
+			customID:   "/a",
+			expectsErr: InvalidCustomIDSignature,
+		},
+		{
+			name:     "signed for the user",
+			signer:   signer,
+			customID: signer.Sign("/a", &SignOptions{User: 123}),
+			expects:  "/a",
+		},
+		{
+			name:       "signed for a different user",
+			signer:     signer,
+			customID:   signer.Sign("/a", &SignOptions{User: 456}),
+			expectsErr: CustomIDWrongUser,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, skipped, err := verifyCustomID(tt.signer, tt.hasStateStore, tt.customID, interaction)
+			assert.Equal(t, tt.expectsErr, err)
+			assert.Equal(t, tt.expects, path)
+			assert.Equal(t, tt.expectsSkip, skipped)
+		})
+	}
+}
+
+func TestComponentRouter_SignCustomID(t *testing.T) {
+	c := &ComponentRouter{}
+	assert.Equal(t, "/a", c.SignCustomID("/a", nil))
+	signer := &CustomIDSigner{Keys: [][]byte{[]byte("key")}}
+	c.SetSigner(signer)
+	assert.Equal(t, signer.Sign("/a", &SignOptions{User: 1}), c.SignCustomID("/a", &SignOptions{User: 1}))
+}
+
+func TestSignedCustomIDs(t *testing.T) {
+	signer := &CustomIDSigner{Keys: [][]byte{[]byte("key")}}
+	var called bool
+	componentRouter := &ComponentRouter{}
+	componentRouter.SetSigner(signer)
+	componentRouter.RegisterButton("/ban/:user", func(ctx *ComponentRouterCtx) error {
+		called = true
+		assert.Equal(t, "1234", ctx.Params["user"])
+		return nil
+	})
+	modalRouter := &ModalRouter{}
+	modalRouter.SetSigner(signer)
+	modalRouter.AddModal(&ModalContent{
+		Path: "/modal/:user",
+		Contents: func(ctx *ModalGenerationCtx) (string, []ModalContentItem) {
+			assert.Equal(t, "/modal/1234", ctx.Path)
+			return "Modal", nil
+		},
+		Function: func(ctx *ModalRouterCtx) error {
+			called = true
+			assert.Equal(t, "1234", ctx.Params["user"])
+			ctx.SetContent("hello")
+			return nil
+		},
+	})
+	modalRouter.AddModal(&ModalContent{
+		Path: "/_postcord/void/:n/modal",
+		Contents: func(*ModalGenerationCtx) (string, []ModalContentItem) {
+			called = true
+			return "Modal", nil
+		},
+	})
+	modalRouter.build(loaderPassthrough{})
+
+	component := func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse {
+		return componentRouter.build(modalRouter, loader)(context.Background(), interaction)
+	}
+	modal := func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse {
+		return modalRouter.build(loader)(context.Background(), interaction)
+	}
+
+	tests := []struct {
+		name string
+
+		handle      func(loader loaderPassthrough, interaction *objects.Interaction) *objects.InteractionResponse
+		interaction *objects.Interaction
+		expectsErr  error
+		expectsKind InteractionKind
+	}{
+		{
+			name:   "signed component",
+			handle: component,
+			interaction: mockInteraction(&objects.ApplicationComponentInteractionData{
+				CustomID:      signer.Sign("/ban/1234", nil),
+				ComponentType: objects.ComponentTypeButton,
+			}),
+		},
+		{
+			name:   "unsigned component",
+			handle: component,
+			interaction: mockInteraction(&objects.ApplicationComponentInteractionData{
+				CustomID:      "/ban/1234",
+				ComponentType: objects.ComponentTypeButton,
+			}),
+			expectsErr:  InvalidCustomIDSignature,
+			expectsKind: InteractionKindComponent,
+		},
+		{
+			name:   "component for a different user",
+			handle: component,
+			interaction: mockInteraction(&objects.ApplicationComponentInteractionData{
+				CustomID:      signer.Sign("/ban/1234", &SignOptions{User: 456}),
+				ComponentType: objects.ComponentTypeButton,
+			}),
+			expectsErr:  CustomIDWrongUser,
+			expectsKind: InteractionKindComponent,
+		},
+		{
+			name:   "void component",
+			handle: component,
+			interaction: mockInteraction(&objects.ApplicationComponentInteractionData{
+				CustomID:      "/_postcord/void/1",
+				ComponentType: objects.ComponentTypeButton,
+			}),
+		},
+		{
+			name:   "state component without a state store",
+			handle: component,
+			interaction: mockInteraction(&objects.ApplicationComponentInteractionData{
+				CustomID:      "/_postcord/state/a",
+				ComponentType: objects.ComponentTypeButton,
+			}),
+			expectsErr:  InvalidCustomIDSignature,
+			expectsKind: InteractionKindComponent,
+		},
+		{
+			name:        "signed modal",
+			handle:      modal,
+			interaction: mockInteraction(&objects.ApplicationModalInteractionData{CustomID: signer.Sign("/modal/1234", nil)}),
+		},
+		{
+			name:        "unsigned modal",
+			handle:      modal,
+			interaction: mockInteraction(&objects.ApplicationModalInteractionData{CustomID: "/modal/1234"}),
+			expectsErr:  InvalidCustomIDSignature,
+			expectsKind: InteractionKindModal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+			var errResult error
+			var infoResult *ErrorInfo
+			tt.handle(loaderPassthrough{
+				rest: dummyRestClient,
+				contextErrHandler: func(err error, info *ErrorInfo) *objects.InteractionResponse {
+					errResult = err
+					infoResult = info
+					return nil
+				},
+			}, tt.interaction)
+
+			if tt.expectsErr == nil {
+				assert.NoError(t, errResult)
+				if !strings.HasPrefix(tt.name, "void") {
+					assert.True(t, called)
+				}
+				return
+			}
+			assert.False(t, called)
+			var routerErr *RouterError
+			require.ErrorAs(t, errResult, &routerErr)
+			assert.Equal(t, tt.expectsErr, routerErr.Err)
+			assert.Equal(t, tt.expectsKind, infoResult.Kind)
+		})
+	}
+
+	t.Run("void custom ID is not proxied to the modal router", func(t *testing.T) {
+		called = false
+		resp := component(loaderPassthrough{rest: dummyRestClient}, mockInteraction(&objects.ApplicationComponentInteractionData{
+			CustomID:      "/_postcord/void/1/modal",
+			ComponentType: objects.ComponentTypeButton,
+		}))
+		assert.Nil(t, resp)
+		assert.False(t, called)
+	})
+
+	t.Run("modal response", func(t *testing.T) {
+		resp := component(loaderPassthrough{rest: dummyRestClient}, mockInteraction(&objects.ApplicationComponentInteractionData{
+			CustomID:      signer.Sign("/modal/1234", nil),
+			ComponentType: objects.ComponentTypeButton,
+		}))
+		require.NotNil(t, resp)
+		assert.Equal(t, objects.ResponseModal, resp.Type)
+		assert.Equal(t, signer.Sign("/modal/1234", &SignOptions{User: 123}), resp.Data.CustomID)
+	})
+}
@@ -0,0 +1,161 @@
+package router
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// StateStore is used to store the state of components on the server so it does not need to fit in the custom ID. The
+// values are stored with a key which is put in the custom ID. Implementations must be safe for concurrent use.
+type StateStore interface {
+	// Set is used to store the value with the key. The value should be deleted once the TTL has passed.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Get is used to get the value for the key. The boolean is false if the key does not exist or has expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+}
+
+// DefaultStateTTL is used to define how long state is stored for when the TTL is zero.
+const DefaultStateTTL = 15 * time.Minute
+
+// NoStateStore is returned when state is stored on a component router without a state store.
+var NoStateStore = errors.New("the component router does not have a state store")
+
+// StateExpired is passed to the error handler when a component with state is used after the state has expired and
+// there is no state expired handler.
+var StateExpired = errors.New("the state of the component has expired")
+
+// NoState is returned when the state is decoded in a component which was not made with StoreState.
+var NoState = errors.New("the component does not have any state")
+
+// Defines the prefix of the custom IDs which use the state store.
+const stateCustomIDPrefix = "/_postcord/state/"
+
+// Used to get the current time in the memory state store. This is a variable so it can be mocked in tests.
+var stateNow = time.Now
+
+// Defines what is stored in the state store.
+type storedState struct {
+	Path  string          `json:"path"`
+	State json.RawMessage `json:"state"`
+}
+
+// Defines an item in the memory state store.
+type memoryStateItem struct {
+	value   []byte
+	expires time.Time
+}
+
+// MemoryStateStore is used to define a state store which keeps the state in memory. Note that the state is lost when
+// the application restarts, and is not shared between instances. The zero value is ready to use.
+type MemoryStateStore struct {
+	mu        sync.Mutex
+	items     map[string]memoryStateItem
+	lastSweep time.Time
+}
+
+// Set implements the StateStore interface.
+func (m *MemoryStateStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := stateNow()
+	if m.items == nil {
+		m.items = map[string]memoryStateItem{}
+	}
+
+	// Remove the expired items at most once a minute so the map does not grow forever.
+	if now.Sub(m.lastSweep) >= time.Minute {
+		for k, v := range m.items {
+			if !now.Before(v.expires) {
+				delete(m.items, k)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	m.items[key] = memoryStateItem{value: value, expires: now.Add(ttl)}
+	return nil
+}
+
+// Get implements the StateStore interface.
+func (m *MemoryStateStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	item, ok := m.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !stateNow().Before(item.expires) {
+		delete(m.items, key)
+		return nil, false, nil
+	}
+	return item.value, true, nil
+}
+
+// SetStateStore is used to set the state store used by StoreState.
+func (c *ComponentRouter) SetStateStore(s StateStore) {
+	c.stateStore = s
+}
+
+// SetStateExpiredHandler is used to set the handler used when a component with state is used after the state has
+// expired. If this is not set, StateExpired is passed to the error handler as a router error.
+func (c *ComponentRouter) SetStateExpiredHandler(h NotFoundHandler) {
+	c.stateExpiredHandler = h
+}
+
+// StoreState is used to store the state in the state store and get a short custom ID for it. When the component is
+// used, the path is routed as if it was the custom ID, and the state can be decoded with the State function of the
+// context. The state is encoded as JSON. If the TTL is zero, DefaultStateTTL is used. Since the path is stored on the
+// server, it can be longer than a custom ID and does not need to be signed.
+func (c *ComponentRouter) StoreState(ctx context.Context, path string, state any, ttl time.Duration) (string, error) {
+	if c.stateStore == nil {
+		return "", NoStateStore
+	}
+	if ttl == 0 {
+		ttl = DefaultStateTTL
+	}
+	encodedState, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	value, err := json.Marshal(storedState{Path: path, State: encodedState})
+	if err != nil {
+		return "", err
+	}
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	key := base64.RawURLEncoding.EncodeToString(b)
+	if err := c.stateStore.Set(ctx, key, value, ttl); err != nil {
+		return "", err
+	}
+	return stateCustomIDPrefix + key, nil
+}
+
+// Loads the state for the custom ID from the store. The boolean is false if the state has expired.
+func (c *ComponentRouter) loadState(ctx context.Context, customID string) (*storedState, bool, error) {
+	value, ok, err := c.stateStore.Get(ctx, customID[len(stateCustomIDPrefix):])
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	var s storedState
+	if err := json.Unmarshal(value, &s); err != nil {
+		return nil, false, err
+	}
+	return &s, true, nil
+}
+
+// State is used to decode the state of the component into the pointer specified. If the component was not made with
+// StoreState, NoState is returned.
+func (c *ComponentRouterCtx) State(v any) error {
+	if c.state == nil {
+		return NoState
+	}
+	return json.Unmarshal(c.state, v)
+}
@@ -0,0 +1,255 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ StateStore = (*MemoryStateStore)(nil)
+
+// Makes the state time return the value pointed to.
+func mockStateNow(t *testing.T, now *time.Time) {
+	t.Helper()
+	stateNow = func() time.Time { return *now }
+	t.Cleanup(func() { stateNow = time.Now })
+}
+
+type recordingStateStore struct {
+	key   string
+	value []byte
+	ttl   time.Duration
+	err   error
+}
+
+func (r *recordingStateStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	r.key, r.value, r.ttl = key, value, ttl
+	return r.err
+}
+
+func (r *recordingStateStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	if r.err != nil {
+		return nil, false, r.err
+	}
+	return r.value, key == r.key, nil
+}
+
+func TestMemoryStateStore(t *testing.T) {
+	now := time.Unix(0, 0)
+	mockStateNow(t, &now)
+	ctx := context.Background()
+	m := &MemoryStateStore{}
+
+	// Check a missing key.
+	_, ok, err := m.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Check getting a key before it expires.
+	require.NoError(t, m.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, m.Set(ctx, "b", []byte("2"), 2*time.Minute))
+	now = now.Add(time.Minute - 1)
+	value, ok, err := m.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+
+	// Check the key expires.
+	now = now.Add(1)
+	_, ok, err = m.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.NotContains(t, m.items, "a")
+
+	// Check expired keys are swept when setting.
+	now = now.Add(time.Minute)
+	require.NoError(t, m.Set(ctx, "c", []byte("3"), time.Minute))
+	assert.NotContains(t, m.items, "b")
+	assert.Contains(t, m.items, "c")
+}
+
+func TestComponentRouter_StoreState(t *testing.T) {
+	t.Run("no state store", func(t *testing.T) {
+		_, err := (&ComponentRouter{}).StoreState(context.Background(), "/a", 1, 0)
+		assert.Equal(t, NoStateStore, err)
+	})
+
+	t.Run("default ttl", func(t *testing.T) {
+		store := &recordingStateStore{}
+		c := &ComponentRouter{}
+		c.SetStateStore(store)
+		customID, err := c.StoreState(context.Background(), "/a/:b", map[string]int{"c": 1}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "/_postcord/state/"+store.key, customID)
+		assert.Len(t, store.key, 16)
+		assert.Equal(t, DefaultStateTTL, store.ttl)
+		assert.JSONEq(t, `{"path":"/a/:b","state":{"c":1}}`, string(store.value))
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		store := &recordingStateStore{}
+		c := &ComponentRouter{}
+		c.SetStateStore(store)
+		_, err := c.StoreState(context.Background(), "/a", nil, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, store.ttl)
+	})
+
+	t.Run("unique keys", func(t *testing.T) {
+		c := &ComponentRouter{}
+		c.SetStateStore(&MemoryStateStore{})
+		a, err := c.StoreState(context.Background(), "/a", nil, 0)
+		require.NoError(t, err)
+		b, err := c.StoreState(context.Background(), "/a", nil, 0)
+		require.NoError(t, err)
+		assert.NotEqual(t, a, b)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		c := &ComponentRouter{}
+		c.SetStateStore(&recordingStateStore{err: errEditFailed})
+		_, err := c.StoreState(context.Background(), "/a", nil, 0)
+		assert.Equal(t, errEditFailed, err)
+	})
+
+	t.Run("encoding error", func(t *testing.T) {
+		c := &ComponentRouter{}
+		c.SetStateStore(&recordingStateStore{})
+		_, err := c.StoreState(context.Background(), "/a", func() {}, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestComponentRouterCtx_State(t *testing.T) {
+	var v int
+	assert.Equal(t, NoState, (&ComponentRouterCtx{}).State(&v))
+	assert.NoError(t, (&ComponentRouterCtx{state: []byte("1")}).State(&v))
+	assert.Equal(t, 1, v)
+}
+
+type componentState struct {
+	Users []objects.Snowflake `json:"users"`
+}
+
+func TestComponentState(t *testing.T) {
+	now := time.Unix(0, 0)
+	mockStateNow(t, &now)
+	expiredResp := &objects.InteractionResponse{Type: objects.ResponseChannelMessageWithSource}
+	errStore := errors.New("store is down")
+
+	tests := []struct {
+		name string
+
+		setup func(c *ComponentRouter)
+		store func(t *testing.T, c *ComponentRouter) string
+
+		expectsState *componentState
+		expectsErr   error
+		expectsResp  *objects.InteractionResponse
+	}{
+		{
+			name: "state",
+			store: func(t *testing.T, c *ComponentRouter) string {
+				customID, err := c.StoreState(context.Background(), "/ban/5", componentState{Users: []objects.Snowflake{1, 2}}, time.Minute)
+				require.NoError(t, err)
+				return customID
+			},
+			expectsState: &componentState{Users: []objects.Snowflake{1, 2}},
+		},
+		{
+			name: "signed router",
+			setup: func(c *ComponentRouter) {
+				c.SetSigner(&CustomIDSigner{Keys: [][]byte{[]byte("key")}})
+			},
+			store: func(t *testing.T, c *ComponentRouter) string {
+				customID, err := c.StoreState(context.Background(), "/ban/5", componentState{}, time.Minute)
+				require.NoError(t, err)
+				return customID
+			},
+			expectsState: &componentState{},
+		},
+		{
+			name: "expired",
+			store: func(t *testing.T, c *ComponentRouter) string {
+				customID, err := c.StoreState(context.Background(), "/ban/5", componentState{}, time.Minute)
+				require.NoError(t, err)
+				now = now.Add(time.Minute)
+				return customID
+			},
+			expectsErr: StateExpired,
+		},
+		{
+			name: "expired handler",
+			setup: func(c *ComponentRouter) {
+				c.SetStateExpiredHandler(func(info *NotFoundInfo) *objects.InteractionResponse {
+					assert.Equal(t, InteractionKindComponent, info.Kind)
+					assert.True(t, strings.HasPrefix(info.ID, "/_postcord/state/"))
+					return expiredResp
+				})
+			},
+			store: func(*testing.T, *ComponentRouter) string {
+				return "/_postcord/state/unknown"
+			},
+			expectsResp: expiredResp,
+		},
+		{
+			name: "store error",
+			setup: func(c *ComponentRouter) {
+				c.SetStateStore(&recordingStateStore{err: errStore})
+			},
+			store: func(*testing.T, *ComponentRouter) string {
+				return "/_postcord/state/a"
+			},
+			expectsErr: errStore,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = time.Unix(0, 0)
+			var state *componentState
+			c := &ComponentRouter{}
+			c.SetStateStore(&MemoryStateStore{})
+			c.RegisterButton("/ban/:n<int>", func(ctx *ComponentRouterCtx) error {
+				n, err := ctx.ParamInt("n")
+				assert.NoError(t, err)
+				assert.Equal(t, int64(5), n)
+				state = &componentState{}
+				return ctx.State(state)
+			})
+			if tt.setup != nil {
+				tt.setup(c)
+			}
+			customID := tt.store(t, c)
+
+			var errResult error
+			resp := c.build(nil, loaderPassthrough{
+				rest: dummyRestClient,
+				contextErrHandler: func(err error, _ *ErrorInfo) *objects.InteractionResponse {
+					errResult = err
+					return nil
+				},
+			})(context.Background(), mockInteraction(&objects.ApplicationComponentInteractionData{
+				CustomID:      customID,
+				ComponentType: objects.ComponentTypeButton,
+			}))
+
+			assert.Equal(t, tt.expectsState, state)
+			if tt.expectsResp != nil {
+				assert.Same(t, tt.expectsResp, resp)
+			}
+			if tt.expectsErr == nil {
+				assert.NoError(t, errResult)
+			} else {
+				var routerErr *RouterError
+				require.ErrorAs(t, errResult, &routerErr)
+				assert.Equal(t, tt.expectsErr, routerErr.Err)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Postcord/objects"
+)
+
+// StructCommandParent is used to define the types which a struct command can be added to.
+type StructCommandParent interface {
+	*CommandRouter | *CommandGroup
+}
+
+// UnsupportedStructField is thrown when a field in a struct command has a type which cannot be mapped to an option.
+var UnsupportedStructField = errors.New("field type cannot be mapped to an option")
+
+// InvalidStructTag is thrown when a tag in a struct command cannot be parsed.
+var InvalidStructTag = errors.New("invalid struct tag")
+
+// MissingStructDescription is thrown when a field in a struct command does not have a description tag.
+var MissingStructDescription = errors.New("field must have a description tag")
+
+// Defines the option types for the non-primitive field types which are supported.
+var structOptionTypes = map[reflect.Type]objects.ApplicationCommandOptionType{
+	reflect.TypeOf((*ResolvableUser)(nil)).Elem():        objects.TypeUser,
+	reflect.TypeOf(objects.User{}):                       objects.TypeUser,
+	reflect.TypeOf(objects.GuildMember{}):                objects.TypeUser,
+	reflect.TypeOf((*ResolvableChannel)(nil)).Elem():     objects.TypeChannel,
+	reflect.TypeOf(objects.Channel{}):                    objects.TypeChannel,
+	reflect.TypeOf((*ResolvableRole)(nil)).Elem():        objects.TypeRole,
+	reflect.TypeOf(objects.Role{}):                       objects.TypeRole,
+	reflect.TypeOf((*ResolvableMentionable)(nil)).Elem(): objects.TypeMentionable,
+	reflect.TypeOf((*ResolvableAttachment)(nil)).Elem():  objects.TypeAttachment,
+	reflect.TypeOf(objects.Attachment{}):                 objects.TypeAttachment,
+}
+
+// Gets the option type for the field type. Pointers are unwrapped since they are used for optional options.
+func structOptionType(t reflect.Type) (objects.ApplicationCommandOptionType, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if optionType, ok := structOptionTypes[t]; ok {
+		return optionType, true
+	}
+	if t == snowflakeType {
+		// A snowflake could be any resolvable type.
+		return 0, false
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return objects.TypeString, true
+	case reflect.Bool:
+		return objects.TypeBoolean, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return objects.TypeInteger, true
+	case reflect.Float32, reflect.Float64:
+		return objects.TypeNumber, true
+	}
+	return 0, false
+}
+
+// Defines an option parsed from a struct field.
+type structOption struct {
+	optionType  objects.ApplicationCommandOptionType
+	name        string
+	description string
+	required    bool
+	choices     []objects.ApplicationCommandOptionChoice
+	configs     []OptionConfig
+}
+
+// Parses a choice value from a tag into the type of the option.
+func parseStructChoiceValue(optionType objects.ApplicationCommandOptionType, value string) (any, error) {
+	switch optionType {
+	case objects.TypeString:
+		return value, nil
+	case objects.TypeInteger:
+		return strconv.Atoi(value)
+	case objects.TypeNumber:
+		return strconv.ParseFloat(value, 64)
+	default:
+		return nil, errors.New("choices are only supported on string, int, and double options")
+	}
+}
+
+// Parses the option from the struct field. If the field is not tagged, nil is returned.
+func parseStructOption(field reflect.StructField) (*structOption, error) {
+	tag := field.Tag.Get(selectorTagName)
+	if tag == "" || !field.IsExported() {
+		return nil, nil
+	}
+	optionType, ok := structOptionType(field.Type)
+	if !ok {
+		return nil, UnsupportedStructField
+	}
+
+	// Discord requires a description for every option.
+	description := field.Tag.Get("description")
+	if description == "" {
+		return nil, MissingStructDescription
+	}
+
+	// Parse the name and flags. Pointers and fields with defaults are optional.
+	name, flags, _ := strings.Cut(tag, ",")
+	_, hasDefault := field.Tag.Lookup("default")
+	option := &structOption{
+		optionType:  optionType,
+		name:        name,
+		description: description,
+		required:    field.Type.Kind() != reflect.Ptr && !hasDefault,
+	}
+	if flags != "" {
+		for _, flag := range strings.Split(flags, ",") {
+			if flag != "optional" {
+				return nil, fmt.Errorf("%w: unknown flag %q", InvalidStructTag, flag)
+			}
+			option.required = false
+		}
+	}
+
+	// Parse the choices. These are in the format "Name=value,Name 2=value 2".
+	if choices := field.Tag.Get("choices"); choices != "" {
+		for _, choice := range strings.Split(choices, ",") {
+			choiceName, value, ok := strings.Cut(choice, "=")
+			if !ok {
+				return nil, fmt.Errorf("%w: choice %q must be in the format name=value", InvalidStructTag, choice)
+			}
+			parsed, err := parseStructChoiceValue(optionType, value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: choice %q: %v", InvalidStructTag, choice, err)
+			}
+			option.choices = append(option.choices, objects.ApplicationCommandOptionChoice{Name: choiceName, Value: parsed})
+		}
+	}
+
+	// Parse the constraints.
+	for _, v := range [...]struct {
+		tag  string
+		conf func(float64) OptionConfig
+	}{
+		{"min_value", OptionMinValue},
+		{"max_value", OptionMaxValue},
+		{"min_length", func(f float64) OptionConfig { return OptionMinLength(int(f)) }},
+		{"max_length", func(f float64) OptionConfig { return OptionMaxLength(int(f)) }},
+	} {
+		if s := field.Tag.Get(v.tag); s != "" {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %v", InvalidStructTag, v.tag, err)
+			}
+			option.configs = append(option.configs, v.conf(f))
+		}
+	}
+	if s := field.Tag.Get("channel_types"); s != "" {
+		var types []objects.ChannelType
+		for _, x := range strings.Split(s, ",") {
+			n, err := strconv.ParseUint(x, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: channel_types: %v", InvalidStructTag, err)
+			}
+			types = append(types, objects.ChannelType(n))
+		}
+		option.configs = append(option.configs, OptionChannelTypes(types...))
+	}
+	return option, nil
+}
+
+// Adds the option to the builder.
+func (c *commandBuilder[T]) addStructOption(option *structOption) {
+	o := &objects.ApplicationCommandOption{
+		OptionType:  option.optionType,
+		Name:        option.name,
+		Description: option.description,
+		Required:    option.required,
+		Choices:     option.choices,
+	}
+	c.cmd.configureOption(o, option.configs)
+	c.cmd.Options = append(c.cmd.Options, o)
+}
+
+// Adds the options from the struct type to the builder. Embedded structs are flattened the same way as when binding.
+func (c *commandBuilder[T]) addStructOptions(t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get(selectorTagName), ",")
+		if field.Anonymous && name == "" && isNestedBindStruct(field.Type) {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				if !field.IsExported() {
+					// Bind would never be able to allocate this.
+					return fmt.Errorf("failed to parse field %s: %w", field.Name, UnexportedBindPointer)
+				}
+				embedded = embedded.Elem()
+			}
+			if err := c.addStructOptions(embedded); err != nil {
+				return err
+			}
+			continue
+		}
+		option, err := parseStructOption(field)
+		if err != nil {
+			return fmt.Errorf("failed to parse field %s: %w", field.Name, err)
+		}
+		if option != nil {
+			c.addStructOption(option)
+		}
+	}
+	return nil
+}
+
+// Builds the command from the struct type.
+func buildStructCommand[T, Args any](c *commandBuilder[T], description string, handler func(*CommandRouterCtx, *Args) error) (*Command, error) {
+	argsType := reflect.TypeOf((*Args)(nil)).Elem()
+	if argsType.Kind() != reflect.Struct {
+		return nil, errors.New("struct command arguments must be a struct")
+	}
+	if err := c.addStructOptions(argsType); err != nil {
+		return nil, err
+	}
+	c.Description(description)
+	c.Handler(func(ctx *CommandRouterCtx) error {
+		var args Args
+		if err := ctx.Bind(&args); err != nil {
+			return err
+		}
+		return handler(ctx, &args)
+	})
+	return c.Build()
+}
+
+// StructCommand is used to add a command to the router or group which has its options defined by the fields of the
+// Args struct. The name of each option is set with the "discord" tag, which can have ",optional" appended to make the
+// option optional. Pointer fields and fields with a "default" tag are also optional. The option type is taken from the
+// field type, which can be any type supported by Bind other than objects.Snowflake. Embedded structs are flattened. Each
+// option must have a "description" tag setting its description, and the following tags can also be used:
+//   - "choices" sets the static choices in the format "Name=value,Name 2=value 2".
+//   - "min_value" and "max_value" set the range of an int or double option.
+//   - "min_length" and "max_length" set the length range of a string option.
+//   - "channel_types" sets the channel types of a channel option as a comma separated list of numbers.
+//
+// When the command is invoked, the options are bound to a new Args struct which is passed to the handler.
+func StructCommand[Args any, P StructCommandParent](parent P, name, description string, handler func(*CommandRouterCtx, *Args) error) (*Command, error) {
+	switch x := any(parent).(type) {
+	case *CommandRouter:
+		return buildStructCommand(x.NewCommandBuilder(name).(*commandBuilder[CommandBuilder]), description, handler)
+	default:
+		return buildStructCommand(x.(*CommandGroup).NewCommandBuilder(name).(subcommandBuilder).commandBuilder, description, handler)
+	}
+}
+
+// MustStructCommand calls StructCommand but must succeed. If not, it will panic.
+func MustStructCommand[Args any, P StructCommandParent](parent P, name, description string, handler func(*CommandRouterCtx, *Args) error) *Command {
+	cmd, err := StructCommand(parent, name, description, handler)
+	if err != nil {
+		panic(err)
+	}
+	return cmd
+}
@@ -0,0 +1,196 @@
+package router
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Postcord/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type structCommandCommon struct {
+	Note string `discord:"note,optional" description:"The note"`
+}
+
+type structCommandArgs struct {
+	User    ResolvableUser    `discord:"user" description:"The user"`
+	Mode    string            `discord:"mode" description:"The mode" choices:"Soft=soft,Hard=hard"`
+	Days    int               `discord:"days,optional" description:"The days" min_value:"0" max_value:"7"`
+	Reason  string            `discord:"reason,optional" description:"The reason" min_length:"1" max_length:"512"`
+	Channel ResolvableChannel `discord:"channel,optional" description:"The channel" channel_types:"0,5"`
+	Silent  *bool             `discord:"silent" description:"Whether to ban silently"`
+	Ignored string
+	structCommandCommon
+}
+
+func TestStructCommand(t *testing.T) {
+	r := &CommandRouter{}
+	var called *structCommandArgs
+	handler := func(_ *CommandRouterCtx, args *structCommandArgs) error {
+		called = args
+		return nil
+	}
+	cmd, err := StructCommand(r, "ban", "Bans a user", handler)
+	require.NoError(t, err)
+	g := r.MustNewCommandGroup("mod", "Moderation", nil)
+	subcmd := MustStructCommand(g, "ban", "Bans a user", handler)
+	assert.Same(t, subcmd, g.Subcommands["ban"])
+	assert.Same(t, cmd, r.roots.Subcommands["ban"])
+
+	// Check the options are derived from the struct.
+	min, max := 0., 7.
+	minLength, maxLength := 1, 512
+	assert.Equal(t, "Bans a user", cmd.Description)
+	assert.Equal(t, []*objects.ApplicationCommandOption{
+		{OptionType: objects.TypeUser, Name: "user", Description: "The user", Required: true},
+		{
+			OptionType: objects.TypeString, Name: "mode", Description: "The mode", Required: true,
+			Choices: []objects.ApplicationCommandOptionChoice{{Name: "Soft", Value: "soft"}, {Name: "Hard", Value: "hard"}},
+		},
+		{OptionType: objects.TypeInteger, Name: "days", Description: "The days", MinValue: "0", MaxValue: "7"},
+		{OptionType: objects.TypeString, Name: "reason", Description: "The reason"},
+		{
+			OptionType: objects.TypeChannel, Name: "channel", Description: "The channel",
+			ChannelTypes: []objects.ChannelType{objects.ChannelTypeGuildText, objects.ChannelTypeGuildNews},
+		},
+		{OptionType: objects.TypeBoolean, Name: "silent", Description: "Whether to ban silently"},
+		{OptionType: objects.TypeString, Name: "note", Description: "The note"},
+	}, cmd.Options)
+	assert.Equal(t, map[string]*OptionConstraints{
+		"days":    {MinValue: &min, MaxValue: &max},
+		"reason":  {MinLength: &minLength, MaxLength: &maxLength},
+		"channel": {ChannelTypes: []objects.ChannelType{objects.ChannelTypeGuildText, objects.ChannelTypeGuildNews}},
+	}, cmd.OptionConstraints)
+
+	// Check the handler gets the populated struct.
+	user := (ResolvableUser)(resolvableUser{resolvable[objects.User]{id: "1"}})
+	require.NoError(t, cmd.Function(&CommandRouterCtx{Command: cmd, Options: map[string]any{
+		"user": user,
+		"mode": "soft",
+		"days": 3,
+		"note": "hello",
+	}}))
+	require.NotNil(t, called)
+	assert.Equal(t, &structCommandArgs{
+		User: user, Mode: "soft", Days: 3, structCommandCommon: structCommandCommon{Note: "hello"},
+	}, called)
+}
+
+func TestStructCommand_errors(t *testing.T) {
+	tests := []struct {
+		name string
+
+		build func(r *CommandRouter) error
+
+		expectsErr error
+	}{
+		{
+			name: "unsupported field",
+			build: func(r *CommandRouter) error {
+				_, err := StructCommand(r, "a", "", func(*CommandRouterCtx, *struct {
+					X objects.Snowflake `discord:"x"`
+				}) error {
+					return nil
+				})
+				return err
+			},
+			expectsErr: UnsupportedStructField,
+		},
+		{
+			name: "unknown flag",
+			build: func(r *CommandRouter) error {
+				_, err := StructCommand(r, "a", "", func(*CommandRouterCtx, *struct {
+					X string `discord:"x,bad" description:"x"`
+				}) error {
+					return nil
+				})
+				return err
+			},
+			expectsErr: InvalidStructTag,
+		},
+		{
+			name: "invalid choice",
+			build: func(r *CommandRouter) error {
+				_, err := StructCommand(r, "a", "", func(*CommandRouterCtx, *struct {
+					X int `discord:"x" description:"x" choices:"One=one"`
+				}) error {
+					return nil
+				})
+				return err
+			},
+			expectsErr: InvalidStructTag,
+		},
+		{
+			name: "invalid constraint",
+			build: func(r *CommandRouter) error {
+				_, err := StructCommand(r, "a", "", func(*CommandRouterCtx, *struct {
+					X int `discord:"x" description:"x" min_value:"one"`
+				}) error {
+					return nil
+				})
+				return err
+			},
+			expectsErr: InvalidStructTag,
+		},
+		{
+			name: "required after optional",
+			build: func(r *CommandRouter) error {
+				_, err := StructCommand(r, "a", "", func(*CommandRouterCtx, *struct {
+					X int `discord:"x,optional" description:"x"`
+					Y int `discord:"y" description:"y"`
+				}) error {
+					return nil
+				})
+				return err
+			},
+			expectsErr: RequiredOptionAfterOptional,
+		},
+		{
+			name: "missing description",
+			build: func(r *CommandRouter) error {
+				_, err := StructCommand(r, "a", "", func(*CommandRouterCtx, *struct {
+					X string `discord:"x"`
+				}) error {
+					return nil
+				})
+				return err
+			},
+			expectsErr: MissingStructDescription,
+		},
+		{
+			name: "missing description in embedded struct",
+			build: func(r *CommandRouter) error {
+				type embedded struct {
+					X string `discord:"x"`
+				}
+				_, err := StructCommand(r, "a", "", func(*CommandRouterCtx, *struct{ embedded }) error {
+					return nil
+				})
+				return err
+			},
+			expectsErr: MissingStructDescription,
+		},
+		{
+			name: "embedded pointer to an unexported struct",
+			build: func(r *CommandRouter) error {
+				type embedded struct {
+					X string `discord:"x" description:"x"`
+				}
+				_, err := StructCommand(r, "a", "", func(*CommandRouterCtx, *struct{ *embedded }) error {
+					return nil
+				})
+				return err
+			},
+			expectsErr: UnexportedBindPointer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &CommandRouter{}
+			err := tt.build(r)
+			assert.True(t, errors.Is(err, tt.expectsErr), err)
+			assert.Empty(t, r.roots.Subcommands)
+		})
+	}
+}
@@ -33,6 +33,10 @@ type genericRunner interface {
 func TestComponent(t TestingT, b LoaderBuilder, path string) {
 	// Get everything we need from the loader.
 	r, _, _, errHandler, restOrigin, allowedMentions := b.CurrentChain()
+	var contextErrHandler ContextErrorHandler
+	if x, ok := b.(*loaderBuilder); ok {
+		contextErrHandler = x.contextErrHandler
+	}
 
 	// Get the Postcord regen env var.
 	regen := 0
@@ -48,7 +52,7 @@ func TestComponent(t TestingT, b LoaderBuilder, path string) {
 	require.NotNil(t, r)
 
 	// Get the filesystem friendly version of the path.
-	fsSafePath := strings.ReplaceAll(path, "/", "_")
+	fsSafePath := strings.ReplaceAll(routePath(path), "/", "_")
 
 	// Create the folder path.
 	folderPath := filepath.Join("testframes", "components", fsSafePath)
@@ -84,13 +88,15 @@ func TestComponent(t TestingT, b LoaderBuilder, path string) {
 		var returnedErr error
 		respExpected := true
 		errHandlerOverride := func(err error) *objects.InteractionResponse {
-			returnedErr = err
 			if errHandler != nil {
 				return errHandler(err)
 			}
 			respExpected = false
 			return nil
 		}
+		errHook := func(err error) {
+			returnedErr = err
+		}
 
 		// Define the test.
 		test := func(t TestingT) {
@@ -110,11 +116,13 @@ func TestComponent(t TestingT, b LoaderBuilder, path string) {
 
 			// Create the components handler.
 			handler := r.build(nil, loaderPassthrough{
-				rest:       restClient,
-				errHandler: errHandlerOverride,
+				rest:              restClient,
+				errHandler:        errHandlerOverride,
+				contextErrHandler: contextErrHandler,
 
 				globalAllowedMentions: allowedMentions,
 				generateFrames:        false,
+				errHook:               errHook,
 			})
 
 			// Run the handler.
@@ -167,6 +175,10 @@ func TestComponent(t TestingT, b LoaderBuilder, path string) {
 func testCommand(t TestingT, b LoaderBuilder, autocomplete bool, commandRoute ...string) {
 	// Get everything we need from the loader.
 	_, r, _, errHandler, restOrigin, allowedMentions := b.CurrentChain()
+	var contextErrHandler ContextErrorHandler
+	if x, ok := b.(*loaderBuilder); ok {
+		contextErrHandler = x.contextErrHandler
+	}
 
 	// Get the Postcord regen env var.
 	regen := 0
@@ -220,13 +232,15 @@ func testCommand(t TestingT, b LoaderBuilder, autocomplete bool, commandRoute ..
 		var returnedErr error
 		respExpected := true
 		errHandlerOverride := func(err error) *objects.InteractionResponse {
-			returnedErr = err
 			if errHandler != nil {
 				return errHandler(err)
 			}
 			respExpected = false
 			return nil
 		}
+		errHook := func(err error) {
+			returnedErr = err
+		}
 
 		// Define the test.
 		test := func(t TestingT) {
@@ -251,8 +265,10 @@ func testCommand(t TestingT, b LoaderBuilder, autocomplete bool, commandRoute ..
 			cmdHandler, autoCompleteHandler := r.build(loaderPassthrough{
 				rest:                  restClient,
 				errHandler:            errHandlerOverride,
+				contextErrHandler:     contextErrHandler,
 				globalAllowedMentions: allowedMentions,
 				generateFrames:        false,
+				errHook:               errHook,
 			})
 
 			// Run the handler.
@@ -316,3 +332,54 @@ func TestCommand(t TestingT, b LoaderBuilder, commandRoute ...string) {
 func TestAutocomplete(t TestingT, b LoaderBuilder, commandRoute ...string) {
 	testCommand(t, b, true, commandRoute...)
 }
+
+// TestCommandSync is used to check that the command router is in sync with the commands recorded for the application.
+// The REST tape of a dry run sync is loaded from "testframes/sync/<application ID>.json". If the router would make any
+// changes to the commands, the test fails with the plan. Regenerating with POSTCORD_REGEN=all records a new tape.
+func TestCommandSync(t TestingT, b LoaderBuilder, appID objects.Snowflake) {
+	// Get everything we need from the loader.
+	_, r, _, _, restOrigin, _ := b.CurrentChain()
+
+	// Make sure the command router isn't nil.
+	require.NotNil(t, r)
+
+	// Load the tape if we are not regenerating.
+	fp := filepath.Join("testframes", "sync", appID.String()+".json")
+	regen := strings.ToLower(os.Getenv("POSTCORD_REGEN")) == "all"
+	var restClient rest.RESTClient
+	if regen {
+		restClient = &restTape{
+			rest: restOrigin,
+			tape: &tape{},
+		}
+	} else {
+		data, err := os.ReadFile(fp)
+		if os.IsNotExist(err) {
+			// Just return here. There's nothing to actually look at.
+			return
+		}
+		require.NoError(t, err)
+		var recorded tape
+		require.NoError(t, json.Unmarshal(data, &recorded))
+		restClient = &restTapePlayer{
+			t:    t,
+			tape: recorded,
+		}
+	}
+
+	// Run the sync as a dry run.
+	report, err := r.Sync(context.Background(), restClient, appID, &SyncOptions{DryRun: true})
+	require.NoError(t, err)
+
+	// Write the tape if this was a regen.
+	if regen {
+		require.NoError(t, os.MkdirAll(filepath.Dir(fp), 0777))
+		require.NoError(t, os.WriteFile(fp, mustMarshal(t, true, *restClient.(*restTape).tape), 0644))
+		return
+	}
+
+	// Fail if there are any changes. The whole report is included so guild changes are shown.
+	if report.HasChanges() {
+		t.Fatalf("commands are out of sync:\n%s", mustMarshal(t, true, report))
+	}
+}
@@ -1,9 +1,59 @@
 package router
 
-import "testing"
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Postcord/objects"
+	"github.com/Postcord/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
 
 func Test_TestComponent(t *testing.T) {
-	// TODO
+	// Run in a temporary directory so the frames are not written to the package.
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	t.Run("route error handler", func(t *testing.T) {
+		r := &ComponentRouter{}
+		r.RegisterButton("/boom", func(ctx *ComponentRouterCtx) error {
+			return errors.New("boom")
+		})
+		r.SetRouteErrorHandler("/boom", func(err error, _ *ErrorInfo) *objects.InteractionResponse {
+			return &objects.InteractionResponse{
+				Type: objects.ResponseUpdateMessage,
+				Data: &objects.InteractionApplicationCommandCallbackData{Content: err.Error()},
+			}
+		})
+
+		// Write a frame which expects the error to be captured.
+		folderPath := filepath.Join("testframes", "components", strings.ReplaceAll(routePath("/boom"), "/", "_"))
+		require.NoError(t, os.MkdirAll(folderPath, 0755))
+		f := frame{
+			Request: mockInteraction(objects.ApplicationComponentInteractionData{
+				CustomID:      "/boom",
+				ComponentType: objects.ComponentTypeButton,
+			}),
+			Error: "boom",
+			Response: &objects.InteractionResponse{
+				Type: objects.ResponseUpdateMessage,
+				Data: &objects.InteractionApplicationCommandCallbackData{Content: "boom"},
+			},
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(folderPath, "1.json"), mustMarshal(t, true, f), 0644))
+
+		TestComponent(t, RouterLoader().ComponentRouter(r), "/boom")
+	})
 }
 
 func Test_TestCommand(t *testing.T) {
@@ -13,3 +63,116 @@ func Test_TestCommand(t *testing.T) {
 func Test_TestAutocomplete(t *testing.T) {
 	// TODO
 }
+
+type restHandlerAccepter struct {
+	fakeBuildHandlerAccepter
+
+	rest *rest.Client
+}
+
+func (r restHandlerAccepter) Rest() *rest.Client {
+	return r.rest
+}
+
+func Test_TestCommandSync(t *testing.T) {
+	// Run in a temporary directory so the tape is not written to the package.
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	// Create a REST client which talks to a fake Discord with a global and a guild command registered.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.RequestURI() {
+		case "/api/v9/applications/1/commands?with_localizations=true":
+			_, _ = w.Write([]byte(`[{"id":"100","application_id":"1","version":"1","name":"ping","description":"pong",` +
+				`"description_localizations":{"fr":"pong"}}]`))
+		case "/api/v9/applications/1/guilds/2/commands?with_localizations=true":
+			_, _ = w.Write([]byte(`[{"id":"200","application_id":"1","guild_id":"2","version":"1","name":"scoped",` +
+				`"description":"scoped"}]`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.RequestURI())
+		}
+	}))
+	defer srv.Close()
+	proxy, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	restClient := rest.New(&rest.Config{Proxy: http.ProxyURL(proxy)})
+
+	router := func(drift string) *CommandRouter {
+		r := &CommandRouter{}
+		r.NewCommandBuilder("ping").Description("pong").
+			DescriptionLocalizations(Localizations{"fr": "pong"}).MustBuild()
+		scoped := "scoped"
+		switch drift {
+		case "global":
+			r.NewCommandBuilder("pong").Description("ping").MustBuild()
+		case "guild":
+			scoped = "drifted"
+		}
+		r.NewCommandBuilder("scoped").Description(scoped).Guilds(2).MustBuild()
+		return r
+	}
+	fp := filepath.Join("testframes", "sync", "1.json")
+
+	// Defines the report printed when the commands are out of sync.
+	type syncReport struct {
+		Changes []struct {
+			Action string `json:"action"`
+			Name   string `json:"name"`
+		} `json:"changes"`
+		Guilds map[string]*syncReport `json:"guilds"`
+	}
+	outOfSync := func(t *testing.T, drift string) *syncReport {
+		m := &testingTTapeItemMatch{T: t}
+		TestCommandSync(m, RouterLoader().CommandRouter(router(drift)), 1)
+		assert.Equal(t, "commands are out of sync:\n%s", m.format)
+		require.Len(t, m.args, 1)
+		var report syncReport
+		require.NoError(t, json.Unmarshal(m.args[0].([]byte), &report))
+		return &report
+	}
+
+	t.Run("no tape", func(t *testing.T) {
+		m := &testingTTapeItemMatch{T: t}
+		TestCommandSync(m, RouterLoader().CommandRouter(router("global")), 1)
+		assert.Empty(t, m.format)
+		assert.NoFileExists(t, fp)
+	})
+
+	t.Run("regen", func(t *testing.T) {
+		t.Setenv("POSTCORD_REGEN", "all")
+		b := RouterLoader().CommandRouter(router("")).Build(&restHandlerAccepter{rest: restClient})
+		TestCommandSync(t, b, 1)
+		data, err := os.ReadFile(fp)
+		require.NoError(t, err)
+		var recorded tape
+		require.NoError(t, json.Unmarshal(data, &recorded))
+		require.Len(t, recorded, 2)
+		assert.Equal(t, "GetDiscordCommands", recorded[0].FuncName)
+		assert.Equal(t, "GetGuildDiscordCommands", recorded[1].FuncName)
+	})
+
+	t.Run("in sync", func(t *testing.T) {
+		m := &testingTTapeItemMatch{T: t}
+		TestCommandSync(m, RouterLoader().CommandRouter(router("")), 1)
+		assert.Empty(t, m.format)
+	})
+
+	t.Run("global drifted", func(t *testing.T) {
+		report := outOfSync(t, "global")
+		require.Len(t, report.Changes, 1)
+		assert.Equal(t, "create", report.Changes[0].Action)
+		assert.Equal(t, "pong", report.Changes[0].Name)
+	})
+
+	t.Run("guild drifted", func(t *testing.T) {
+		report := outOfSync(t, "guild")
+		assert.Empty(t, report.Changes)
+		require.NotNil(t, report.Guilds["2"])
+		require.Len(t, report.Guilds["2"].Changes, 1)
+		assert.Equal(t, "update", report.Guilds["2"].Changes[0].Action)
+		assert.Equal(t, "scoped", report.Guilds["2"].Changes[0].Name)
+	})
+}
@@ -6,6 +6,7 @@ package router
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -17,10 +18,10 @@ import (
 var fakeHandlerValue string
 
 func fakeHandler(val string) *routeContext {
-	return &routeContext{func(reqCtx context.Context, ctx *objects.Interaction, data *objects.ApplicationComponentInteractionData, params map[string]string, rest rest.RESTClient, errHandler ErrorHandler) *objects.InteractionResponse {
+	return &routeContext{i: func(reqCtx context.Context, ctx *objects.Interaction, data *objects.ApplicationComponentInteractionData, params map[string]string, state json.RawMessage, rest rest.RESTClient, scope *errorScope, errHandler ErrorHandler) *objects.InteractionResponse {
 		fakeHandlerValue = val
 		return nil
-	}, val}
+	}, r: val}
 }
 
 type testRequests []struct {
@@ -42,7 +43,7 @@ func checkRequests(t *testing.T, tree *node, requests testRequests) {
 		} else if request.nilHandler {
 			t.Errorf("handle mismatch for route '%s': Expected nil handle", request.path)
 		} else {
-			handler.i.(contextCallback)(nil, nil, nil, nil, nil, nil)
+			handler.i.(contextCallback)(nil, nil, nil, nil, nil, nil, nil, nil)
 			if fakeHandlerValue != request.route {
 				t.Errorf("handle mismatch for route '%s': Wrong handle (%s != %s)", request.path, fakeHandlerValue, request.route)
 			}
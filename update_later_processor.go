@@ -12,15 +12,16 @@ type restEditInteractionResponse interface {
 }
 
 // Process the result and update the webhook.
-func processUpdateLaterResponse(reqCtx context.Context, restClient restEditInteractionResponse, applicationID objects.Snowflake, token string, response *objects.InteractionResponse) {
-	if response.Type == objects.ResponseDeferredMessageUpdate || response.Type == objects.ResponseDeferredChannelMessageWithSource {
-		// We can ignore this! The token will get passed up the chain.
-		return
+func processUpdateLaterResponse(reqCtx context.Context, restClient restEditInteractionResponse, applicationID objects.Snowflake, token string, response *objects.InteractionResponse) error {
+	if response == nil || response.Type == objects.ResponseDeferredMessageUpdate || response.Type == objects.ResponseDeferredChannelMessageWithSource {
+		// We can ignore this! Either the error handler returned nothing or the token will get passed up the chain.
+		return nil
 	}
-	_, _ = restClient.EditOriginalInteractionResponse(reqCtx, applicationID, token, &rest.EditWebhookMessageParams{
+	_, err := restClient.EditOriginalInteractionResponse(reqCtx, applicationID, token, &rest.EditWebhookMessageParams{
 		Content:         response.Data.Content,
 		Embeds:          response.Data.Embeds,
 		AllowedMentions: response.Data.AllowedMentions,
 		Components:      response.Data.Components,
 	})
+	return err
 }
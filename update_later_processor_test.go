@@ -116,7 +116,7 @@ func Test_processUpdateLaterResponse(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			restClient := newMockRestEditInteractionResponse(t)
-			processUpdateLaterResponse(context.Background(), restClient, tt.applicationID, tt.token, tt.response)
+			assert.NoError(t, processUpdateLaterResponse(context.Background(), restClient, tt.applicationID, tt.token, tt.response))
 			assert.Equal(t, tt.restParams, restClient.params)
 		})
 	}